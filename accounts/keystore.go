@@ -0,0 +1,237 @@
+package accounts
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SECRYPT-2022/SECRYPT/crypto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Keys are stored in the Web3 Secret Storage format, version 3
+// https://github.com/ethereum/wiki/wiki/Web3-Secret-Storage-Definition
+const (
+	keystoreVersion = 3
+	keystoreCipher  = "aes-128-ctr"
+	keystoreKDF     = "scrypt"
+
+	// scrypt parameters of the keys created by the node, matching the other clients
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+
+	// lighter scrypt parameters, faster to decrypt at the cost of a weaker encryption
+	LightScryptN = 1 << 12
+	LightScryptP = 6
+
+	scryptR     = 8
+	scryptDKLen = 32
+)
+
+var (
+	ErrDecrypt            = errors.New("could not decrypt key with given password")
+	ErrUnsupportedVersion = errors.New("unsupported keystore version")
+)
+
+type encryptedKey struct {
+	Address string       `json:"address"`
+	Crypto  cryptoParams `json:"crypto"`
+	ID      string       `json:"id"`
+	Version int          `json:"version"`
+}
+
+type cryptoParams struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams cipherParams           `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type cipherParams struct {
+	IV string `json:"iv"`
+}
+
+// encryptKey encrypts the private key with the password, in the keystore format
+func encryptKey(key *ecdsa.PrivateKey, password string, scryptN, scryptP int) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+
+	rawKey, err := crypto.MarshalECDSAPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := aesCTR(derivedKey[:16], rawKey, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	address := crypto.PubKeyToAddress(&key.PublicKey)
+
+	return json.Marshal(&encryptedKey{
+		Address: hex.EncodeToString(address.Bytes()),
+		Crypto: cryptoParams{
+			Cipher:     keystoreCipher,
+			CipherText: hex.EncodeToString(cipherText),
+			CipherParams: cipherParams{
+				IV: hex.EncodeToString(iv),
+			},
+			KDF: keystoreKDF,
+			KDFParams: map[string]interface{}{
+				"n":     scryptN,
+				"r":     scryptR,
+				"p":     scryptP,
+				"dklen": scryptDKLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(crypto.Keccak256(derivedKey[16:32], cipherText)),
+		},
+		ID:      uuid.New().String(),
+		Version: keystoreVersion,
+	})
+}
+
+// decryptKey decrypts the private key of the keystore file with the password
+func decryptKey(keyJSON []byte, password string) (*ecdsa.PrivateKey, error) {
+	var key encryptedKey
+	if err := json.Unmarshal(keyJSON, &key); err != nil {
+		return nil, err
+	}
+
+	if key.Version != keystoreVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, key.Version)
+	}
+
+	if key.Crypto.Cipher != keystoreCipher {
+		return nil, fmt.Errorf("unsupported cipher %s", key.Crypto.Cipher)
+	}
+
+	mac, err := hex.DecodeString(key.Crypto.MAC)
+	if err != nil {
+		return nil, err
+	}
+
+	iv, err := hex.DecodeString(key.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := hex.DecodeString(key.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := deriveKey(&key.Crypto, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(crypto.Keccak256(derivedKey[16:32], cipherText), mac) {
+		return nil, ErrDecrypt
+	}
+
+	rawKey, err := aesCTR(derivedKey[:16], cipherText, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.ParseECDSAPrivateKey(rawKey)
+}
+
+// deriveKey derives the encryption key from the password, using the kdf of the keystore file
+func deriveKey(params *cryptoParams, password string) ([]byte, error) {
+	salt, err := hex.DecodeString(stringParam(params.KDFParams, "salt"))
+	if err != nil {
+		return nil, err
+	}
+
+	dkLen := intParam(params.KDFParams, "dklen")
+
+	switch params.KDF {
+	case keystoreKDF:
+		return scrypt.Key(
+			[]byte(password),
+			salt,
+			intParam(params.KDFParams, "n"),
+			intParam(params.KDFParams, "r"),
+			intParam(params.KDFParams, "p"),
+			dkLen,
+		)
+
+	case "pbkdf2":
+		if prf := stringParam(params.KDFParams, "prf"); prf != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported pbkdf2 prf %s", prf)
+		}
+
+		return pbkdf2.Key([]byte(password), salt, intParam(params.KDFParams, "c"), dkLen, sha256.New), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported kdf %s", params.KDF)
+	}
+}
+
+// keyFileAddress returns the address of the keystore file, without decrypting it
+func keyFileAddress(keyJSON []byte) (types.Address, error) {
+	var key encryptedKey
+	if err := json.Unmarshal(keyJSON, &key); err != nil {
+		return types.ZeroAddress, err
+	}
+
+	buf, err := hex.DecodeString(strings.TrimPrefix(key.Address, "0x"))
+	if err != nil || len(buf) != types.AddressLength {
+		return types.ZeroAddress, fmt.Errorf("invalid keystore address %q", key.Address)
+	}
+
+	return types.BytesToAddress(buf), nil
+}
+
+func aesCTR(key, input, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]byte, len(input))
+	cipher.NewCTR(block, iv).XORKeyStream(output, input)
+
+	return output, nil
+}
+
+func stringParam(params map[string]interface{}, name string) string {
+	value, _ := params[name].(string)
+
+	return value
+}
+
+func intParam(params map[string]interface{}, name string) int {
+	// the numbers are decoded as float64 from json
+	value, _ := params[name].(float64)
+
+	return int(value)
+}
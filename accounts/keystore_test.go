@@ -0,0 +1,74 @@
+package accounts
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/SECRYPT-2022/SECRYPT/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeystore_EncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	keyJSON, err := encryptKey(key, "password", LightScryptN, LightScryptP)
+	assert.NoError(t, err)
+
+	address, err := keyFileAddress(keyJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.PubKeyToAddress(&key.PublicKey), address)
+
+	decrypted, err := decryptKey(keyJSON, "password")
+	assert.NoError(t, err)
+	assert.Equal(t, key.D, decrypted.D)
+
+	_, err = decryptKey(keyJSON, "wrong password")
+	assert.ErrorIs(t, err, ErrDecrypt)
+}
+
+func TestKeystore_DecryptTestVectors(t *testing.T) {
+	t.Parallel()
+
+	// test vectors of the Web3 Secret Storage definition
+	testCases := []struct {
+		name    string
+		keyJSON string
+	}{
+		{
+			"pbkdf2",
+			`{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},` +
+				`"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",` +
+				`"kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256",` +
+				`"salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},` +
+				`"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},` +
+				`"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`,
+		},
+		{
+			"scrypt",
+			`{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},` +
+				`"ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",` +
+				`"kdf":"scrypt","kdfparams":{"dklen":32,"n":262144,"p":8,"r":1,` +
+				`"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},` +
+				`"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},` +
+				`"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := decryptKey([]byte(tc.keyJSON), "testpassword")
+			assert.NoError(t, err)
+
+			rawKey, err := crypto.MarshalECDSAPrivateKey(key)
+			assert.NoError(t, err)
+			assert.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hex.EncodeToString(rawKey))
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/crypto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+var (
+	ErrUnknownAccount = errors.New("unknown account")
+	ErrLocked         = errors.New("account is locked, unlock it first")
+	ErrAccountExists  = errors.New("account already exists")
+)
+
+// Manager manages the accounts of the keystore directory,
+// signing with the keys of the unlocked ones
+type Manager struct {
+	logger hclog.Logger
+	dir    string
+	signer crypto.TxSigner
+
+	// scrypt parameters of the created key files
+	scryptN int
+	scryptP int
+
+	lock sync.Mutex
+
+	// account -> key file path
+	accounts map[types.Address]string
+
+	// account -> decrypted key, until it gets locked again
+	unlocked map[types.Address]*unlockedKey
+}
+
+type unlockedKey struct {
+	key *ecdsa.PrivateKey
+
+	// locks the account at the end of the unlock timeout, nil without timeout
+	timer *time.Timer
+}
+
+// NewManager creates the account manager of the keys in the keystore directory,
+// signing the transactions with the given signer
+func NewManager(logger hclog.Logger, dir string, signer crypto.TxSigner) (*Manager, error) {
+	m := &Manager{
+		logger:   logger.Named("accounts"),
+		dir:      dir,
+		signer:   signer,
+		scryptN:  StandardScryptN,
+		scryptP:  StandardScryptP,
+		accounts: make(map[types.Address]string),
+		unlocked: make(map[types.Address]*unlockedKey),
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the keystore directory %s: %w", dir, err)
+	}
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+
+	m.logger.Info("keystore loaded", "dir", dir, "accounts", len(m.accounts))
+
+	return m, nil
+}
+
+// load reads the addresses of the key files in the keystore directory
+func (m *Manager) load() error {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read the keystore directory %s: %w", m.dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name()[0] == '.' {
+			continue
+		}
+
+		path := filepath.Join(m.dir, entry.Name())
+
+		keyJSON, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read the key file %s: %w", path, err)
+		}
+
+		address, err := keyFileAddress(keyJSON)
+		if err != nil {
+			m.logger.Warn("skipping invalid key file", "path", path, "err", err)
+
+			continue
+		}
+
+		m.accounts[address] = path
+	}
+
+	return nil
+}
+
+// Accounts returns the addresses of the accounts, sorted
+func (m *Manager) Accounts() []types.Address {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	addresses := make([]types.Address, 0, len(m.accounts))
+	for address := range m.accounts {
+		addresses = append(addresses, address)
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].String() < addresses[j].String()
+	})
+
+	return addresses
+}
+
+// NewAccount generates a new key, stored encrypted with the password
+func (m *Manager) NewAccount(password string) (types.Address, error) {
+	key, err := crypto.GenerateECDSAKey()
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return m.ImportKey(key, password)
+}
+
+// ImportRawKey stores the raw private key encrypted with the password
+func (m *Manager) ImportRawKey(rawKey []byte, password string) (types.Address, error) {
+	if len(rawKey) != 32 {
+		return types.ZeroAddress, fmt.Errorf("invalid private key length %d", len(rawKey))
+	}
+
+	key, err := crypto.ParseECDSAPrivateKey(rawKey)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return m.ImportKey(key, password)
+}
+
+// ImportKey stores the private key encrypted with the password
+func (m *Manager) ImportKey(key *ecdsa.PrivateKey, password string) (types.Address, error) {
+	address := crypto.PubKeyToAddress(&key.PublicKey)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.accounts[address]; ok {
+		return types.ZeroAddress, fmt.Errorf("%w: %s", ErrAccountExists, address)
+	}
+
+	keyJSON, err := encryptKey(key, password, m.scryptN, m.scryptP)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	path := filepath.Join(m.dir, keyFileName(address, time.Now()))
+
+	if err := os.WriteFile(path, keyJSON, 0600); err != nil {
+		return types.ZeroAddress, fmt.Errorf("failed to write the key file %s: %w", path, err)
+	}
+
+	m.accounts[address] = path
+
+	m.logger.Info("account created", "address", address)
+
+	return address, nil
+}
+
+// Unlock decrypts the key of the account with the password, for the given duration
+// or until the account is locked again if zero
+func (m *Manager) Unlock(address types.Address, password string, duration time.Duration) error {
+	m.lock.Lock()
+	path, ok := m.accounts[address]
+	m.lock.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownAccount, address)
+	}
+
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the key file %s: %w", path, err)
+	}
+
+	// decrypted without holding the lock, it takes a while
+	key, err := decryptKey(keyJSON, password)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.lockAccount(address)
+
+	unlocked := &unlockedKey{
+		key: key,
+	}
+
+	if duration > 0 {
+		unlocked.timer = time.AfterFunc(duration, func() {
+			m.lock.Lock()
+			defer m.lock.Unlock()
+
+			// the account may have been unlocked again since
+			if m.unlocked[address] == unlocked {
+				delete(m.unlocked, address)
+				m.logger.Debug("account locked", "address", address)
+			}
+		})
+	}
+
+	m.unlocked[address] = unlocked
+
+	m.logger.Debug("account unlocked", "address", address, "duration", duration)
+
+	return nil
+}
+
+// Lock removes the decrypted key of the account
+func (m *Manager) Lock(address types.Address) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.accounts[address]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownAccount, address)
+	}
+
+	m.lockAccount(address)
+
+	return nil
+}
+
+// lockAccount removes the decrypted key of the account, the lock must be held
+func (m *Manager) lockAccount(address types.Address) {
+	unlocked, ok := m.unlocked[address]
+	if !ok {
+		return
+	}
+
+	if unlocked.timer != nil {
+		unlocked.timer.Stop()
+	}
+
+	delete(m.unlocked, address)
+}
+
+// Close locks all the accounts
+func (m *Manager) Close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for address := range m.unlocked {
+		m.lockAccount(address)
+	}
+}
+
+// unlockedKey returns the decrypted key of the account
+func (m *Manager) unlockedKey(address types.Address) (*ecdsa.PrivateKey, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.accounts[address]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAccount, address)
+	}
+
+	unlocked, ok := m.unlocked[address]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrLocked, address)
+	}
+
+	return unlocked.key, nil
+}
+
+// SignHash signs the hash with the key of the unlocked account,
+// returning the signature in the [R || S || V] format, V being 0 or 1
+func (m *Manager) SignHash(address types.Address, hash []byte) ([]byte, error) {
+	key, err := m.unlockedKey(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Sign(key, hash)
+}
+
+// SignTx signs the transaction with the key of its unlocked sender
+func (m *Manager) SignTx(tx *types.Transaction) (*types.Transaction, error) {
+	key, err := m.unlockedKey(tx.From)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := m.signer.SignTx(tx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	signed.ComputeHash()
+
+	return signed, nil
+}
+
+// keyFileName returns the name of the key file, as named by the other clients
+func keyFileName(address types.Address, now time.Time) string {
+	return fmt.Sprintf(
+		"UTC--%s--%x",
+		now.UTC().Format("2006-01-02T15-04-05.000000000Z"),
+		address.Bytes(),
+	)
+}
+
+// TextHash returns the hash signed for a message, prefixed so that it can't be a transaction
+func TextHash(data []byte) []byte {
+	return crypto.Keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(data))), data)
+}
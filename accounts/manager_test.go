@@ -0,0 +1,132 @@
+package accounts
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/crypto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestManager(t *testing.T, dir string) *Manager {
+	t.Helper()
+
+	m, err := NewManager(hclog.NewNullLogger(), dir, crypto.NewEIP155Signer(100))
+	assert.NoError(t, err)
+
+	m.scryptN, m.scryptP = LightScryptN, LightScryptP
+
+	t.Cleanup(m.Close)
+
+	return m
+}
+
+func TestManager_Accounts(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m := newTestManager(t, dir)
+
+	assert.Empty(t, m.Accounts())
+
+	address, err := m.NewAccount("password")
+	assert.NoError(t, err)
+
+	key, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	rawKey, err := crypto.MarshalECDSAPrivateKey(key)
+	assert.NoError(t, err)
+
+	imported, err := m.ImportRawKey(rawKey, "password")
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.PubKeyToAddress(&key.PublicKey), imported)
+
+	_, err = m.ImportRawKey(rawKey, "password")
+	assert.ErrorIs(t, err, ErrAccountExists)
+
+	_, err = m.ImportRawKey(rawKey[:31], "password")
+	assert.Error(t, err)
+
+	assert.ElementsMatch(t, []types.Address{address, imported}, m.Accounts())
+
+	// the accounts are loaded from the keystore directory
+	assert.ElementsMatch(t, []types.Address{address, imported}, newTestManager(t, dir).Accounts())
+}
+
+func TestManager_UnlockAndSign(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t, t.TempDir())
+
+	address, err := m.NewAccount("password")
+	assert.NoError(t, err)
+
+	hash := crypto.Keccak256([]byte("message"))
+
+	_, err = m.SignHash(address, hash)
+	assert.ErrorIs(t, err, ErrLocked)
+
+	_, err = m.SignHash(types.StringToAddress("1"), hash)
+	assert.ErrorIs(t, err, ErrUnknownAccount)
+
+	assert.ErrorIs(t, m.Unlock(address, "wrong password", 0), ErrDecrypt)
+	assert.NoError(t, m.Unlock(address, "password", 0))
+
+	sig, err := m.SignHash(address, hash)
+	assert.NoError(t, err)
+
+	pub, err := crypto.SigToPub(hash, sig)
+	assert.NoError(t, err)
+	assert.Equal(t, address, crypto.PubKeyToAddress(pub))
+
+	tx, err := m.SignTx(&types.Transaction{
+		From:     address,
+		To:       &address,
+		GasPrice: big.NewInt(1),
+		Value:    big.NewInt(0),
+		Gas:      21000,
+	})
+	assert.NoError(t, err)
+
+	sender, err := crypto.NewEIP155Signer(100).Sender(tx)
+	assert.NoError(t, err)
+	assert.Equal(t, address, sender)
+
+	assert.NoError(t, m.Lock(address))
+
+	_, err = m.SignHash(address, hash)
+	assert.ErrorIs(t, err, ErrLocked)
+}
+
+func TestManager_UnlockTimeout(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t, t.TempDir())
+
+	address, err := m.NewAccount("password")
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.Unlock(address, "password", 50*time.Millisecond))
+
+	_, err = m.SignHash(address, crypto.Keccak256([]byte("message")))
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		_, err := m.SignHash(address, crypto.Keccak256([]byte("message")))
+
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// unlocking again without timeout cancels the previous one
+	assert.NoError(t, m.Unlock(address, "password", 50*time.Millisecond))
+	assert.NoError(t, m.Unlock(address, "password", 0))
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = m.SignHash(address, crypto.Keccak256([]byte("message")))
+	assert.NoError(t, err)
+}
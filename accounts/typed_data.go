@@ -0,0 +1,330 @@
+package accounts
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SECRYPT-2022/SECRYPT/crypto"
+)
+
+// type of the domain separator of the typed data
+const typedDataDomain = "EIP712Domain"
+
+var ErrInvalidTypedData = errors.New("invalid typed data")
+
+// TypedDataField is a field of a typed data struct type
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData is the structured data signed per EIP-712
+// https://eips.ethereum.org/EIPS/eip-712
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// UnmarshalJSON decodes the typed data, sent by the wallets either as an object or as a JSON string
+func (t *TypedData) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var raw string
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+
+		data = []byte(raw)
+	}
+
+	type typedData TypedData
+
+	var decoded typedData
+
+	// the numbers are kept as strings, they can exceed the float64 precision
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&decoded); err != nil {
+		return err
+	}
+
+	*t = TypedData(decoded)
+
+	return nil
+}
+
+// Hash returns the hash signed for the typed data
+func (t *TypedData) Hash() ([]byte, error) {
+	domainSeparator, err := t.hashStruct(typedDataDomain, t.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the domain: %w", err)
+	}
+
+	messageHash, err := t.hashStruct(t.PrimaryType, t.Message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the message: %w", err)
+	}
+
+	return crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, messageHash), nil
+}
+
+// hashStruct returns the hash of the struct value of the given type
+func (t *TypedData) hashStruct(typeName string, value map[string]interface{}) ([]byte, error) {
+	fields, ok := t.Types[typeName]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %s", ErrInvalidTypedData, typeName)
+	}
+
+	encoded := crypto.Keccak256([]byte(t.encodeType(typeName)))
+
+	for _, field := range fields {
+		fieldValue, ok := value[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: missing field %s of %s", ErrInvalidTypedData, field.Name, typeName)
+		}
+
+		encodedValue, err := t.encodeValue(field.Type, fieldValue)
+		if err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", field.Name, typeName, err)
+		}
+
+		encoded = append(encoded, encodedValue...)
+	}
+
+	return crypto.Keccak256(encoded), nil
+}
+
+// encodeType returns the type encoding, the struct type followed by the struct types it references sorted by name
+func (t *TypedData) encodeType(typeName string) string {
+	deps := make(map[string]bool)
+	t.dependencies(typeName, deps)
+	delete(deps, typeName)
+
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var buf strings.Builder
+
+	for _, name := range append([]string{typeName}, names...) {
+		fields := make([]string, len(t.Types[name]))
+		for idx, field := range t.Types[name] {
+			fields[idx] = field.Type + " " + field.Name
+		}
+
+		buf.WriteString(name + "(" + strings.Join(fields, ",") + ")")
+	}
+
+	return buf.String()
+}
+
+// dependencies adds the struct types referenced by the type, recursively
+func (t *TypedData) dependencies(typeName string, deps map[string]bool) {
+	typeName = strings.SplitN(typeName, "[", 2)[0]
+
+	if _, ok := t.Types[typeName]; !ok || deps[typeName] {
+		return
+	}
+
+	deps[typeName] = true
+
+	for _, field := range t.Types[typeName] {
+		t.dependencies(field.Type, deps)
+	}
+}
+
+// encodeValue returns the 32 bytes encoding of the value of the given type
+func (t *TypedData) encodeValue(typeName string, value interface{}) ([]byte, error) {
+	// arrays are encoded as the hash of the concatenated encodings of their items
+	if strings.HasSuffix(typeName, "]") {
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s expects an array", ErrInvalidTypedData, typeName)
+		}
+
+		itemType := typeName[:strings.LastIndex(typeName, "[")]
+
+		encoded := make([]byte, 0, 32*len(items))
+
+		for _, item := range items {
+			encodedItem, err := t.encodeValue(itemType, item)
+			if err != nil {
+				return nil, err
+			}
+
+			encoded = append(encoded, encodedItem...)
+		}
+
+		return crypto.Keccak256(encoded), nil
+	}
+
+	if _, ok := t.Types[typeName]; ok {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s expects an object", ErrInvalidTypedData, typeName)
+		}
+
+		return t.hashStruct(typeName, fields)
+	}
+
+	return encodeAtomicValue(typeName, value)
+}
+
+// encodeAtomicValue returns the 32 bytes encoding of the value of a non struct, non array type
+func encodeAtomicValue(typeName string, value interface{}) ([]byte, error) {
+	switch {
+	case typeName == "string":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: string expects a string", ErrInvalidTypedData)
+		}
+
+		return crypto.Keccak256([]byte(str)), nil
+
+	case typeName == "bytes":
+		buf, err := decodeHexValue(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return crypto.Keccak256(buf), nil
+
+	case typeName == "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w: bool expects a boolean", ErrInvalidTypedData)
+		}
+
+		encoded := make([]byte, 32)
+		if b {
+			encoded[31] = 1
+		}
+
+		return encoded, nil
+
+	case typeName == "address":
+		buf, err := decodeHexValue(value)
+		if err != nil || len(buf) != 20 {
+			return nil, fmt.Errorf("%w: invalid address %v", ErrInvalidTypedData, value)
+		}
+
+		return leftPad32(buf), nil
+
+	case strings.HasPrefix(typeName, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(typeName, "bytes"))
+		if err != nil || size < 1 || size > 32 {
+			return nil, fmt.Errorf("%w: unknown type %s", ErrInvalidTypedData, typeName)
+		}
+
+		buf, err := decodeHexValue(value)
+		if err != nil || len(buf) > size {
+			return nil, fmt.Errorf("%w: invalid %s %v", ErrInvalidTypedData, typeName, value)
+		}
+
+		encoded := make([]byte, 32)
+		copy(encoded, buf)
+
+		return encoded, nil
+
+	case strings.HasPrefix(typeName, "uint"), strings.HasPrefix(typeName, "int"):
+		return encodeInteger(typeName, value)
+
+	default:
+		return nil, fmt.Errorf("%w: unknown type %s", ErrInvalidTypedData, typeName)
+	}
+}
+
+// encodeInteger returns the 32 bytes two's complement encoding of the integer
+func encodeInteger(typeName string, value interface{}) ([]byte, error) {
+	signed := strings.HasPrefix(typeName, "int")
+
+	bits, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(typeName, "u"), "int"))
+	if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+		return nil, fmt.Errorf("%w: unknown type %s", ErrInvalidTypedData, typeName)
+	}
+
+	n, err := parseInteger(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// range [min, max)
+	min, max := big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if signed {
+		max.Rsh(max, 1)
+		min.Neg(max)
+	}
+
+	if n.Cmp(min) < 0 || n.Cmp(max) >= 0 {
+		return nil, fmt.Errorf("%w: %s out of range for %s", ErrInvalidTypedData, n, typeName)
+	}
+
+	if n.Sign() < 0 {
+		n.Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+
+	return n.FillBytes(make([]byte, 32)), nil
+}
+
+// parseInteger parses the integer, given as a number, a decimal string or a hex string
+func parseInteger(value interface{}) (*big.Int, error) {
+	var str string
+
+	switch v := value.(type) {
+	case json.Number:
+		str = v.String()
+	case string:
+		str = v
+	case float64:
+		str = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return nil, fmt.Errorf("%w: invalid integer %v", ErrInvalidTypedData, value)
+	}
+
+	n, ok := new(big.Int), false
+
+	if strings.HasPrefix(str, "0x") || strings.HasPrefix(str, "0X") {
+		n, ok = n.SetString(str[2:], 16)
+	} else {
+		n, ok = n.SetString(str, 10)
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid integer %v", ErrInvalidTypedData, value)
+	}
+
+	return n, nil
+}
+
+func decodeHexValue(value interface{}) ([]byte, error) {
+	str, ok := value.(string)
+	if !ok || !strings.HasPrefix(str, "0x") {
+		return nil, fmt.Errorf("%w: invalid hex value %v", ErrInvalidTypedData, value)
+	}
+
+	buf, err := hex.DecodeString(str[2:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid hex value %v", ErrInvalidTypedData, value)
+	}
+
+	return buf, nil
+}
+
+func leftPad32(buf []byte) []byte {
+	encoded := make([]byte, 32)
+	copy(encoded[32-len(buf):], buf)
+
+	return encoded
+}
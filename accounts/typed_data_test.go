@@ -0,0 +1,116 @@
+package accounts
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// example of the EIP-712 specification
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestTypedData_Hash(t *testing.T) {
+	t.Parallel()
+
+	var typedData TypedData
+	assert.NoError(t, json.Unmarshal([]byte(mailTypedData), &typedData))
+
+	assert.Equal(
+		t,
+		"Mail(Person from,Person to,string contents)Person(string name,address wallet)",
+		typedData.encodeType("Mail"),
+	)
+
+	domainSeparator, err := typedData.hashStruct(typedDataDomain, typedData.Domain)
+	assert.NoError(t, err)
+	assert.Equal(t, "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hex.EncodeToString(domainSeparator))
+
+	hash, err := typedData.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(hash))
+
+	// also sent as a JSON string
+	quoted, err := json.Marshal(mailTypedData)
+	assert.NoError(t, err)
+
+	var fromString TypedData
+	assert.NoError(t, json.Unmarshal(quoted, &fromString))
+
+	stringHash, err := fromString.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, stringHash)
+}
+
+func TestTypedData_EncodeAtomicValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		typeName string
+		value    interface{}
+		expected string
+		err      bool
+	}{
+		{"uint from number", "uint256", json.Number("10"), "000000000000000000000000000000000000000000000000000000000000000a", false},
+		{"uint from hex", "uint8", "0xff", "00000000000000000000000000000000000000000000000000000000000000ff", false},
+		{"uint out of range", "uint8", "256", "", true},
+		{"negative uint", "uint256", "-1", "", true},
+		{"negative int", "int8", "-128", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff80", false},
+		{"int out of range", "int8", "128", "", true},
+		{"bool", "bool", true, "0000000000000000000000000000000000000000000000000000000000000001", false},
+		{"fixed bytes", "bytes2", "0x0102", "0102000000000000000000000000000000000000000000000000000000000000", false},
+		{"fixed bytes too long", "bytes1", "0x0102", "", true},
+		{"invalid address", "address", "0x01", "", true},
+		{"unknown type", "fixed128x18", "1", "", true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := encodeAtomicValue(tc.typeName, tc.value)
+			if tc.err {
+				assert.ErrorIs(t, err, ErrInvalidTypedData)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, hex.EncodeToString(encoded))
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/SECRYPT-2022/SECRYPT/blockchain/storage"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 )
 
 // Factory creates a leveldb storage
@@ -35,6 +36,16 @@ func NewLevelDBStorage(path string, logger hclog.Logger) (storage.Storage, error
 	return storage.NewKeyValueStorage(logger.Named("leveldb"), kv), nil
 }
 
+// NewReadOnlyLevelDBStorage opens the leveldb storage in read-only mode, its writes failing
+func NewReadOnlyLevelDBStorage(path string, logger hclog.Logger) (storage.Storage, error) {
+	db, err := leveldb.OpenFile(path, &opt.Options{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.NewKeyValueStorage(logger.Named("leveldb"), &levelDBKV{db}), nil
+}
+
 // levelDBKV is the leveldb implementation of the kv storage
 type levelDBKV struct {
 	db *leveldb.DB
@@ -12,6 +12,7 @@ type Params struct {
 	ChainID        int                    `json:"chainID"`
 	Engine         map[string]interface{} `json:"engine"`
 	Whitelists     *Whitelists            `json:"whitelists,omitempty"`
+	AllowLists     *AllowLists            `json:"allowLists,omitempty"`
 	BlockGasTarget uint64                 `json:"blockGasTarget"`
 }
 
@@ -29,6 +30,23 @@ type Whitelists struct {
 	Deployment []types.Address `json:"deployment,omitempty"`
 }
 
+// AllowLists specifies from which block each contract-managed allow list is enforced.
+// The deployment whitelist is used as the bootstrap admin set of the allow lists
+type AllowLists struct {
+	Deployment  *Fork `json:"deployment,omitempty"`
+	Transaction *Fork `json:"transaction,omitempty"`
+}
+
+// IsDeploymentActive returns true if the deployment allow list is enforced at the given block
+func (a *AllowLists) IsDeploymentActive(block uint64) bool {
+	return a != nil && a.Deployment != nil && a.Deployment.Active(block)
+}
+
+// IsTransactionActive returns true if the transaction allow list is enforced at the given block
+func (a *AllowLists) IsTransactionActive(block uint64) bool {
+	return a != nil && a.Transaction != nil && a.Transaction.Active(block)
+}
+
 // Forks specifies when each fork is activated
 type Forks struct {
 	Homestead      *Fork `json:"homestead,omitempty"`
@@ -39,6 +57,7 @@ type Forks struct {
 	EIP150         *Fork `json:"EIP150,omitempty"`
 	EIP158         *Fork `json:"EIP158,omitempty"`
 	EIP155         *Fork `json:"EIP155,omitempty"`
+	FeeDelegation  *Fork `json:"feeDelegation,omitempty"`
 }
 
 func (f *Forks) active(ff *Fork, block uint64) bool {
@@ -77,6 +96,11 @@ func (f *Forks) IsEIP155(block uint64) bool {
 	return f.active(f.EIP155, block)
 }
 
+// IsFeeDelegation returns true if the fee delegated transactions are accepted at the given block
+func (f *Forks) IsFeeDelegation(block uint64) bool {
+	return f.active(f.FeeDelegation, block)
+}
+
 func (f *Forks) At(block uint64) ForksInTime {
 	return ForksInTime{
 		Homestead:      f.active(f.Homestead, block),
@@ -87,6 +111,7 @@ func (f *Forks) At(block uint64) ForksInTime {
 		EIP150:         f.active(f.EIP150, block),
 		EIP158:         f.active(f.EIP158, block),
 		EIP155:         f.active(f.EIP155, block),
+		FeeDelegation:  f.active(f.FeeDelegation, block),
 	}
 }
 
@@ -114,7 +139,8 @@ type ForksInTime struct {
 	Istanbul,
 	EIP150,
 	EIP158,
-	EIP155 bool
+	EIP155,
+	FeeDelegation bool
 }
 
 var AllForksEnabled = &Forks{
@@ -126,4 +152,5 @@ var AllForksEnabled = &Forks{
 	Constantinople: NewFork(0),
 	Petersburg:     NewFork(0),
 	Istanbul:       NewFork(0),
+	FeeDelegation:  NewFork(0),
 }
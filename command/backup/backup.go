@@ -16,6 +16,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(backupCmd)
+	helper.RegisterIPCClientFlag(backupCmd)
 
 	setFlags(backupCmd)
 	helper.SetRequiredFlags(backupCmd, params.getRequiredFlags())
@@ -54,7 +55,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
-	if err := params.createBackup(helper.GetGRPCAddress(cmd)); err != nil {
+	if err := params.createBackup(helper.GetNodeAddress(cmd)); err != nil {
 		outputter.SetError(err)
 
 		return
@@ -16,6 +16,7 @@ const (
 	JSONOutputFlag  = "json"
 	GRPCAddressFlag = "grpc-address"
 	JSONRPCFlag     = "jsonrpc"
+	IPCFlag         = "ipc"
 )
 
 // GRPCAddressFlagLEGACY Legacy flag that needs to be present to preserve backwards
@@ -1,18 +1,21 @@
 package helper
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/SECRYPT-2022/SECRYPT/chain"
 	"github.com/SECRYPT-2022/SECRYPT/command"
 	ibftOp "github.com/SECRYPT-2022/SECRYPT/consensus/ibft/proto"
 	"github.com/SECRYPT-2022/SECRYPT/helper/common"
+	"github.com/SECRYPT-2022/SECRYPT/helper/ipc"
 	"github.com/SECRYPT-2022/SECRYPT/server"
 	"github.com/SECRYPT-2022/SECRYPT/server/proto"
 	txpoolOp "github.com/SECRYPT-2022/SECRYPT/txpool/proto"
@@ -37,6 +40,9 @@ const (
 	AllInterfacesBinding IPBinding = "0.0.0.0"
 )
 
+// IPCAddressPrefix marks the node addresses that are IPC endpoint paths
+const IPCAddressPrefix = "ipc://"
+
 // HandleSignals is a helper method for handling signals sent to the console
 // Like stop, error, etc.
 func HandleSignals(
@@ -137,9 +143,19 @@ func GetIBFTOperatorClientConnection(address string) (
 	return ibftOp.NewIbftOperatorClient(conn), nil
 }
 
-// GetGRPCConnection returns a grpc client connection
+// GetGRPCConnection returns a grpc client connection,
+// over the IPC endpoint if the address has the IPC prefix
 func GetGRPCConnection(address string) (*grpc.ClientConn, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+
+	if ipcPath := strings.TrimPrefix(address, IPCAddressPrefix); ipcPath != address {
+		address = "passthrough:///" + ipcPath
+		opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ipc.Dial(ipcPath)
+		}))
+	}
+
+	conn, err := grpc.Dial(address, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to server: %w", err)
 	}
@@ -157,11 +173,26 @@ func GetGRPCAddress(cmd *cobra.Command) string {
 	return cmd.Flag(command.GRPCAddressFlag).Value.String()
 }
 
+// GetNodeAddress extracts the address the node is reached at by the commands,
+// the IPC endpoint if it's set or the GRPC address otherwise
+func GetNodeAddress(cmd *cobra.Command) string {
+	if ipcPath := GetIPCPath(cmd); ipcPath != "" {
+		return IPCAddressPrefix + ipcPath
+	}
+
+	return GetGRPCAddress(cmd)
+}
+
 // GetJSONRPCAddress extracts the set JSON-RPC address
 func GetJSONRPCAddress(cmd *cobra.Command) string {
 	return cmd.Flag(command.JSONRPCFlag).Value.String()
 }
 
+// GetIPCPath extracts the set IPC path
+func GetIPCPath(cmd *cobra.Command) string {
+	return cmd.Flag(command.IPCFlag).Value.String()
+}
+
 // GetJSONLogFormat extracts the set JSON Format flag
 func GetJSONLogFormat(cmd *cobra.Command) bool {
 	return cmd.Flag(command.JSONOutputFlag).Changed
@@ -211,6 +242,24 @@ func RegisterJSONRPCFlag(cmd *cobra.Command) {
 	)
 }
 
+// RegisterIPCFlag registers the JSON-RPC IPC path flag for all child commands
+func RegisterIPCFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(
+		command.IPCFlag,
+		"",
+		"the IPC endpoint socket path, serving the JSON-RPC and GRPC APIs, the IPC endpoint is disabled if empty",
+	)
+}
+
+// RegisterIPCClientFlag registers the node IPC path flag for all child commands
+func RegisterIPCClientFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(
+		command.IPCFlag,
+		"",
+		"the IPC endpoint socket path of the node, used instead of the GRPC interface if set",
+	)
+}
+
 // ParseJSONRPCAddress parses the passed in JSONRPC address
 func ParseJSONRPCAddress(jsonrpcAddress string) (*url.URL, error) {
 	return url.ParseRequestURI(jsonrpcAddress)
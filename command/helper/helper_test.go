@@ -0,0 +1,94 @@
+//go:build !windows
+// +build !windows
+
+package helper
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/command"
+	"github.com/SECRYPT-2022/SECRYPT/helper/ipc"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestGetNodeAddress(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			"GRPC address by default",
+			[]string{},
+			"127.0.0.1:9632",
+		},
+		{
+			"set GRPC address",
+			[]string{"--" + command.GRPCAddressFlag, "127.0.0.1:10000"},
+			"127.0.0.1:10000",
+		},
+		{
+			"IPC endpoint over the GRPC address",
+			[]string{"--" + command.GRPCAddressFlag, "127.0.0.1:10000", "--" + command.IPCFlag, "/tmp/secrypt.ipc"},
+			IPCAddressPrefix + "/tmp/secrypt.ipc",
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := &cobra.Command{Use: "test", Run: func(*cobra.Command, []string) {}}
+			RegisterGRPCAddressFlag(cmd)
+			RegisterIPCClientFlag(cmd)
+
+			assert.NoError(t, cmd.ParseFlags(testCase.args))
+			assert.Equal(t, testCase.expected, GetNodeAddress(cmd))
+		})
+	}
+}
+
+func TestGetGRPCConnection_IPC(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "secrypt.ipc")
+
+	lis, err := ipc.Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := GetGRPCConnection(IPCAddressPrefix + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+}
@@ -22,7 +22,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
-	candidatesResponse, err := getIBFTCandidates(helper.GetGRPCAddress(cmd))
+	candidatesResponse, err := getIBFTCandidates(helper.GetNodeAddress(cmd))
 	if err != nil {
 		outputter.SetError(err)
 
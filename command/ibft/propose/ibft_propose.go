@@ -65,7 +65,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
-	if err := params.proposeCandidate(helper.GetGRPCAddress(cmd)); err != nil {
+	if err := params.proposeCandidate(helper.GetNodeAddress(cmd)); err != nil {
 		outputter.SetError(err)
 
 		return
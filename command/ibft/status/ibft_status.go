@@ -22,7 +22,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
-	statusResponse, err := getIBFTStatus(helper.GetGRPCAddress(cmd))
+	statusResponse, err := getIBFTStatus(helper.GetNodeAddress(cmd))
 	if err != nil {
 		outputter.SetError(err)
 
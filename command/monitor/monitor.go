@@ -23,6 +23,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(monitorCmd)
+	helper.RegisterIPCClientFlag(monitorCmd)
 
 	return monitorCmd
 }
@@ -33,7 +34,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 
 	subscribeToEvents(
 		outputter,
-		helper.GetGRPCAddress(cmd),
+		helper.GetNodeAddress(cmd),
 	)
 }
 
@@ -37,7 +37,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
-	if err := params.initSystemClient(helper.GetGRPCAddress(cmd)); err != nil {
+	if err := params.initSystemClient(helper.GetNodeAddress(cmd)); err != nil {
 		outputter.SetError(err)
 
 		return
@@ -24,7 +24,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
-	peersList, err := getPeersList(helper.GetGRPCAddress(cmd))
+	peersList, err := getPeersList(helper.GetNodeAddress(cmd))
 	if err != nil {
 		outputter.SetError(err)
 
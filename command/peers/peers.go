@@ -15,6 +15,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(peersCmd)
+	helper.RegisterIPCClientFlag(peersCmd)
 
 	registerSubcommands(peersCmd)
 
@@ -0,0 +1,266 @@
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/SECRYPT-2022/SECRYPT/blockchain/storage/leveldb"
+	"github.com/SECRYPT-2022/SECRYPT/chain"
+	"github.com/SECRYPT-2022/SECRYPT/command"
+	"github.com/SECRYPT-2022/SECRYPT/consensus/ibft"
+	"github.com/SECRYPT-2022/SECRYPT/state"
+	itrie "github.com/SECRYPT-2022/SECRYPT/state/immutable-trie"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer/structtracer"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag     = "data-dir"
+	genesisPathFlag = "chain"
+	fromFlag        = "from"
+	toFlag          = "to"
+	traceFlag       = "trace"
+)
+
+const ibftEngine = "ibft"
+
+var (
+	params = &replayParams{}
+)
+
+var (
+	errDecodeRange  = errors.New("unable to decode range value")
+	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+	errGenesisBlock = errors.New("genesis block can't be replayed")
+	errNoChain      = errors.New("no chain found in the data directory")
+)
+
+type replayParams struct {
+	dataDir     string
+	genesisPath string
+	tracePath   string
+
+	fromRaw string
+	toRaw   string
+
+	from uint64
+	to   *uint64
+
+	genesisConfig *chain.Chain
+
+	result *ReplayResult
+}
+
+func (p *replayParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *replayParams) initRawParams() error {
+	if err := p.validateFlags(); err != nil {
+		return err
+	}
+
+	genesisConfig, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to load chain config from %s: %w",
+			p.genesisPath,
+			err,
+		)
+	}
+
+	p.genesisConfig = genesisConfig
+
+	return nil
+}
+
+func (p *replayParams) validateFlags() error {
+	var parseErr error
+
+	if p.from, parseErr = types.ParseUint64orHex(&p.fromRaw); parseErr != nil {
+		return errDecodeRange
+	}
+
+	if p.from == 0 {
+		return errGenesisBlock
+	}
+
+	if p.toRaw != "" {
+		var parsedTo uint64
+
+		if parsedTo, parseErr = types.ParseUint64orHex(&p.toRaw); parseErr != nil {
+			return errDecodeRange
+		}
+
+		if p.from > parsedTo {
+			return errInvalidRange
+		}
+
+		p.to = &parsedTo
+	}
+
+	return nil
+}
+
+func (p *replayParams) replay() error {
+	logger := hclog.NewNullLogger()
+
+	// the storages are opened read-only, the replays don't change the data of the node
+	stateStorage, err := itrie.NewReadOnlyLevelDBStorage(filepath.Join(p.dataDir, "trie"), logger)
+	if err != nil {
+		return fmt.Errorf("failed to open state storage, is the node running? %w", err)
+	}
+
+	defer stateStorage.Close()
+
+	chainStorage, err := leveldb.NewReadOnlyLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return fmt.Errorf("failed to open blockchain storage, is the node running? %w", err)
+	}
+
+	defer chainStorage.Close()
+
+	verifier, err := p.newVerifier()
+	if err != nil {
+		return err
+	}
+
+	store := &chainStore{
+		db:      chainStorage,
+		forks:   p.genesisConfig.Params.Forks,
+		chainID: uint64(p.genesisConfig.Params.ChainID),
+	}
+
+	// the genesis is read from the chain, the re-executed blocks have to descend from it
+	if _, ok := store.GetHeaderByNumber(0); !ok {
+		return errNoChain
+	}
+
+	executor := state.NewExecutor(
+		p.genesisConfig.Params,
+		itrie.NewState(&readOnlyStorage{stateStorage}),
+		logger,
+	)
+	executor.GetHash = store.GetHashHelper
+
+	to, ok := store.head()
+	if !ok {
+		return errNoChain
+	}
+
+	if p.to != nil && *p.to < to {
+		to = *p.to
+	}
+
+	if p.from > to {
+		return fmt.Errorf("block %d is beyond the head of the chain %d", p.from, to)
+	}
+
+	r := &replayer{
+		store:    store,
+		executor: executor,
+		verifier: verifier,
+	}
+
+	p.result = &ReplayResult{
+		From: p.from,
+		To:   to,
+	}
+
+	for number := p.from; number <= to; number++ {
+		divergence, err := r.replayBlock(number)
+		if err != nil {
+			return err
+		}
+
+		if divergence == nil {
+			continue
+		}
+
+		p.result.Divergence = divergence
+		p.result.To = number
+
+		return p.dumpTrace(r, divergence)
+	}
+
+	return nil
+}
+
+// dumpTrace writes the trace of the first diverging transaction, if requested
+func (p *replayParams) dumpTrace(r *replayer, divergence *BlockDivergence) error {
+	if p.tracePath == "" || len(divergence.Txs) == 0 {
+		return nil
+	}
+
+	tx := divergence.Txs[0]
+
+	trace, err := r.traceTx(divergence.Number, tx.Index, structtracer.NewStructTracer(structtracer.Config{
+		EnableMemory:     true,
+		EnableStack:      true,
+		EnableStorage:    true,
+		EnableReturnData: true,
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to trace transaction %s: %w", tx.Hash, err)
+	}
+
+	data, err := json.MarshalIndent(trace, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(p.tracePath, data, 0600); err != nil {
+		return err
+	}
+
+	p.result.TracePath = p.tracePath
+	p.result.TracedTx = &tx.Hash
+
+	return nil
+}
+
+// newVerifier returns the consensus specific parts of the block execution
+func (p *replayParams) newVerifier() (verifier, error) {
+	engineName := p.genesisConfig.Params.GetEngine()
+
+	if engineName != ibftEngine {
+		// the other engines reward the miner field of the header
+		return &minerVerifier{}, nil
+	}
+
+	engineConfig, ok := p.genesisConfig.Params.Engine[engineName].(map[string]interface{})
+	if !ok {
+		engineConfig = map[string]interface{}{}
+	}
+
+	replayVerifier, err := ibft.NewReplayVerifier(engineConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// headers have to be hashed as IBFT does before reading the chain
+	replayVerifier.SetHeaderHash()
+
+	return replayVerifier, nil
+}
+
+func (p *replayParams) getResult() command.CommandResult {
+	return p.result
+}
+
+// minerVerifier is the verifier of the engines which don't seal the headers
+type minerVerifier struct{}
+
+func (v *minerVerifier) GetBlockCreator(header *types.Header) (types.Address, error) {
+	return types.BytesToAddress(header.Miner), nil
+}
+
+func (v *minerVerifier) PreCommitState(_ *types.Header, _ *state.Transition) error {
+	return nil
+}
@@ -0,0 +1,78 @@
+package replay
+
+import (
+	"fmt"
+
+	"github.com/SECRYPT-2022/SECRYPT/command"
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	replayCmd := &cobra.Command{
+		Use: "replay",
+		Short: "Re-executes the stored blocks from the data directory of a stopped node, " +
+			"and reports the first block whose results differ from the stored ones",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(replayCmd)
+	helper.SetRequiredFlags(replayCmd, params.getRequiredFlags())
+
+	return replayCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		genesisPathFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file used by the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.fromRaw,
+		fromFlag,
+		"1",
+		"the first block to re-execute",
+	)
+
+	cmd.Flags().StringVar(
+		&params.toRaw,
+		toFlag,
+		"",
+		"the last block to re-execute, the head of the chain if omitted",
+	)
+
+	cmd.Flags().StringVar(
+		&params.tracePath,
+		traceFlag,
+		"",
+		"the file to write the struct trace of the first diverging transaction to",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.replay(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,393 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SECRYPT-2022/SECRYPT/blockchain/storage/leveldb"
+	"github.com/SECRYPT-2022/SECRYPT/chain"
+	"github.com/SECRYPT-2022/SECRYPT/crypto"
+	"github.com/SECRYPT-2022/SECRYPT/helper/tests"
+	"github.com/SECRYPT-2022/SECRYPT/state"
+	itrie "github.com/SECRYPT-2022/SECRYPT/state/immutable-trie"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer/structtracer"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/SECRYPT-2022/SECRYPT/types/buildroot"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	contractAddr = types.StringToAddress("0x1000")
+	coinbase     = types.StringToAddress("0x2000")
+
+	// stores the block number in the first slot
+	contractCode = []byte{
+		0x43,       // NUMBER
+		0x60, 0x00, // PUSH1 0
+		0x55, // SSTORE
+		0x00, // STOP
+	}
+)
+
+// tamperFn changes the stored header or receipts of a block once it's executed
+type tamperFn func(header *types.Header, receipts []*types.Receipt)
+
+// newTestChain writes a chain of the given number of blocks to a new data directory,
+// each block calling the contract once, and returns the directory with the chain config
+func newTestChain(t *testing.T, numBlocks int, tamper map[uint64]tamperFn) (string, *chain.Chain, []*types.Block) {
+	t.Helper()
+
+	dataDir := t.TempDir()
+	logger := hclog.NewNullLogger()
+
+	key, sender := tests.GenerateKeyAndAddr(t)
+
+	config := &chain.Chain{
+		Genesis: &chain.Genesis{
+			GasLimit: 5000000,
+			Alloc: map[types.Address]*chain.GenesisAccount{
+				sender:       {Balance: big.NewInt(1e18)},
+				contractAddr: {Balance: big.NewInt(0), Code: contractCode},
+			},
+		},
+		Params: &chain.Params{
+			Forks:   chain.AllForksEnabled,
+			ChainID: 100,
+			Engine:  map[string]interface{}{"dev": map[string]interface{}{}},
+		},
+	}
+
+	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(dataDir, "trie"), logger)
+	assert.NoError(t, err)
+
+	chainStorage, err := leveldb.NewLevelDBStorage(filepath.Join(dataDir, "blockchain"), logger)
+	assert.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, stateStorage.Close())
+		assert.NoError(t, chainStorage.Close())
+	}()
+
+	executor := state.NewExecutor(config.Params, itrie.NewState(stateStorage), logger)
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(number uint64) types.Hash {
+			hash, _ := chainStorage.ReadCanonicalHash(number)
+
+			return hash
+		}
+	}
+
+	parent := &types.Header{
+		Number:     0,
+		GasLimit:   config.Genesis.GasLimit,
+		Difficulty: 1,
+		StateRoot:  executor.WriteGenesis(config.Genesis.Alloc),
+	}
+
+	writeBlock := func(block *types.Block, receipts []*types.Receipt) {
+		header := block.Header
+		header.ComputeHash()
+
+		assert.NoError(t, chainStorage.WriteHeader(header))
+		assert.NoError(t, chainStorage.WriteCanonicalHash(header.Number, header.Hash))
+		assert.NoError(t, chainStorage.WriteBody(header.Hash, block.Body()))
+		assert.NoError(t, chainStorage.WriteReceipts(header.Hash, receipts))
+		assert.NoError(t, chainStorage.WriteHeadHash(header.Hash))
+		assert.NoError(t, chainStorage.WriteHeadNumber(header.Number))
+	}
+
+	writeBlock(&types.Block{Header: parent}, nil)
+
+	blocks := make([]*types.Block, 0, numBlocks)
+
+	for number := uint64(1); number <= uint64(numBlocks); number++ {
+		signer := crypto.NewSigner(config.Params.Forks.At(number), uint64(config.Params.ChainID))
+
+		tx, err := signer.SignTx(&types.Transaction{
+			Nonce:    number - 1,
+			To:       &contractAddr,
+			Value:    big.NewInt(0),
+			Gas:      100000,
+			GasPrice: big.NewInt(1),
+		}, key)
+		assert.NoError(t, err)
+
+		tx.From = sender
+		tx.ComputeHash()
+
+		block := &types.Block{
+			Header: &types.Header{
+				ParentHash: parent.Hash,
+				Number:     number,
+				GasLimit:   config.Genesis.GasLimit,
+				Timestamp:  number,
+				Difficulty: 1,
+				Miner:      coinbase.Bytes(),
+			},
+			Transactions: []*types.Transaction{tx},
+		}
+
+		txn, err := executor.ProcessBlock(parent.StateRoot, block, coinbase)
+		assert.NoError(t, err)
+
+		_, root := txn.Commit()
+		receipts := txn.Receipts()
+
+		block.Header.StateRoot = root
+		block.Header.ReceiptsRoot = buildroot.CalculateReceiptsRoot(receipts)
+		block.Header.GasUsed = txn.TotalGas()
+
+		if fn, ok := tamper[number]; ok {
+			fn(block.Header, receipts)
+		}
+
+		writeBlock(block, receipts)
+
+		blocks = append(blocks, block)
+		parent = block.Header
+	}
+
+	return dataDir, config, blocks
+}
+
+// readDir returns the content of the files of the directory, by path
+func readDir(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	files := map[string]string{}
+
+	assert.NoError(t, filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		files[path] = string(data)
+
+		return nil
+	}))
+
+	return files
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("matching blocks", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir, config, _ := newTestChain(t, 3, nil)
+		before := readDir(t, dataDir)
+
+		p := &replayParams{dataDir: dataDir, from: 1, genesisConfig: config}
+
+		assert.NoError(t, p.replay())
+		assert.Nil(t, p.result.Divergence)
+		assert.Equal(t, uint64(1), p.result.From)
+		assert.Equal(t, uint64(3), p.result.To)
+
+		// the data of the node isn't written
+		assert.Equal(t, before, readDir(t, dataDir))
+	})
+
+	t.Run("reports the first diverging receipt", func(t *testing.T) {
+		t.Parallel()
+
+		tamperReceipt := func(_ *types.Header, receipts []*types.Receipt) {
+			receipts[0].CumulativeGasUsed++
+			receipts[0].SetStatus(types.ReceiptFailed)
+		}
+
+		dataDir, config, blocks := newTestChain(t, 3, map[uint64]tamperFn{
+			2: tamperReceipt,
+			3: tamperReceipt,
+		})
+
+		p := &replayParams{dataDir: dataDir, from: 1, genesisConfig: config}
+
+		assert.NoError(t, p.replay())
+		assert.Equal(t, uint64(2), p.result.To)
+
+		divergence := p.result.Divergence
+		if assert.NotNil(t, divergence) {
+			assert.Equal(t, uint64(2), divergence.Number)
+			assert.Equal(t, blocks[1].Hash(), divergence.Hash)
+			assert.Len(t, divergence.Divergences, 0)
+
+			if assert.Len(t, divergence.Txs, 1) {
+				tx := divergence.Txs[0]
+
+				assert.Equal(t, 0, tx.Index)
+				assert.Equal(t, blocks[1].Transactions[0].Hash, tx.Hash)
+				assert.Equal(t, []*Divergence{
+					{Field: "status", Expected: "failed", Actual: "success"},
+					{
+						Field:    "cumulativeGasUsed",
+						Expected: fmt.Sprint(blocks[1].Header.GasUsed + 1),
+						Actual:   fmt.Sprint(blocks[1].Header.GasUsed),
+					},
+				}, tx.Divergences)
+			}
+		}
+
+		// no trace without the trace option
+		assert.Nil(t, p.result.TracedTx)
+	})
+
+	t.Run("reports the diverging state root", func(t *testing.T) {
+		t.Parallel()
+
+		tampered := types.StringToHash("0x1")
+
+		var executedRoot types.Hash
+
+		dataDir, config, _ := newTestChain(t, 2, map[uint64]tamperFn{
+			2: func(header *types.Header, _ []*types.Receipt) {
+				executedRoot = header.StateRoot
+				header.StateRoot = tampered
+			},
+		})
+
+		p := &replayParams{dataDir: dataDir, from: 1, genesisConfig: config}
+
+		assert.NoError(t, p.replay())
+
+		divergence := p.result.Divergence
+		if assert.NotNil(t, divergence) {
+			assert.Equal(t, uint64(2), divergence.Number)
+			assert.Len(t, divergence.Txs, 0)
+
+			if assert.Len(t, divergence.Divergences, 1) {
+				assert.Equal(t, "stateRoot", divergence.Divergences[0].Field)
+				assert.Equal(t, tampered.String(), divergence.Divergences[0].Expected)
+				assert.Equal(t, executedRoot.String(), divergence.Divergences[0].Actual)
+			}
+		}
+	})
+
+	t.Run("dumps the trace of the diverging tx", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir, config, blocks := newTestChain(t, 1, map[uint64]tamperFn{
+			1: func(_ *types.Header, receipts []*types.Receipt) {
+				receipts[0].CumulativeGasUsed++
+			},
+		})
+
+		tracePath := filepath.Join(t.TempDir(), "trace.json")
+
+		p := &replayParams{dataDir: dataDir, from: 1, genesisConfig: config, tracePath: tracePath}
+
+		assert.NoError(t, p.replay())
+
+		txHash := blocks[0].Transactions[0].Hash
+
+		assert.Equal(t, tracePath, p.result.TracePath)
+		assert.Equal(t, &txHash, p.result.TracedTx)
+
+		data, err := os.ReadFile(tracePath)
+		assert.NoError(t, err)
+
+		var trace structtracer.StructTraceResult
+
+		assert.NoError(t, json.Unmarshal(data, &trace))
+		assert.False(t, trace.Failed)
+
+		ops := make([]string, len(trace.StructLogs))
+		for idx, log := range trace.StructLogs {
+			ops[idx] = log.Op
+		}
+
+		assert.Equal(t, []string{"NUMBER", "PUSH1", "SSTORE", "STOP"}, ops)
+	})
+
+	t.Run("fails without a chain", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir, config, _ := newTestChain(t, 0, nil)
+
+		p := &replayParams{dataDir: dataDir, from: 1, genesisConfig: config}
+
+		assert.Error(t, p.replay())
+	})
+}
+
+func TestReadOnlyStorages(t *testing.T) {
+	t.Parallel()
+
+	t.Run("chain storage writes fail", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir, _, blocks := newTestChain(t, 1, nil)
+
+		db, err := leveldb.NewReadOnlyLevelDBStorage(filepath.Join(dataDir, "blockchain"), hclog.NewNullLogger())
+		assert.NoError(t, err)
+
+		defer db.Close()
+
+		header := blocks[0].Header.Copy()
+		header.Number = 2
+		header.ComputeHash()
+
+		assert.Error(t, db.WriteHeader(header))
+		assert.Error(t, db.WriteHeadNumber(2))
+
+		number, ok := db.ReadHeadNumber()
+		assert.True(t, ok)
+		assert.Equal(t, uint64(1), number)
+	})
+
+	t.Run("state storage writes are dropped", func(t *testing.T) {
+		t.Parallel()
+
+		dataDir, _, _ := newTestChain(t, 0, nil)
+
+		db, err := itrie.NewReadOnlyLevelDBStorage(filepath.Join(dataDir, "trie"), hclog.NewNullLogger())
+		assert.NoError(t, err)
+
+		defer db.Close()
+
+		db.Put([]byte{0x1}, []byte{0x2})
+
+		_, ok := db.Get([]byte{0x1})
+		assert.False(t, ok)
+	})
+
+	t.Run("replay storage drops the writes", func(t *testing.T) {
+		t.Parallel()
+
+		db := itrie.NewMemoryStorage()
+		db.Put([]byte{0x1}, []byte{0x1})
+
+		s := &readOnlyStorage{db}
+
+		s.Put([]byte{0x1}, []byte{0x2})
+		s.Put([]byte{0x2}, []byte{0x2})
+		s.SetCode(types.StringToHash("0x1"), []byte{0x2})
+
+		batch := s.Batch()
+		batch.Put([]byte{0x3}, []byte{0x3})
+		batch.Write()
+
+		value, ok := s.Get([]byte{0x1})
+		assert.True(t, ok)
+		assert.Equal(t, []byte{0x1}, value)
+
+		for _, key := range [][]byte{{0x2}, {0x3}} {
+			_, ok := s.Get(key)
+			assert.False(t, ok)
+		}
+
+		_, ok = s.GetCode(types.StringToHash("0x1"))
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,244 @@
+package replay
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/SECRYPT-2022/SECRYPT/state"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/SECRYPT-2022/SECRYPT/types/buildroot"
+)
+
+var (
+	errBlockNotFound  = errors.New("block not found")
+	errParentNotFound = errors.New("parent header not found")
+)
+
+// blockStore is the stored chain the blocks are read from
+type blockStore interface {
+	GetBlockByNumber(number uint64, full bool) (*types.Block, bool)
+	GetHeaderByHash(hash types.Hash) (*types.Header, bool)
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+}
+
+// blockExecutor re-executes the blocks from the parent state
+type blockExecutor interface {
+	ProcessBlock(parentRoot types.Hash, block *types.Block, blockCreator types.Address) (*state.Transition, error)
+	BeginTxn(parentRoot types.Hash, header *types.Header, coinbaseReceiver types.Address) (*state.Transition, error)
+}
+
+// verifier provides the consensus specific parts of the block execution
+type verifier interface {
+	GetBlockCreator(header *types.Header) (types.Address, error)
+	PreCommitState(header *types.Header, txn *state.Transition) error
+}
+
+// Divergence is a difference between the stored and the re-executed values
+type Divergence struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// TxDivergence holds the differences of a transaction receipt
+type TxDivergence struct {
+	Index       int           `json:"index"`
+	Hash        types.Hash    `json:"hash"`
+	Divergences []*Divergence `json:"divergences"`
+}
+
+// BlockDivergence holds the differences found when re-executing a block
+type BlockDivergence struct {
+	Number      uint64          `json:"number"`
+	Hash        types.Hash      `json:"hash"`
+	Divergences []*Divergence   `json:"divergences"`
+	Txs         []*TxDivergence `json:"txs"`
+}
+
+type replayer struct {
+	store    blockStore
+	executor blockExecutor
+	verifier verifier
+}
+
+// replayBlock re-executes the block at the given height, and returns
+// the differences with the stored results, or nil if there's none
+func (r *replayer) replayBlock(number uint64) (*BlockDivergence, error) {
+	block, parent, creator, err := r.getBlock(number)
+	if err != nil {
+		return nil, err
+	}
+
+	txn, err := r.executor.ProcessBlock(parent.StateRoot, block, creator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process block %d: %w", number, err)
+	}
+
+	if err := r.verifier.PreCommitState(block.Header, txn); err != nil {
+		return nil, err
+	}
+
+	// the trie storage drops the writes, the state root is computed without being saved
+	_, root := txn.Commit()
+
+	storedReceipts, err := r.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read receipts of block %d: %w", number, err)
+	}
+
+	receipts := txn.Receipts()
+	header := block.Header
+
+	result := &BlockDivergence{
+		Number: number,
+		Hash:   block.Hash(),
+	}
+
+	result.compare("stateRoot", header.StateRoot.String(), root.String())
+	result.compare("receiptsRoot", header.ReceiptsRoot.String(), buildroot.CalculateReceiptsRoot(receipts).String())
+	result.compare("gasUsed", fmt.Sprint(header.GasUsed), fmt.Sprint(txn.TotalGas()))
+	result.compare("receipts", fmt.Sprint(len(storedReceipts)), fmt.Sprint(len(receipts)))
+
+	for idx, tx := range block.Transactions {
+		txResult := &TxDivergence{
+			Index: idx,
+			Hash:  tx.Hash,
+		}
+
+		if idx < len(storedReceipts) && idx < len(receipts) {
+			txResult.Divergences = compareReceipts(storedReceipts[idx], receipts[idx])
+		}
+
+		if len(txResult.Divergences) > 0 {
+			result.Txs = append(result.Txs, txResult)
+		}
+	}
+
+	if len(result.Divergences) == 0 && len(result.Txs) == 0 {
+		return nil, nil
+	}
+
+	return result, nil
+}
+
+// traceTx re-executes the block up to the given transaction, and returns its trace
+func (r *replayer) traceTx(number uint64, index int, tracer tracer.Tracer) (interface{}, error) {
+	block, parent, creator, err := r.getBlock(number)
+	if err != nil {
+		return nil, err
+	}
+
+	if index >= len(block.Transactions) {
+		return nil, fmt.Errorf("transaction %d not found in block %d", index, number)
+	}
+
+	transition, err := r.executor.BeginTxn(parent.StateRoot, block.Header, creator)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute transactions without tracer until reaching the target transaction
+	for _, tx := range block.Transactions[:index] {
+		if _, err := transition.Apply(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	transition.SetTracer(tracer)
+
+	if _, err := transition.Apply(block.Transactions[index]); err != nil {
+		return nil, err
+	}
+
+	return tracer.GetResult()
+}
+
+// getBlock returns the block at the given height with its parent header and creator
+func (r *replayer) getBlock(number uint64) (*types.Block, *types.Header, types.Address, error) {
+	block, ok := r.store.GetBlockByNumber(number, true)
+	if !ok {
+		return nil, nil, types.ZeroAddress, fmt.Errorf("%w: %d", errBlockNotFound, number)
+	}
+
+	parent, ok := r.store.GetHeaderByHash(block.ParentHash())
+	if !ok {
+		return nil, nil, types.ZeroAddress, fmt.Errorf("%w: %d", errParentNotFound, number)
+	}
+
+	creator, err := r.verifier.GetBlockCreator(block.Header)
+	if err != nil {
+		return nil, nil, types.ZeroAddress, err
+	}
+
+	return block, parent, creator, nil
+}
+
+// compare records a divergence if the values differ
+func (d *BlockDivergence) compare(field, expected, actual string) {
+	if expected != actual {
+		d.Divergences = append(d.Divergences, &Divergence{
+			Field:    field,
+			Expected: expected,
+			Actual:   actual,
+		})
+	}
+}
+
+// compareReceipts returns the differences between the stored and the re-executed receipt
+func compareReceipts(expected, actual *types.Receipt) []*Divergence {
+	divergences := []*Divergence{}
+
+	add := func(field, expected, actual string) {
+		if expected != actual {
+			divergences = append(divergences, &Divergence{
+				Field:    field,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+
+	add("status", statusString(expected.Status), statusString(actual.Status))
+	add("cumulativeGasUsed", fmt.Sprint(expected.CumulativeGasUsed), fmt.Sprint(actual.CumulativeGasUsed))
+	add("logs", fmt.Sprint(len(expected.Logs)), fmt.Sprint(len(actual.Logs)))
+
+	for idx := 0; idx < len(expected.Logs) && idx < len(actual.Logs); idx++ {
+		if !logsEqual(expected.Logs[idx], actual.Logs[idx]) {
+			add(fmt.Sprintf("logs[%d]", idx), logString(expected.Logs[idx]), logString(actual.Logs[idx]))
+		}
+	}
+
+	return divergences
+}
+
+func logsEqual(a, b *types.Log) bool {
+	if a.Address != b.Address || len(a.Topics) != len(b.Topics) || !bytes.Equal(a.Data, b.Data) {
+		return false
+	}
+
+	for idx := range a.Topics {
+		if a.Topics[idx] != b.Topics[idx] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func logString(log *types.Log) string {
+	return fmt.Sprintf("address=%s topics=%v data=%x", log.Address, log.Topics, log.Data)
+}
+
+func statusString(status *types.ReceiptStatus) string {
+	if status == nil {
+		return "none"
+	}
+
+	if *status == types.ReceiptSuccess {
+		return "success"
+	}
+
+	return "failed"
+}
@@ -0,0 +1,74 @@
+package replay
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+)
+
+type ReplayResult struct {
+	From       uint64           `json:"from"`
+	To         uint64           `json:"to"`
+	Divergence *BlockDivergence `json:"divergence,omitempty"`
+	TracedTx   *types.Hash      `json:"tracedTx,omitempty"`
+	TracePath  string           `json:"tracePath,omitempty"`
+}
+
+func (r *ReplayResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[REPLAY]\n")
+
+	if r.Divergence == nil {
+		buffer.WriteString("All blocks match the stored results:\n")
+		buffer.WriteString(helper.FormatKV([]string{
+			fmt.Sprintf("From|%d", r.From),
+			fmt.Sprintf("To|%d", r.To),
+		}))
+		buffer.WriteString("\n")
+
+		return buffer.String()
+	}
+
+	buffer.WriteString("Divergence found:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block|%d", r.Divergence.Number),
+		fmt.Sprintf("Hash|%s", r.Divergence.Hash),
+	}))
+	buffer.WriteString("\n")
+
+	if len(r.Divergence.Divergences) > 0 {
+		buffer.WriteString("\n[BLOCK]\n")
+		writeDivergences(&buffer, r.Divergence.Divergences)
+	}
+
+	for _, tx := range r.Divergence.Txs {
+		buffer.WriteString(fmt.Sprintf("\n[TRANSACTION %d: %s]\n", tx.Index, tx.Hash))
+		writeDivergences(&buffer, tx.Divergences)
+	}
+
+	if r.TracedTx != nil {
+		buffer.WriteString("\n[TRACE]\n")
+		buffer.WriteString(helper.FormatKV([]string{
+			fmt.Sprintf("Transaction|%s", r.TracedTx),
+			fmt.Sprintf("File|%s", r.TracePath),
+		}))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
+
+func writeDivergences(buffer *bytes.Buffer, divergences []*Divergence) {
+	rows := make([]string, len(divergences)+1)
+	rows[0] = "Field|Stored|Re-executed"
+
+	for idx, divergence := range divergences {
+		rows[idx+1] = fmt.Sprintf("%s|%s|%s", divergence.Field, divergence.Expected, divergence.Actual)
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+}
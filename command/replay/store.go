@@ -0,0 +1,114 @@
+package replay
+
+import (
+	"github.com/SECRYPT-2022/SECRYPT/blockchain/storage"
+	"github.com/SECRYPT-2022/SECRYPT/chain"
+	"github.com/SECRYPT-2022/SECRYPT/crypto"
+	itrie "github.com/SECRYPT-2022/SECRYPT/state/immutable-trie"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+)
+
+// chainStore reads the stored chain of the node, without writing to its storage
+// as the blockchain does when it recovers the senders of the transactions
+type chainStore struct {
+	db      storage.Storage
+	forks   *chain.Forks
+	chainID uint64
+}
+
+// head returns the number of the head block of the stored chain
+func (s *chainStore) head() (uint64, bool) {
+	return s.db.ReadHeadNumber()
+}
+
+func (s *chainStore) GetHeaderByHash(hash types.Hash) (*types.Header, bool) {
+	header, err := s.db.ReadHeader(hash)
+	if err != nil {
+		return nil, false
+	}
+
+	header.ComputeHash()
+
+	return header, true
+}
+
+func (s *chainStore) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	hash, ok := s.db.ReadCanonicalHash(number)
+	if !ok {
+		return nil, false
+	}
+
+	return s.GetHeaderByHash(hash)
+}
+
+func (s *chainStore) GetBlockByNumber(number uint64, full bool) (*types.Block, bool) {
+	header, ok := s.GetHeaderByNumber(number)
+	if !ok {
+		return nil, false
+	}
+
+	block := &types.Block{Header: header}
+
+	if !full || number == 0 {
+		return block, true
+	}
+
+	body, err := s.db.ReadBody(header.Hash)
+	if err != nil {
+		return nil, false
+	}
+
+	// the senders are recovered with the signer of the block, as they were on execution
+	signer := crypto.NewSigner(s.forks.At(number), s.chainID)
+
+	for _, tx := range body.Transactions {
+		if tx.From != types.ZeroAddress {
+			continue
+		}
+
+		if from, err := signer.Sender(tx); err == nil {
+			tx.From = from
+		}
+	}
+
+	block.Transactions = body.Transactions
+	block.Uncles = body.Uncles
+
+	return block, true
+}
+
+func (s *chainStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return s.db.ReadReceipts(hash)
+}
+
+// GetHashHelper returns the hashes of the canonical blocks for the BLOCKHASH opcode,
+// the replayed blocks being canonical
+func (s *chainStore) GetHashHelper(_ *types.Header) func(i uint64) types.Hash {
+	return func(i uint64) types.Hash {
+		hash, _ := s.db.ReadCanonicalHash(i)
+
+		return hash
+	}
+}
+
+// readOnlyStorage is the trie storage of the replays, reading the state of the node
+// and dropping the writes so that the re-executed states aren't saved.
+// The replayed blocks are executed from their stored parent state, never from a re-executed one
+type readOnlyStorage struct {
+	itrie.Storage
+}
+
+func (s *readOnlyStorage) Put(_, _ []byte) {}
+
+func (s *readOnlyStorage) SetCode(_ types.Hash, _ []byte) {}
+
+func (s *readOnlyStorage) Batch() itrie.Batch {
+	return discardBatch{}
+}
+
+// discardBatch is a batch dropping its writes
+type discardBatch struct{}
+
+func (discardBatch) Put(_, _ []byte) {}
+
+func (discardBatch) Write() {}
@@ -11,6 +11,7 @@ import (
 	"github.com/SECRYPT-2022/SECRYPT/command/license"
 	"github.com/SECRYPT-2022/SECRYPT/command/monitor"
 	"github.com/SECRYPT-2022/SECRYPT/command/peers"
+	"github.com/SECRYPT-2022/SECRYPT/command/replay"
 	"github.com/SECRYPT-2022/SECRYPT/command/secrets"
 	"github.com/SECRYPT-2022/SECRYPT/command/server"
 	"github.com/SECRYPT-2022/SECRYPT/command/status"
@@ -52,6 +53,7 @@ func (rc *RootCommand) registerSubCommands() {
 		server.GetCommand(),
 		whitelist.GetCommand(),
 		license.GetCommand(),
+		replay.GetCommand(),
 	)
 }
 
@@ -19,6 +19,7 @@ type Config struct {
 	BlockGasTarget           string     `json:"block_gas_target" yaml:"block_gas_target"`
 	GRPCAddr                 string     `json:"grpc_addr" yaml:"grpc_addr"`
 	JSONRPCAddr              string     `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
+	IPCPath                  string     `json:"ipc_path" yaml:"ipc_path"`
 	Telemetry                *Telemetry `json:"telemetry" yaml:"telemetry"`
 	Network                  *Network   `json:"network" yaml:"network"`
 	ShouldSeal               bool       `json:"seal" yaml:"seal"`
@@ -31,6 +32,12 @@ type Config struct {
 	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
 	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
 	JSONLogFormat            bool       `json:"json_log_format" yaml:"json_log_format"`
+	Accounts                 *Accounts  `json:"accounts" yaml:"accounts"`
+
+	JSONRPCDisabledNamespaces []string     `json:"json_rpc_disabled_namespaces" yaml:"json_rpc_disabled_namespaces"`
+	JSONRPCAuth               *JSONRPCAuth `json:"json_rpc_auth" yaml:"json_rpc_auth"`
+
+	JSONRPCRateLimit *JSONRPCRateLimit `json:"json_rpc_rate_limit" yaml:"json_rpc_rate_limit"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -51,9 +58,54 @@ type Network struct {
 
 // TxPool defines the TxPool configuration params
 type TxPool struct {
-	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
-	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
-	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	PriceLimit         uint64   `json:"price_limit" yaml:"price_limit"`
+	PriceBump          uint64   `json:"price_bump" yaml:"price_bump"`
+	MaxSlots           uint64   `json:"max_slots" yaml:"max_slots"`
+	MaxAccountEnqueued uint64   `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	Journal            bool     `json:"journal" yaml:"journal"`
+	JournalRemotes     bool     `json:"journal_remotes" yaml:"journal_remotes"`
+	RejournalInterval  uint64   `json:"rejournal_interval" yaml:"rejournal_interval"`
+	Lifetime           uint64   `json:"lifetime" yaml:"lifetime"`
+	PrivateTxPeers     []string `json:"private_tx_peers" yaml:"private_tx_peers"`
+}
+
+// Accounts defines the node accounts configuration params
+type Accounts struct {
+	Enabled     bool   `json:"enabled" yaml:"enabled"`
+	KeystoreDir string `json:"keystore_dir" yaml:"keystore_dir"`
+}
+
+// JSONRPCAuth defines the JSON-RPC authentication params of the HTTP and WS transports,
+// a transport serves all the namespaces to anyone if its params are omitted
+type JSONRPCAuth struct {
+	HTTP *JSONRPCTransportAuth `json:"http" yaml:"http"`
+	WS   *JSONRPCTransportAuth `json:"ws" yaml:"ws"`
+}
+
+// JSONRPCTransportAuth defines the access lists of a JSON-RPC transport,
+// each entry being a namespace ("eth"), a method ("eth_call") or "*" for all of them
+type JSONRPCTransportAuth struct {
+	// subject claim of the JWT tokens -> access list, "*" matching any subject
+	JWTSubjects map[string][]string `json:"jwt_subjects" yaml:"jwt_subjects"`
+
+	// static API key -> access list
+	APIKeys map[string][]string `json:"api_keys" yaml:"api_keys"`
+
+	// access list of the requests without credentials
+	Public []string `json:"public" yaml:"public"`
+}
+
+// JSONRPCRateLimit defines the per client rate limiting params of the JSON-RPC requests,
+// the clients being identified by their API key, JWT subject or IP
+type JSONRPCRateLimit struct {
+	// cost refilled per second for each client, 0 disables the rate limiting
+	Rate float64 `json:"rate" yaml:"rate"`
+
+	// cost a client can spend at once
+	Burst float64 `json:"burst" yaml:"burst"`
+
+	// method ("eth_getLogs") or namespace ("debug") -> cost, the other methods costing 1
+	MethodCosts map[string]float64 `json:"method_costs" yaml:"method_costs"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -75,8 +127,29 @@ const (
 	// DefaultJSONRPCBlockRangeLimit maximum block range allowed for json_rpc
 	// requests with fromBlock/toBlock values (e.g. eth_getLogs)
 	DefaultJSONRPCBlockRangeLimit uint64 = 1000
+
+	// DefaultRejournalInterval is the time in seconds between the txpool journal compactions
+	DefaultRejournalInterval uint64 = 3600
+
+	// DefaultJSONRPCRateLimitBurst is the cost a json-rpc client can spend at once when rate limited
+	DefaultJSONRPCRateLimitBurst float64 = 100
 )
 
+// DefaultJSONRPCMethodCosts returns the default costs of the json-rpc methods when rate limited,
+// the ones going through many blocks or executing transactions costing more
+func DefaultJSONRPCMethodCosts() map[string]float64 {
+	return map[string]float64{
+		"debug":                  20,
+		"trace":                  20,
+		"eth_getLogs":            10,
+		"eth_getFilterLogs":      10,
+		"eth_feeHistory":         5,
+		"eth_call":               5,
+		"eth_estimateGas":        5,
+		"eth_sendRawTransaction": 2,
+	}
+}
+
 // DefaultConfig returns the default server configuration
 func DefaultConfig() *Config {
 	defaultNetworkConfig := network.DefaultConfig()
@@ -99,8 +172,10 @@ func DefaultConfig() *Config {
 		ShouldSeal: true,
 		TxPool: &TxPool{
 			PriceLimit:         5000000000,
+			PriceBump:          10,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
+			RejournalInterval:  DefaultRejournalInterval,
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
@@ -111,6 +186,12 @@ func DefaultConfig() *Config {
 		LogFilePath:              "",
 		JSONRPCBatchRequestLimit: DefaultJSONRPCBatchRequestLimit,
 		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
+		Accounts:                 &Accounts{},
+		JSONRPCAuth:              &JSONRPCAuth{},
+		JSONRPCRateLimit: &JSONRPCRateLimit{
+			Burst:       DefaultJSONRPCRateLimitBurst,
+			MethodCosts: DefaultJSONRPCMethodCosts(),
+		},
 	}
 }
 
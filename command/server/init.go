@@ -16,6 +16,7 @@ import (
 	"github.com/SECRYPT-2022/SECRYPT/secrets"
 	"github.com/SECRYPT-2022/SECRYPT/server"
 	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 var (
@@ -58,12 +59,31 @@ func (p *serverParams) initRawParams() error {
 		p.initDevMode()
 	}
 
+	if err := p.initPrivateTxPeers(); err != nil {
+		return err
+	}
+
 	p.initPeerLimits()
 	p.initLogFileLocation()
 
 	return p.initAddresses()
 }
 
+func (p *serverParams) initPrivateTxPeers() error {
+	p.privateTxPeers = make([]peer.ID, 0, len(p.rawConfig.TxPool.PrivateTxPeers))
+
+	for _, rawPeerID := range p.rawConfig.TxPool.PrivateTxPeers {
+		peerID, err := peer.Decode(rawPeerID)
+		if err != nil {
+			return fmt.Errorf("invalid private tx peer %s, %w", rawPeerID, err)
+		}
+
+		p.privateTxPeers = append(p.privateTxPeers, peerID)
+	}
+
+	return nil
+}
+
 func (p *serverParams) initBlockTime() error {
 	if p.rawConfig.BlockTime < 1 {
 		return errInvalidBlockTime
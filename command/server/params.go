@@ -3,13 +3,16 @@ package server
 import (
 	"errors"
 	"net"
+	"time"
 
 	"github.com/SECRYPT-2022/SECRYPT/chain"
 	"github.com/SECRYPT-2022/SECRYPT/command/server/config"
+	"github.com/SECRYPT-2022/SECRYPT/jsonrpc"
 	"github.com/SECRYPT-2022/SECRYPT/network"
 	"github.com/SECRYPT-2022/SECRYPT/secrets"
 	"github.com/SECRYPT-2022/SECRYPT/server"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -26,10 +29,19 @@ const (
 	maxInboundPeersFlag          = "max-inbound-peers"
 	maxOutboundPeersFlag         = "max-outbound-peers"
 	priceLimitFlag               = "price-limit"
+	priceBumpFlag                = "price-bump"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
+	jsonRPCDisabledNamespaceFlag = "json-rpc-disabled-namespace"
+	jsonRPCRateLimitFlag         = "json-rpc-rate-limit"
+	jsonRPCRateLimitBurstFlag    = "json-rpc-rate-limit-burst"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
+	journalFlag                  = "journal"
+	journalRemotesFlag           = "journal-remotes"
+	rejournalIntervalFlag        = "rejournal-interval"
+	txLifetimeFlag               = "tx-lifetime"
+	privateTxPeerFlag            = "private-tx-peer"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
@@ -38,6 +50,8 @@ const (
 	devFlag                      = "dev"
 	corsOriginFlag               = "access-control-allow-origins"
 	logFileLocationFlag          = "log-to"
+	enableAccountsFlag           = "enable-accounts"
+	keystoreFlag                 = "keystore"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -53,9 +67,14 @@ const (
 var (
 	params = &serverParams{
 		rawConfig: &config.Config{
-			Telemetry: &config.Telemetry{},
-			Network:   &config.Network{},
-			TxPool:    &config.TxPool{},
+			Telemetry:   &config.Telemetry{},
+			Network:     &config.Network{},
+			TxPool:      &config.TxPool{},
+			Accounts:    &config.Accounts{},
+			JSONRPCAuth: &config.JSONRPCAuth{},
+			JSONRPCRateLimit: &config.JSONRPCRateLimit{
+				MethodCosts: config.DefaultJSONRPCMethodCosts(),
+			},
 		},
 	}
 )
@@ -81,6 +100,8 @@ type serverParams struct {
 
 	corsAllowedOrigins []string
 
+	privateTxPeers []peer.ID
+
 	ibftBaseTimeoutLegacy uint64
 
 	genesisConfig *chain.Chain
@@ -138,6 +159,10 @@ func (p *serverParams) setRawJSONRPCAddress(jsonRPCAddress string) {
 	p.rawConfig.JSONRPCAddr = jsonRPCAddress
 }
 
+func (p *serverParams) setRawIPCPath(ipcPath string) {
+	p.rawConfig.IPCPath = ipcPath
+}
+
 func (p *serverParams) setJSONLogFormat(jsonLogFormat bool) {
 	p.rawConfig.JSONLogFormat = jsonLogFormat
 }
@@ -150,6 +175,11 @@ func (p *serverParams) generateConfig() *server.Config {
 			AccessControlAllowOrigin: p.corsAllowedOrigins,
 			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			IPCPath:                  p.rawConfig.IPCPath,
+			DisabledNamespaces:       p.rawConfig.JSONRPCDisabledNamespaces,
+			HTTPAuth:                 newJSONRPCAuth(p.rawConfig.JSONRPCAuth.HTTP),
+			WSAuth:                   newJSONRPCAuth(p.rawConfig.JSONRPCAuth.WS),
+			RateLimit:                newJSONRPCRateLimit(p.rawConfig.JSONRPCRateLimit),
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -170,13 +200,49 @@ func (p *serverParams) generateConfig() *server.Config {
 		DataDir:            p.rawConfig.DataDir,
 		Seal:               p.rawConfig.ShouldSeal,
 		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
+		PriceBump:          p.rawConfig.TxPool.PriceBump,
 		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
 		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
+		Journal:            p.rawConfig.TxPool.Journal,
+		JournalRemotes:     p.rawConfig.TxPool.JournalRemotes,
+		RejournalInterval:  time.Duration(p.rawConfig.TxPool.RejournalInterval) * time.Second,
+		TxLifetime:         time.Duration(p.rawConfig.TxPool.Lifetime) * time.Second,
+		PrivateTxPeers:     p.privateTxPeers,
 		SecretsManager:     p.secretsConfig,
 		RestoreFile:        p.getRestoreFilePath(),
 		BlockTime:          p.rawConfig.BlockTime,
 		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
 		JSONLogFormat:      p.rawConfig.JSONLogFormat,
 		LogFilePath:        p.logFileLocation,
+		Accounts: &server.Accounts{
+			Enabled:     p.rawConfig.Accounts.Enabled,
+			KeystoreDir: p.rawConfig.Accounts.KeystoreDir,
+		},
+	}
+}
+
+// newJSONRPCAuth returns the authentication config of a JSON-RPC transport, nil if not authenticated
+func newJSONRPCAuth(auth *config.JSONRPCTransportAuth) *server.JSONRPCAuth {
+	if auth == nil {
+		return nil
+	}
+
+	return &server.JSONRPCAuth{
+		JWTSubjects: auth.JWTSubjects,
+		APIKeys:     auth.APIKeys,
+		Public:      auth.Public,
+	}
+}
+
+// newJSONRPCRateLimit returns the rate limiting config of the JSON-RPC requests, nil if disabled
+func newJSONRPCRateLimit(rateLimit *config.JSONRPCRateLimit) *jsonrpc.RateLimitConfig {
+	if rateLimit == nil || rateLimit.Rate <= 0 {
+		return nil
+	}
+
+	return &jsonrpc.RateLimitConfig{
+		Rate:        rateLimit.Rate,
+		Burst:       rateLimit.Burst,
+		MethodCosts: rateLimit.MethodCosts,
 	}
 }
@@ -22,6 +22,7 @@ func GetCommand() *cobra.Command {
 	helper.RegisterGRPCAddressFlag(serverCmd)
 	helper.RegisterLegacyGRPCAddressFlag(serverCmd)
 	helper.RegisterJSONRPCFlag(serverCmd)
+	helper.RegisterIPCFlag(serverCmd)
 
 	registerSubcommands(serverCmd)
 	setFlags(serverCmd)
@@ -171,6 +172,13 @@ func setFlags(cmd *cobra.Command) {
 		),
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.PriceBump,
+		priceBumpFlag,
+		defaultConfig.TxPool.PriceBump,
+		"the minimum gas price increase (in percent) to replace a pending transaction with the same nonce",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TxPool.MaxSlots,
 		maxSlotsFlag,
@@ -185,6 +193,41 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.TxPool.Journal,
+		journalFlag,
+		defaultConfig.TxPool.Journal,
+		"keep the local pool transactions in a journal file across restarts",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.TxPool.JournalRemotes,
+		journalRemotesFlag,
+		defaultConfig.TxPool.JournalRemotes,
+		"journal the gossiped pool transactions as well",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.RejournalInterval,
+		rejournalIntervalFlag,
+		defaultConfig.TxPool.RejournalInterval,
+		"time in seconds between the compactions of the pool journal",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.Lifetime,
+		txLifetimeFlag,
+		defaultConfig.TxPool.Lifetime,
+		"maximum time in seconds a transaction stays in the pool, transactions don't expire if 0",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.TxPool.PrivateTxPeers,
+		privateTxPeerFlag,
+		defaultConfig.TxPool.PrivateTxPeers,
+		"the libp2p ID of a validator peer the private transactions are forwarded to",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.BlockTime,
 		blockTimeFlag,
@@ -214,6 +257,28 @@ func setFlags(cmd *cobra.Command) {
 			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
 	)
 
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.JSONRPCDisabledNamespaces,
+		jsonRPCDisabledNamespaceFlag,
+		defaultConfig.JSONRPCDisabledNamespaces,
+		"the json-rpc namespace that isn't served (e.g. debug), can be repeated",
+	)
+
+	cmd.Flags().Float64Var(
+		&params.rawConfig.JSONRPCRateLimit.Rate,
+		jsonRPCRateLimitFlag,
+		defaultConfig.JSONRPCRateLimit.Rate,
+		"the json-rpc request cost refilled per second for each client (API key, JWT subject or IP), "+
+			"value of 0 disables the rate limiting",
+	)
+
+	cmd.Flags().Float64Var(
+		&params.rawConfig.JSONRPCRateLimit.Burst,
+		jsonRPCRateLimitBurstFlag,
+		defaultConfig.JSONRPCRateLimit.Burst,
+		"the json-rpc request cost a client can spend at once when rate limited",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.LogFilePath,
 		logFileLocationFlag,
@@ -221,6 +286,21 @@ func setFlags(cmd *cobra.Command) {
 		"write all logs to the file at specified location instead of writing them to console",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.Accounts.Enabled,
+		enableAccountsFlag,
+		defaultConfig.Accounts.Enabled,
+		"enable the node accounts, their keys being stored encrypted in the keystore directory "+
+			"and used through the personal_ and eth_sign* json-rpc methods and eth_sendTransaction",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.Accounts.KeystoreDir,
+		keystoreFlag,
+		defaultConfig.Accounts.KeystoreDir,
+		"the directory of the node accounts key files (default <data-dir>/keystore)",
+	)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
@@ -265,6 +345,7 @@ func runPreRun(cmd *cobra.Command, _ []string) error {
 	// The config file will have precedence over --flag
 	params.setRawGRPCAddress(helper.GetGRPCAddress(cmd))
 	params.setRawJSONRPCAddress(helper.GetJSONRPCAddress(cmd))
+	params.setRawIPCPath(helper.GetIPCPath(cmd))
 	params.setJSONLogFormat(helper.GetJSONLogFormat(cmd))
 
 	// Check if the config file has been specified
@@ -20,6 +20,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(statusCmd)
+	helper.RegisterIPCClientFlag(statusCmd)
 
 	return statusCmd
 }
@@ -28,7 +29,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
-	statusResponse, err := getSystemStatus(helper.GetGRPCAddress(cmd))
+	statusResponse, err := getSystemStatus(helper.GetNodeAddress(cmd))
 	if err != nil {
 		outputter.SetError(err)
 
@@ -0,0 +1,92 @@
+package get
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SECRYPT-2022/SECRYPT/command"
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	"github.com/SECRYPT-2022/SECRYPT/command/txpool/list"
+	txpoolOp "github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+)
+
+const (
+	hashFlag    = "hash"
+	accountFlag = "account"
+)
+
+var (
+	errHashOrAccountRequired = errors.New("either the transaction hash or the account is required")
+)
+
+var (
+	params = &getParams{}
+)
+
+type getParams struct {
+	hash    string
+	account string
+
+	txnResponse     *txpoolOp.GetTxnResp
+	accountResponse *txpoolOp.AccountStatusResp
+}
+
+func (p *getParams) validateFlags() error {
+	if p.hash == "" && p.account == "" {
+		return errHashOrAccountRequired
+	}
+
+	return nil
+}
+
+func (p *getParams) initResponse(grpcAddress string) error {
+	client, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	if p.hash != "" {
+		p.txnResponse, err = client.GetTxn(
+			context.Background(),
+			&txpoolOp.GetTxnReq{
+				Hash: p.hash,
+			},
+		)
+
+		return err
+	}
+
+	p.accountResponse, err = client.AccountStatus(
+		context.Background(),
+		&txpoolOp.AccountReq{
+			Address: p.account,
+		},
+	)
+
+	return err
+}
+
+func (p *getParams) getResult() command.CommandResult {
+	if p.txnResponse != nil {
+		return &TxPoolGetTxnResult{
+			TxnResult: list.NewTxnResult(p.txnResponse.Txn),
+		}
+	}
+
+	gaps := make([]*NonceGapResult, len(p.accountResponse.Gaps))
+	for i, gap := range p.accountResponse.Gaps {
+		gaps[i] = &NonceGapResult{
+			First: gap.First,
+			Last:  gap.Last,
+		}
+	}
+
+	return &TxPoolAccountResult{
+		Address:   p.account,
+		NextNonce: p.accountResponse.NextNonce,
+		Promoted:  p.accountResponse.Promoted,
+		Enqueued:  p.accountResponse.Enqueued,
+		Gaps:      gaps,
+		Local:     p.accountResponse.Local,
+	}
+}
@@ -0,0 +1,69 @@
+package get
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	"github.com/SECRYPT-2022/SECRYPT/command/txpool/list"
+)
+
+type TxPoolGetTxnResult struct {
+	*list.TxnResult
+}
+
+func (r *TxPoolGetTxnResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL TRANSACTION]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("From|%s", r.From),
+		fmt.Sprintf("To|%s", r.To),
+		fmt.Sprintf("Nonce|%d", r.Nonce),
+		fmt.Sprintf("Value|%s", r.Value),
+		fmt.Sprintf("Gas Price|%s", r.GasPrice),
+		fmt.Sprintf("Gas|%d", r.Gas),
+		fmt.Sprintf("State|%s", r.State),
+		fmt.Sprintf("Private|%t", r.Private),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+type NonceGapResult struct {
+	First uint64 `json:"first"`
+	Last  uint64 `json:"last"`
+}
+
+type TxPoolAccountResult struct {
+	Address   string            `json:"address"`
+	NextNonce uint64            `json:"nextNonce"`
+	Promoted  uint64            `json:"promoted"`
+	Enqueued  uint64            `json:"enqueued"`
+	Gaps      []*NonceGapResult `json:"gaps"`
+	Local     bool              `json:"local"`
+}
+
+func (r *TxPoolAccountResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	gaps := make([]string, len(r.Gaps))
+	for i, gap := range r.Gaps {
+		gaps[i] = fmt.Sprintf("%d-%d", gap.First, gap.Last)
+	}
+
+	buffer.WriteString("\n[TXPOOL ACCOUNT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Address|%s", r.Address),
+		fmt.Sprintf("Next nonce|%d", r.NextNonce),
+		fmt.Sprintf("Promoted transactions|%d", r.Promoted),
+		fmt.Sprintf("Enqueued transactions|%d", r.Enqueued),
+		fmt.Sprintf("Nonce gaps|%s", gaps),
+		fmt.Sprintf("Local|%t", r.Local),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,55 @@
+package get
+
+import (
+	"github.com/SECRYPT-2022/SECRYPT/command"
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	txPoolGetCmd := &cobra.Command{
+		Use:     "get",
+		Short:   "Returns a transaction in the transaction pool, or the status of an account in the pool",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(txPoolGetCmd)
+
+	return txPoolGetCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.hash,
+		hashFlag,
+		"",
+		"the hash of the transaction",
+	)
+
+	cmd.Flags().StringVar(
+		&params.account,
+		accountFlag,
+		"",
+		"the address of the account",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(hashFlag, accountFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initResponse(helper.GetNodeAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,95 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/SECRYPT-2022/SECRYPT/command"
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	txpoolOp "github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+)
+
+const (
+	accountFlag = "account"
+	stateFlag   = "state"
+	offsetFlag  = "offset"
+	limitFlag   = "limit"
+)
+
+const (
+	allState      = "all"
+	promotedState = "promoted"
+	enqueuedState = "enqueued"
+)
+
+var (
+	errInvalidState = errors.New("invalid transaction state")
+)
+
+var (
+	params = &listParams{}
+)
+
+type listParams struct {
+	account  string
+	stateRaw string
+	offset   uint64
+	limit    uint64
+
+	state txpoolOp.TxnState
+
+	listResponse *txpoolOp.ListTxnsResp
+}
+
+func (p *listParams) initRawParams() error {
+	switch p.stateRaw {
+	case allState:
+		p.state = txpoolOp.TxnState_ANY_STATE
+	case promotedState:
+		p.state = txpoolOp.TxnState_STATE_PROMOTED
+	case enqueuedState:
+		p.state = txpoolOp.TxnState_STATE_ENQUEUED
+	default:
+		return fmt.Errorf("%w: %s", errInvalidState, p.stateRaw)
+	}
+
+	return nil
+}
+
+func (p *listParams) listTxns(grpcAddress string) error {
+	client, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ListTxns(
+		context.Background(),
+		&txpoolOp.ListTxnsReq{
+			Account: p.account,
+			State:   p.state,
+			Offset:  p.offset,
+			Limit:   p.limit,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.listResponse = resp
+
+	return nil
+}
+
+func (p *listParams) getResult() command.CommandResult {
+	txns := make([]*TxnResult, len(p.listResponse.Txns))
+	for i, txn := range p.listResponse.Txns {
+		txns[i] = NewTxnResult(txn)
+	}
+
+	return &TxPoolListResult{
+		Total:  p.listResponse.Total,
+		Offset: p.offset,
+		Txns:   txns,
+	}
+}
@@ -0,0 +1,83 @@
+package list
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	txpoolOp "github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+)
+
+type TxnResult struct {
+	Hash     string `json:"hash"`
+	From     string `json:"from"`
+	To       string `json:"to,omitempty"`
+	Nonce    uint64 `json:"nonce"`
+	Value    string `json:"value"`
+	GasPrice string `json:"gasPrice"`
+	Gas      uint64 `json:"gas"`
+	State    string `json:"state"`
+	Private  bool   `json:"private"`
+}
+
+func NewTxnResult(txn *txpoolOp.TxnInfo) *TxnResult {
+	return &TxnResult{
+		Hash:     txn.Hash,
+		From:     txn.From,
+		To:       txn.To,
+		Nonce:    txn.Nonce,
+		Value:    txn.Value,
+		GasPrice: txn.GasPrice,
+		Gas:      txn.Gas,
+		State:    stateName(txn.State),
+		Private:  txn.Private,
+	}
+}
+
+func stateName(state txpoolOp.TxnState) string {
+	switch state {
+	case txpoolOp.TxnState_STATE_PROMOTED:
+		return promotedState
+	case txpoolOp.TxnState_STATE_ENQUEUED:
+		return enqueuedState
+	default:
+		return strings.ToLower(state.String())
+	}
+}
+
+type TxPoolListResult struct {
+	Total  uint64       `json:"total"`
+	Offset uint64       `json:"offset"`
+	Txns   []*TxnResult `json:"transactions"`
+}
+
+func (r *TxPoolListResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL TRANSACTIONS]\n")
+
+	if len(r.Txns) == 0 {
+		buffer.WriteString(fmt.Sprintf("No transactions found (total: %d)", r.Total))
+	} else {
+		buffer.WriteString(fmt.Sprintf(
+			"Transactions %d to %d of %d\n\n",
+			r.Offset+1,
+			r.Offset+uint64(len(r.Txns)),
+			r.Total,
+		))
+
+		rows := make([]string, len(r.Txns)+1)
+		rows[0] = "Hash|From|Nonce|Gas Price|State"
+
+		for i, txn := range r.Txns {
+			rows[i+1] = fmt.Sprintf("%s|%s|%d|%s|%s", txn.Hash, txn.From, txn.Nonce, txn.GasPrice, txn.State)
+		}
+
+		buffer.WriteString(helper.FormatList(rows))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,74 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/SECRYPT-2022/SECRYPT/command"
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	txPoolListCmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the transactions in the transaction pool, ordered by account and nonce",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(txPoolListCmd)
+
+	return txPoolListCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.account,
+		accountFlag,
+		"",
+		"only lists the transactions of this account",
+	)
+
+	cmd.Flags().StringVar(
+		&params.stateRaw,
+		stateFlag,
+		allState,
+		fmt.Sprintf(
+			"only lists the transactions in this state. Possible values: [%s, %s, %s]",
+			allState,
+			promotedState,
+			enqueuedState,
+		),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.offset,
+		offsetFlag,
+		0,
+		"the number of transactions to skip",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.limit,
+		limitFlag,
+		100,
+		"the maximum number of transactions to list",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.listTxns(helper.GetNodeAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,71 @@
+package remove
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SECRYPT-2022/SECRYPT/command"
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	txpoolOp "github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+)
+
+const (
+	hashFlag    = "hash"
+	accountFlag = "account"
+)
+
+var (
+	errHashOrAccountRequired = errors.New("either the transaction hash or the account is required")
+)
+
+var (
+	params = &removeParams{}
+)
+
+type removeParams struct {
+	hash    string
+	account string
+
+	removeResponse *txpoolOp.RemoveTxnsResp
+}
+
+func (p *removeParams) validateFlags() error {
+	if p.hash == "" && p.account == "" {
+		return errHashOrAccountRequired
+	}
+
+	return nil
+}
+
+func (p *removeParams) removeTxns(grpcAddress string) error {
+	client, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	if p.hash != "" {
+		p.removeResponse, err = client.RemoveTxn(
+			context.Background(),
+			&txpoolOp.RemoveTxnReq{
+				Hash: p.hash,
+			},
+		)
+
+		return err
+	}
+
+	p.removeResponse, err = client.RemoveAccount(
+		context.Background(),
+		&txpoolOp.AccountReq{
+			Address: p.account,
+		},
+	)
+
+	return err
+}
+
+func (p *removeParams) getResult() command.CommandResult {
+	return &TxPoolRemoveResult{
+		Removed: p.removeResponse.TxHashes,
+	}
+}
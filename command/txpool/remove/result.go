@@ -0,0 +1,33 @@
+package remove
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+)
+
+type TxPoolRemoveResult struct {
+	Removed []string `json:"removed"`
+}
+
+func (r *TxPoolRemoveResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL REMOVE]\n")
+	buffer.WriteString(fmt.Sprintf("Number of removed transactions: %d\n", len(r.Removed)))
+
+	if len(r.Removed) > 0 {
+		rows := make([]string, len(r.Removed))
+		for i, hash := range r.Removed {
+			rows[i] = fmt.Sprintf("[%d]|%s", i, hash)
+		}
+
+		buffer.WriteString("\n")
+		buffer.WriteString(helper.FormatKV(rows))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
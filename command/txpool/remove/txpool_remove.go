@@ -0,0 +1,56 @@
+package remove
+
+import (
+	"github.com/SECRYPT-2022/SECRYPT/command"
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	txPoolRemoveCmd := &cobra.Command{
+		Use: "remove",
+		Short: "Removes a transaction, or all the transactions of an account, from the transaction pool. " +
+			"The following transactions of the account are enqueued again",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(txPoolRemoveCmd)
+
+	return txPoolRemoveCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.hash,
+		hashFlag,
+		"",
+		"the hash of the transaction to remove",
+	)
+
+	cmd.Flags().StringVar(
+		&params.account,
+		accountFlag,
+		"",
+		"the address of the account whose transactions are removed",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(hashFlag, accountFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.removeTxns(helper.GetNodeAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -23,7 +23,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
-	statusResponse, err := getTxPoolStatus(helper.GetGRPCAddress(cmd))
+	statusResponse, err := getTxPoolStatus(helper.GetNodeAddress(cmd))
 	if err != nil {
 		outputter.SetError(err)
 
@@ -14,6 +14,8 @@ var (
 	droppedFlag        = "dropped"
 	prunedPromotedFlag = "pruned-promoted"
 	prunedEnqueuedFlag = "pruned-enqueued"
+	replacedFlag       = "replaced"
+	evictedFlag        = "evicted"
 )
 
 type subscribeParams struct {
@@ -31,6 +33,8 @@ func (sp *subscribeParams) initEventMap() {
 		proto.EventType_DEMOTED:         &falseRaw,
 		proto.EventType_PRUNED_PROMOTED: &falseRaw,
 		proto.EventType_PRUNED_ENQUEUED: &falseRaw,
+		proto.EventType_REPLACED:        &falseRaw,
+		proto.EventType_EVICTED:         &falseRaw,
 	}
 }
 
@@ -65,5 +69,7 @@ func (sp *subscribeParams) setAllEvents() {
 		proto.EventType_DEMOTED,
 		proto.EventType_PRUNED_PROMOTED,
 		proto.EventType_PRUNED_ENQUEUED,
+		proto.EventType_REPLACED,
+		proto.EventType_EVICTED,
 	}
 }
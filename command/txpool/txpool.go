@@ -2,8 +2,12 @@ package txpool
 
 import (
 	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	"github.com/SECRYPT-2022/SECRYPT/command/txpool/get"
+	"github.com/SECRYPT-2022/SECRYPT/command/txpool/list"
+	"github.com/SECRYPT-2022/SECRYPT/command/txpool/remove"
 	"github.com/SECRYPT-2022/SECRYPT/command/txpool/status"
 	"github.com/SECRYPT-2022/SECRYPT/command/txpool/subscribe"
+	"github.com/SECRYPT-2022/SECRYPT/command/txpool/txstatus"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +18,7 @@ func GetCommand() *cobra.Command {
 	}
 
 	helper.RegisterGRPCAddressFlag(txPoolCmd)
+	helper.RegisterIPCClientFlag(txPoolCmd)
 
 	registerSubcommands(txPoolCmd)
 
@@ -26,5 +31,13 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		status.GetCommand(),
 		// txpool subscribe
 		subscribe.GetCommand(),
+		// txpool list
+		list.GetCommand(),
+		// txpool get
+		get.GetCommand(),
+		// txpool remove
+		remove.GetCommand(),
+		// txpool tx-status
+		txstatus.GetCommand(),
 	)
 }
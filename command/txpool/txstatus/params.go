@@ -0,0 +1,73 @@
+package txstatus
+
+import (
+	"context"
+
+	"github.com/SECRYPT-2022/SECRYPT/command"
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	txpoolOp "github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+)
+
+const (
+	hashFlag = "hash"
+)
+
+var (
+	params = &txStatusParams{}
+)
+
+type txStatusParams struct {
+	hash string
+
+	statusResponse *txpoolOp.TxnStatusResp
+}
+
+func (p *txStatusParams) getRequiredFlags() []string {
+	return []string{
+		hashFlag,
+	}
+}
+
+func (p *txStatusParams) initTxStatus(grpcAddress string) error {
+	client, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	statusResponse, err := client.TxnStatus(
+		context.Background(),
+		&txpoolOp.GetTxnReq{
+			Hash: p.hash,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.statusResponse = statusResponse
+
+	return nil
+}
+
+func (p *txStatusParams) getResult() command.CommandResult {
+	history := make([]*TxEventResult, len(p.statusResponse.History))
+	for i, event := range p.statusResponse.History {
+		history[i] = &TxEventResult{
+			Type:   event.Type,
+			Reason: event.Reason,
+			Time:   event.Time,
+		}
+	}
+
+	return &TxPoolTxStatusResult{
+		Hash:             p.hash,
+		State:            p.statusResponse.State,
+		From:             p.statusResponse.From,
+		Nonce:            p.statusResponse.Nonce,
+		NextNonce:        p.statusResponse.NextNonce,
+		MissingNonces:    p.statusResponse.MissingNonces,
+		GasPrice:         p.statusResponse.GasPrice,
+		RequiredGasPrice: p.statusResponse.RequiredGasPrice,
+		History:          history,
+	}
+}
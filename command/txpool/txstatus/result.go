@@ -0,0 +1,74 @@
+package txstatus
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+)
+
+type TxEventResult struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason,omitempty"`
+	Time   int64  `json:"time"`
+}
+
+type TxPoolTxStatusResult struct {
+	Hash             string           `json:"hash"`
+	State            string           `json:"state"`
+	From             string           `json:"from,omitempty"`
+	Nonce            uint64           `json:"nonce"`
+	NextNonce        uint64           `json:"nextNonce"`
+	MissingNonces    []uint64         `json:"missingNonces"`
+	GasPrice         string           `json:"gasPrice,omitempty"`
+	RequiredGasPrice string           `json:"requiredGasPrice"`
+	History          []*TxEventResult `json:"history"`
+}
+
+func (r *TxPoolTxStatusResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL TRANSACTION STATUS]\n")
+
+	rows := []string{
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("State|%s", r.State),
+	}
+
+	if r.From != "" {
+		rows = append(rows,
+			fmt.Sprintf("From|%s", r.From),
+			fmt.Sprintf("Nonce|%d", r.Nonce),
+			fmt.Sprintf("Next account nonce|%d", r.NextNonce),
+			fmt.Sprintf("Missing nonces|%v", r.MissingNonces),
+			fmt.Sprintf("Gas price|%s", r.GasPrice),
+		)
+	}
+
+	rows = append(rows, fmt.Sprintf("Required gas price|%s", r.RequiredGasPrice))
+
+	buffer.WriteString(helper.FormatKV(rows))
+	buffer.WriteString("\n")
+
+	if len(r.History) > 0 {
+		buffer.WriteString("\n[HISTORY]\n")
+
+		events := make([]string, len(r.History)+1)
+		events[0] = "Time|Event|Reason"
+
+		for i, event := range r.History {
+			events[i+1] = fmt.Sprintf(
+				"%s|%s|%s",
+				time.UnixMilli(event.Time).UTC().Format(time.RFC3339),
+				event.Type,
+				event.Reason,
+			)
+		}
+
+		buffer.WriteString(helper.FormatList(events))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
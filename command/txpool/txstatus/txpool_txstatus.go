@@ -0,0 +1,43 @@
+package txstatus
+
+import (
+	"github.com/SECRYPT-2022/SECRYPT/command"
+	"github.com/SECRYPT-2022/SECRYPT/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	txPoolTxStatusCmd := &cobra.Command{
+		Use: "tx-status",
+		Short: "Returns the state of a transaction in the transaction pool, " +
+			"along with the events explaining why it's stuck or gone",
+		Run: runCommand,
+	}
+
+	setFlags(txPoolTxStatusCmd)
+	helper.SetRequiredFlags(txPoolTxStatusCmd, params.getRequiredFlags())
+
+	return txPoolTxStatusCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.hash,
+		hashFlag,
+		"",
+		"the hash of the transaction",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initTxStatus(helper.GetNodeAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
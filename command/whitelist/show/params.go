@@ -27,6 +27,7 @@ type showParams struct {
 
 type Whitelists struct {
 	deployment []types.Address
+	allowLists *chain.AllowLists
 }
 
 func (p *showParams) initRawParams() error {
@@ -58,6 +59,7 @@ func (p *showParams) initWhitelists() error {
 	// set whitelists
 	p.whitelists = Whitelists{
 		deployment: deploymentWhitelist,
+		allowLists: genesisConfig.Params.AllowLists,
 	}
 
 	return nil
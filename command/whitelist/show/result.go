@@ -16,5 +16,17 @@ func (r *ShowResult) GetOutput() string {
 
 	buffer.WriteString(fmt.Sprintf("Contract deployment whitelist : %s,\n", r.Whitelists.deployment))
 
+	if allowLists := r.Whitelists.allowLists; allowLists != nil {
+		buffer.WriteString("\n[ALLOW LISTS]\n\n")
+
+		if allowLists.Deployment != nil {
+			buffer.WriteString(fmt.Sprintf("Contract deployment allow list : active from block %d\n", *allowLists.Deployment))
+		}
+
+		if allowLists.Transaction != nil {
+			buffer.WriteString(fmt.Sprintf("Transaction allow list : active from block %d\n", *allowLists.Transaction))
+		}
+	}
+
 	return buffer.String()
 }
@@ -34,6 +34,9 @@ type Consensus interface {
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
 
+	// GetFinalizedHeader returns the last finalized header, which can't be reverted by a reorg
+	GetFinalizedHeader() *types.Header
+
 	// Initialize initializes the consensus (e.g. setup data)
 	Initialize() error
 
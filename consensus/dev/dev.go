@@ -237,6 +237,11 @@ func (d *Dev) GetSyncProgression() *progress.Progression {
 	return nil
 }
 
+// GetFinalizedHeader returns the latest header, there are no reorgs
+func (d *Dev) GetFinalizedHeader() *types.Header {
+	return d.blockchain.Header()
+}
+
 func (d *Dev) Close() error {
 	close(d.closeCh)
 
@@ -69,6 +69,11 @@ func (d *Dummy) GetSyncProgression() *progress.Progression {
 	return nil
 }
 
+// GetFinalizedHeader returns the latest header, there are no reorgs
+func (d *Dummy) GetFinalizedHeader() *types.Header {
+	return d.blockchain.Header()
+}
+
 func (d *Dummy) Close() error {
 	close(d.closeCh)
 
@@ -2,6 +2,7 @@ package ibft
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -11,9 +12,12 @@ import (
 	"github.com/SECRYPT-2022/SECRYPT/consensus/ibft/signer"
 	"github.com/SECRYPT-2022/SECRYPT/helper/hex"
 	"github.com/SECRYPT-2022/SECRYPT/state"
+	"github.com/SECRYPT-2022/SECRYPT/txpool"
 	"github.com/SECRYPT-2022/SECRYPT/types"
 )
 
+var errBundleTxReverted = errors.New("bundle transaction reverted")
+
 func (i *backendIBFT) BuildProposal(blockNumber uint64) []byte {
 	var (
 		latestHeader      = i.blockchain.Header()
@@ -283,6 +287,9 @@ type txExeResult struct {
 type transitionInterface interface {
 	Write(txn *types.Transaction) error
 	WriteFailedReceipt(txn *types.Transaction) error
+	Receipts() []*types.Receipt
+	Snapshot() int
+	RevertToSnapshot(id int)
 }
 
 func (i *backendIBFT) writeTransactions(
@@ -301,6 +308,7 @@ func (i *backendIBFT) writeTransactions(
 		successful = 0
 		failed     = 0
 		skipped    = 0
+		bundles    = 0
 	)
 
 	defer func() {
@@ -309,10 +317,29 @@ func (i *backendIBFT) writeTransactions(
 			"successful", successful,
 			"failed", failed,
 			"skipped", skipped,
+			"bundles", bundles,
 			"remaining", i.txpool.Length(),
 		)
 	}()
 
+	// bundles go first, all or nothing
+	for _, bundle := range i.txpool.Bundles(blockNumber) {
+		if writeCtx.Err() != nil {
+			return
+		}
+
+		if !i.writeBundle(bundle, transition, gasLimit) {
+			// not retried by the next proposals
+			i.txpool.RemoveBundle(bundle.Hash)
+
+			continue
+		}
+
+		executed = append(executed, bundle.Txs...)
+		successful += len(bundle.Txs)
+		bundles++
+	}
+
 	i.txpool.Prepare()
 
 write:
@@ -352,6 +379,51 @@ write:
 	return
 }
 
+// writeBundle writes the bundle transactions in order,
+// and rolls them all back if any of them fails or reverts without being allowed to
+func (i *backendIBFT) writeBundle(
+	bundle *txpool.Bundle,
+	transition transitionInterface,
+	gasLimit uint64,
+) bool {
+	snapshot := transition.Snapshot()
+
+	for _, tx := range bundle.Txs {
+		if err := i.simulateBundleTx(bundle, tx, transition, gasLimit); err != nil {
+			i.logger.Debug("discarding bundle", "hash", bundle.Hash, "tx", tx.Hash, "err", err)
+			transition.RevertToSnapshot(snapshot)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+func (i *backendIBFT) simulateBundleTx(
+	bundle *txpool.Bundle,
+	tx *types.Transaction,
+	transition transitionInterface,
+	gasLimit uint64,
+) error {
+	if tx.ExceedsBlockGasLimit(gasLimit) {
+		return txpool.ErrBlockLimitExceeded
+	}
+
+	if err := transition.Write(tx); err != nil {
+		return err
+	}
+
+	receipts := transition.Receipts()
+	reverted := *receipts[len(receipts)-1].Status == types.ReceiptFailed
+
+	if reverted && !bundle.CanRevert(tx.Hash) {
+		return errBundleTxReverted
+	}
+
+	return nil
+}
+
 func (i *backendIBFT) writeTransaction(
 	tx *types.Transaction,
 	transition transitionInterface,
@@ -1,9 +1,13 @@
 package ibft
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/SECRYPT-2022/SECRYPT/txpool"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -111,3 +115,135 @@ func TestIBFTBackend_RoundUpTime(t *testing.T) {
 		})
 	}
 }
+
+// mockTransition writes the transactions with the receipt status
+// set by the results, and rolls them back on revert
+type mockTransition struct {
+	receipts  []*types.Receipt
+	snapshots []int
+
+	// per-transaction write errors and receipt statuses
+	errors   map[types.Hash]error
+	statuses map[types.Hash]types.ReceiptStatus
+}
+
+func (m *mockTransition) Write(tx *types.Transaction) error {
+	if err, ok := m.errors[tx.Hash]; ok {
+		return err
+	}
+
+	status, ok := m.statuses[tx.Hash]
+	if !ok {
+		status = types.ReceiptSuccess
+	}
+
+	receipt := &types.Receipt{TxHash: tx.Hash}
+	receipt.SetStatus(status)
+
+	m.receipts = append(m.receipts, receipt)
+
+	return nil
+}
+
+func (m *mockTransition) WriteFailedReceipt(tx *types.Transaction) error {
+	return nil
+}
+
+func (m *mockTransition) Receipts() []*types.Receipt {
+	return m.receipts
+}
+
+func (m *mockTransition) Snapshot() int {
+	m.snapshots = append(m.snapshots, len(m.receipts))
+
+	return len(m.snapshots) - 1
+}
+
+func (m *mockTransition) RevertToSnapshot(id int) {
+	m.receipts = m.receipts[:m.snapshots[id]]
+	m.snapshots = m.snapshots[:id]
+}
+
+// TestIBFTBackend_WriteBundle verifies that bundles are written all together or not at all
+func TestIBFTBackend_WriteBundle(t *testing.T) {
+	t.Parallel()
+
+	txs := make([]*types.Transaction, 3)
+	for idx := range txs {
+		txs[idx] = &types.Transaction{Nonce: uint64(idx), Gas: 21000}
+		txs[idx].ComputeHash()
+	}
+
+	errWrite := errors.New("write error")
+
+	testTable := []struct {
+		name              string
+		revertingTxHashes []types.Hash
+		errors            map[types.Hash]error
+		statuses          map[types.Hash]types.ReceiptStatus
+
+		expectedWritten bool
+	}{
+		{
+			"All transactions succeed",
+			nil,
+			nil,
+			nil,
+			true,
+		},
+		{
+			"Transaction reverts",
+			nil,
+			nil,
+			map[types.Hash]types.ReceiptStatus{txs[1].Hash: types.ReceiptFailed},
+			false,
+		},
+		{
+			"Transaction allowed to revert",
+			[]types.Hash{txs[1].Hash},
+			nil,
+			map[types.Hash]types.ReceiptStatus{txs[1].Hash: types.ReceiptFailed},
+			true,
+		},
+		{
+			"Transaction not applied",
+			nil,
+			map[types.Hash]error{txs[2].Hash: errWrite},
+			nil,
+			false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			i := &backendIBFT{
+				logger: hclog.NewNullLogger(),
+			}
+
+			// a transaction written before the bundle is kept
+			transition := &mockTransition{
+				receipts: []*types.Receipt{{}},
+				errors:   testCase.errors,
+				statuses: testCase.statuses,
+			}
+
+			bundle := txpool.NewBundle(txs, 1, testCase.revertingTxHashes)
+
+			assert.Equal(
+				t,
+				testCase.expectedWritten,
+				i.writeBundle(bundle, transition, 100000),
+			)
+
+			if testCase.expectedWritten {
+				assert.Len(t, transition.receipts, 1+len(txs))
+			} else {
+				assert.Len(t, transition.receipts, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package fork
+
+import (
+	"github.com/SECRYPT-2022/SECRYPT/consensus/ibft/signer"
+)
+
+// NewRecoverOnlySigner returns the signer of the fork at the given height without loading
+// any validator key. It can only recover the signers of the headers,
+// which is enough to re-execute the stored blocks offline
+func NewRecoverOnlySigner(forks IBFTForks, height uint64) (signer.Signer, error) {
+	getKeyManager := func(height uint64) (signer.KeyManager, error) {
+		fork := forks.getFork(height)
+		if fork == nil {
+			return nil, ErrForkNotFound
+		}
+
+		return signer.NewRecoverOnlyKeyManager(fork.ValidatorType)
+	}
+
+	keyManager, err := getKeyManager(height)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentKeyManager signer.KeyManager
+
+	if height > 1 {
+		if parentKeyManager, err = getKeyManager(height - 1); err != nil {
+			return nil, err
+		}
+	}
+
+	return signer.NewSigner(
+		keyManager,
+		parentKeyManager,
+	), nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/SECRYPT-2022/SECRYPT/secrets"
 	"github.com/SECRYPT-2022/SECRYPT/state"
 	"github.com/SECRYPT-2022/SECRYPT/syncer"
+	"github.com/SECRYPT-2022/SECRYPT/txpool"
 	"github.com/SECRYPT-2022/SECRYPT/types"
 	"github.com/SECRYPT-2022/SECRYPT/validators"
 	"github.com/armon/go-metrics"
@@ -51,6 +52,8 @@ type txPoolInterface interface {
 	Demote(tx *types.Transaction)
 	ResetWithHeaders(headers ...*types.Header)
 	SetSealing(bool)
+	Bundles(blockNumber uint64) []*txpool.Bundle
+	RemoveBundle(hash types.Hash)
 }
 
 type forkManagerInterface interface {
@@ -97,19 +100,11 @@ type backendIBFT struct {
 // Factory implements the base consensus Factory method
 func Factory(params *consensus.Params) (consensus.Consensus, error) {
 	// defaults for user set fields in genesis
-	var (
-		epochSize          = uint64(DefaultEpochSize)
-		quorumSizeBlockNum = uint64(0)
-	)
+	quorumSizeBlockNum := uint64(0)
 
-	if definedEpochSize, ok := params.Config.Config[KeyEpochSize]; ok {
-		// Epoch size is defined, use the passed in one
-		readSize, ok := definedEpochSize.(float64)
-		if !ok {
-			return nil, errors.New("invalid type assertion")
-		}
-
-		epochSize = uint64(readSize)
+	epochSize, err := getEpochSize(params.Config.Config)
+	if err != nil {
+		return nil, err
 	}
 
 	if rawBlockNum, ok := params.Config.Config["quorumSizeBlockNum"]; ok {
@@ -482,6 +477,29 @@ func (i *backendIBFT) PreCommitState(header *types.Header, txn *state.Transition
 	return hooks.PreCommitState(header, txn)
 }
 
+// GetFinalizedHeader returns the last finalized header.
+// IBFT has instant finality, a block is only inserted into the chain once its committed seals
+// reach the quorum of the validators (VerifyHeader during the sync), so the head is final
+func (i *backendIBFT) GetFinalizedHeader() *types.Header {
+	return i.blockchain.Header()
+}
+
+// getEpochSize returns the epoch size defined in the engine config, or the default one
+func getEpochSize(config map[string]interface{}) (uint64, error) {
+	definedEpochSize, ok := config[KeyEpochSize]
+	if !ok {
+		return DefaultEpochSize, nil
+	}
+
+	// Epoch size is defined, use the passed in one
+	readSize, ok := definedEpochSize.(float64)
+	if !ok {
+		return 0, errors.New("invalid type assertion")
+	}
+
+	return uint64(readSize), nil
+}
+
 // GetEpoch returns the current epoch
 func (i *backendIBFT) GetEpoch(number uint64) uint64 {
 	if number%i.epochSize == 0 {
@@ -0,0 +1,70 @@
+package ibft
+
+import (
+	"github.com/SECRYPT-2022/SECRYPT/consensus/ibft/fork"
+	"github.com/SECRYPT-2022/SECRYPT/consensus/ibft/hook"
+	"github.com/SECRYPT-2022/SECRYPT/state"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+)
+
+// ReplayVerifier provides the parts of IBFT needed to re-execute the stored blocks offline.
+// It doesn't need the validator keys, the validator stores nor a running consensus
+type ReplayVerifier struct {
+	forks        fork.IBFTForks
+	hookRegister *fork.PoSHookRegister
+}
+
+// NewReplayVerifier creates a ReplayVerifier from the IBFT engine config in genesis
+func NewReplayVerifier(config map[string]interface{}) (*ReplayVerifier, error) {
+	epochSize, err := getEpochSize(config)
+	if err != nil {
+		return nil, err
+	}
+
+	forks, err := fork.GetIBFTForks(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplayVerifier{
+		forks:        forks,
+		hookRegister: fork.NewPoSHookRegister(forks, epochSize),
+	}, nil
+}
+
+// SetHeaderHash updates hash calculation function for IBFT
+func (r *ReplayVerifier) SetHeaderHash() {
+	types.HeaderHash = func(h *types.Header) types.Hash {
+		signer, err := fork.NewRecoverOnlySigner(r.forks, h.Number)
+		if err != nil {
+			return types.ZeroHash
+		}
+
+		hash, err := signer.CalculateHeaderHash(h)
+		if err != nil {
+			return types.ZeroHash
+		}
+
+		return hash
+	}
+}
+
+// GetBlockCreator retrieves the block signer from the extra data field
+func (r *ReplayVerifier) GetBlockCreator(header *types.Header) (types.Address, error) {
+	signer, err := fork.NewRecoverOnlySigner(r.forks, header.Number)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return signer.EcrecoverFromHeader(header)
+}
+
+// PreCommitState a hook to be called before finalizing state transition on inserting block
+func (r *ReplayVerifier) PreCommitState(header *types.Header, txn *state.Transition) error {
+	hooks := &hook.Hooks{}
+
+	// only PoS modifies the state, by deploying the staking contract
+	r.hookRegister.RegisterHooks(hooks, header.Number)
+
+	return hooks.PreCommitState(header, txn)
+}
@@ -109,6 +109,19 @@ func NewKeyManagerFromType(
 	}
 }
 
+// NewRecoverOnlyKeyManager returns a KeyManager of the given type holding no key,
+// which can only be used to parse IBFT Extra and recover the signers of the seals
+func NewRecoverOnlyKeyManager(validatorType validators.ValidatorType) (KeyManager, error) {
+	switch validatorType {
+	case validators.ECDSAValidatorType:
+		return &ECDSAKeyManager{}, nil
+	case validators.BLSValidatorType:
+		return &BLSKeyManager{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported validator type: %s", validatorType)
+	}
+}
+
 // verifyIBFTExtraSize checks whether header.ExtraData has enough size for IBFT Extra
 func verifyIBFTExtraSize(header *types.Header) error {
 	if len(header.ExtraData) < IstanbulExtraVanity {
@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/bits"
@@ -25,8 +26,23 @@ type TxSigner interface {
 
 	// CalculateV calculates the V value based on the type of signer used
 	CalculateV(parity byte) []byte
+
+	// FeePayerHash returns the hash signed by the fee payer of a fee delegated transaction
+	FeePayerHash(tx *types.Transaction) types.Hash
+
+	// FeePayer returns the fee payer of a fee delegated transaction
+	FeePayer(tx *types.Transaction) (types.Address, error)
+
+	// SignFeePayerTx signs a fee delegated transaction as its fee payer
+	SignFeePayerTx(tx *types.Transaction, priv *ecdsa.PrivateKey) (*types.Transaction, error)
 }
 
+var (
+	ErrNotFeeDelegated    = errors.New("transaction is not fee delegated")
+	ErrFeePayerMismatch   = errors.New("fee payer signature does not match the fee payer")
+	ErrMissingFeePayerSig = errors.New("missing fee payer signature")
+)
+
 // NewSigner creates a new signer object (EIP155 or FrontierSigner)
 func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 	var signer TxSigner
@@ -63,6 +79,12 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 	v.Set(a.NewBigInt(tx.Value))
 	v.Set(a.NewCopyBytes(tx.Input))
 
+	// the sender of a fee delegated transaction commits to its fee payer
+	if tx.IsFeeDelegated() {
+		v.Set(a.NewUint(uint64(tx.Type)))
+		setFeePayer(a, v, tx)
+	}
+
 	// EIP155
 	if chainID != 0 {
 		v.Set(a.NewUint(chainID))
@@ -77,6 +99,119 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 	return types.BytesToHash(hash)
 }
 
+// calcFeePayerHash calculates the hash signed by the fee payer of a fee delegated transaction.
+// It covers the sender signature, so the fee payer only pays for the transaction of that sender
+func calcFeePayerHash(tx *types.Transaction, chainID uint64) types.Hash {
+	a := signerPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewUint(uint64(tx.Type)))
+	v.Set(a.NewUint(tx.Nonce))
+	v.Set(a.NewBigInt(tx.GasPrice))
+	v.Set(a.NewUint(tx.Gas))
+
+	if tx.To == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes((*tx.To).Bytes()))
+	}
+
+	v.Set(a.NewBigInt(tx.Value))
+	v.Set(a.NewCopyBytes(tx.Input))
+	setFeePayer(a, v, tx)
+
+	// sender signature values
+	v.Set(a.NewBigInt(tx.V))
+	v.Set(a.NewBigInt(tx.R))
+	v.Set(a.NewBigInt(tx.S))
+
+	v.Set(a.NewUint(chainID))
+
+	hash := keccak.Keccak256Rlp(nil, v)
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+func setFeePayer(a *fastrlp.Arena, v *fastrlp.Value, tx *types.Transaction) {
+	if tx.FeePayer == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes((*tx.FeePayer).Bytes()))
+	}
+}
+
+// recoverAddress returns the address which signed the hash
+func recoverAddress(hash types.Hash, r, s *big.Int, parity byte) (types.Address, error) {
+	sig, err := encodeSignature(r, s, parity)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	pub, err := Ecrecover(hash.Bytes(), sig)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	buf := Keccak256(pub[1:])[12:]
+
+	return types.BytesToAddress(buf), nil
+}
+
+// verifyFeePayer checks the fee payer signature of the transaction was made by its fee payer
+func verifyFeePayer(tx *types.Transaction, recoverFn func() (types.Address, error)) (types.Address, error) {
+	if !tx.IsFeeDelegated() || tx.FeePayer == nil {
+		return types.Address{}, ErrNotFeeDelegated
+	}
+
+	if tx.FeePayerR == nil || tx.FeePayerS == nil {
+		return types.Address{}, ErrMissingFeePayerSig
+	}
+
+	feePayer, err := recoverFn()
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	if feePayer != *tx.FeePayer {
+		return types.Address{}, ErrFeePayerMismatch
+	}
+
+	return feePayer, nil
+}
+
+// signFeePayer signs the fee delegated transaction with the fee payer key
+func signFeePayer(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+	hash func(*types.Transaction) types.Hash,
+	calculateV func(byte) []byte,
+) (*types.Transaction, error) {
+	if !tx.IsFeeDelegated() || tx.FeePayer == nil {
+		return nil, ErrNotFeeDelegated
+	}
+
+	if PubKeyToAddress(&privateKey.PublicKey) != *tx.FeePayer {
+		return nil, ErrFeePayerMismatch
+	}
+
+	tx = tx.Copy()
+
+	h := hash(tx)
+
+	sig, err := Sign(privateKey, h[:])
+	if err != nil {
+		return nil, err
+	}
+
+	tx.FeePayerR = new(big.Int).SetBytes(sig[:32])
+	tx.FeePayerS = new(big.Int).SetBytes(sig[32:64])
+	tx.FeePayerV = new(big.Int).SetBytes(calculateV(sig[64]))
+
+	return tx, nil
+}
+
 // Hash is a wrapper function for the calcTxHash, with chainID 0
 func (f *FrontierSigner) Hash(tx *types.Transaction) types.Hash {
 	return calcTxHash(tx, 0)
@@ -97,19 +232,34 @@ func (f *FrontierSigner) Sender(tx *types.Transaction) (types.Address, error) {
 
 	refV.Sub(refV, big27)
 
-	sig, err := encodeSignature(tx.R, tx.S, byte(refV.Int64()))
-	if err != nil {
-		return types.Address{}, err
-	}
+	return recoverAddress(f.Hash(tx), tx.R, tx.S, byte(refV.Int64()))
+}
 
-	pub, err := Ecrecover(f.Hash(tx).Bytes(), sig)
-	if err != nil {
-		return types.Address{}, err
-	}
+// FeePayerHash is a wrapper function for the calcFeePayerHash, with chainID 0
+func (f *FrontierSigner) FeePayerHash(tx *types.Transaction) types.Hash {
+	return calcFeePayerHash(tx, 0)
+}
 
-	buf := Keccak256(pub[1:])[12:]
+// FeePayer decodes the fee payer signature and returns the fee payer of the transaction
+func (f *FrontierSigner) FeePayer(tx *types.Transaction) (types.Address, error) {
+	return verifyFeePayer(tx, func() (types.Address, error) {
+		refV := big.NewInt(0)
+		if tx.FeePayerV != nil {
+			refV.SetBytes(tx.FeePayerV.Bytes())
+		}
 
-	return types.BytesToAddress(buf), nil
+		refV.Sub(refV, big27)
+
+		return recoverAddress(f.FeePayerHash(tx), tx.FeePayerR, tx.FeePayerS, byte(refV.Int64()))
+	})
+}
+
+// SignFeePayerTx signs the fee delegated transaction using the passed in fee payer private key
+func (f *FrontierSigner) SignFeePayerTx(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	return signFeePayer(tx, privateKey, f.FeePayerHash, f.CalculateV)
 }
 
 // SignTx signs the transaction using the passed in private key
@@ -157,20 +307,52 @@ func (e *EIP155Signer) Hash(tx *types.Transaction) types.Hash {
 
 // Sender returns the transaction sender
 func (e *EIP155Signer) Sender(tx *types.Transaction) (types.Address, error) {
-	protected := true
+	parity, protected := e.parity(tx.V)
+	if !protected {
+		return (&FrontierSigner{}).Sender(tx)
+	}
+
+	return recoverAddress(e.Hash(tx), tx.R, tx.S, parity)
+}
+
+// FeePayerHash is a wrapper function that calls calcFeePayerHash with the EIP155Signer's chainID
+func (e *EIP155Signer) FeePayerHash(tx *types.Transaction) types.Hash {
+	return calcFeePayerHash(tx, e.chainID)
+}
+
+// FeePayer returns the fee payer of the fee delegated transaction
+func (e *EIP155Signer) FeePayer(tx *types.Transaction) (types.Address, error) {
+	parity, protected := e.parity(tx.FeePayerV)
+	if !protected {
+		return (&FrontierSigner{}).FeePayer(tx)
+	}
+
+	return verifyFeePayer(tx, func() (types.Address, error) {
+		return recoverAddress(e.FeePayerHash(tx), tx.FeePayerR, tx.FeePayerS, parity)
+	})
+}
+
+// SignFeePayerTx signs the fee delegated transaction using the passed in fee payer private key
+func (e *EIP155Signer) SignFeePayerTx(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	return signFeePayer(tx, privateKey, e.FeePayerHash, e.CalculateV)
+}
 
+// parity returns the recovery id of the signature with the given V value,
+// and whether the signature conforms to EIP155
+func (e *EIP155Signer) parity(v *big.Int) (byte, bool) {
 	// Check if v value conforms to an earlier standard (before EIP155)
 	bigV := big.NewInt(0)
-	if tx.V != nil {
-		bigV.SetBytes(tx.V.Bytes())
+	if v != nil {
+		bigV.SetBytes(v.Bytes())
 	}
 
 	if vv := bigV.Uint64(); bits.Len(uint(vv)) <= 8 {
-		protected = vv != 27 && vv != 28
-	}
-
-	if !protected {
-		return (&FrontierSigner{}).Sender(tx)
+		if vv == 27 || vv == 28 {
+			return 0, false
+		}
 	}
 
 	// Reverse the V calculation to find the original V in the range [0, 1]
@@ -179,19 +361,7 @@ func (e *EIP155Signer) Sender(tx *types.Transaction) (types.Address, error) {
 	bigV.Sub(bigV, mulOperand)
 	bigV.Sub(bigV, big35)
 
-	sig, err := encodeSignature(tx.R, tx.S, byte(bigV.Int64()))
-	if err != nil {
-		return types.Address{}, err
-	}
-
-	pub, err := Ecrecover(e.Hash(tx).Bytes(), sig)
-	if err != nil {
-		return types.Address{}, err
-	}
-
-	buf := Keccak256(pub[1:])[12:]
-
-	return types.BytesToAddress(buf), nil
+	return byte(bigV.Int64()), true
 }
 
 // SignTx signs the transaction using the passed in private key
@@ -143,3 +143,73 @@ func TestEIP155Signer_ChainIDMismatch(t *testing.T) {
 		}
 	}
 }
+
+func TestEIP155Signer_FeePayer(t *testing.T) {
+	t.Parallel()
+
+	signer := NewEIP155Signer(100)
+	toAddress := types.StringToAddress("1")
+
+	senderKey, err := GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	feePayerKey, err := GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	feePayer := PubKeyToAddress(&feePayerKey.PublicKey)
+
+	txn := &types.Transaction{
+		To:       &toAddress,
+		Value:    big.NewInt(10),
+		GasPrice: big.NewInt(1),
+		Gas:      21000,
+		Type:     types.FeeDelegatedTx,
+		FeePayer: &feePayer,
+	}
+
+	signedTx, err := signer.SignTx(txn, senderKey)
+	assert.NoError(t, err)
+
+	// the fee payer can only sign for itself
+	_, err = signer.SignFeePayerTx(signedTx, senderKey)
+	assert.ErrorIs(t, err, ErrFeePayerMismatch)
+
+	signedTx, err = signer.SignFeePayerTx(signedTx, feePayerKey)
+	assert.NoError(t, err)
+
+	from, err := signer.Sender(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&senderKey.PublicKey), from)
+
+	payer, err := signer.FeePayer(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, feePayer, payer)
+
+	// the sender signature is bound to the fee payer
+	otherPayer := types.StringToAddress("2")
+	tamperedTx := signedTx.Copy()
+	tamperedTx.FeePayer = &otherPayer
+
+	from, err = signer.Sender(tamperedTx)
+	if err == nil {
+		assert.NotEqual(t, PubKeyToAddress(&senderKey.PublicKey), from)
+	}
+
+	// the fee payer signature is bound to the sender signature
+	tamperedTx = signedTx.Copy()
+	tamperedTx.Nonce++
+
+	_, err = signer.FeePayer(tamperedTx)
+	assert.Error(t, err)
+
+	// legacy transactions have no fee payer
+	legacyTx, err := signer.SignTx(&types.Transaction{
+		To:       &toAddress,
+		Value:    big.NewInt(10),
+		GasPrice: big.NewInt(1),
+	}, senderKey)
+	assert.NoError(t, err)
+
+	_, err = signer.FeePayer(legacyTx)
+	assert.ErrorIs(t, err, ErrNotFeeDelegated)
+}
@@ -0,0 +1,139 @@
+package ipc
+
+import (
+	"bufio"
+	"net"
+	"sync"
+)
+
+// grpcPrefaceStart is the first byte of the HTTP/2 client connection preface
+// ("PRI * HTTP/2.0..."), the GRPC clients open their connections with it.
+// A JSON stream can't start with it
+const grpcPrefaceStart = 'P'
+
+// Mux splits the connections accepted by an IPC listener between two listeners,
+// the GRPC connections and the other (JSON-RPC) ones
+type Mux struct {
+	lis   net.Listener
+	grpc  *muxListener
+	other *muxListener
+}
+
+// NewMux creates the mux of the IPC listener, it has to be started with Serve
+func NewMux(lis net.Listener) *Mux {
+	return &Mux{
+		lis:   lis,
+		grpc:  newMuxListener(lis.Addr()),
+		other: newMuxListener(lis.Addr()),
+	}
+}
+
+// GRPC returns the listener of the GRPC connections
+func (m *Mux) GRPC() net.Listener {
+	return m.grpc
+}
+
+// Other returns the listener of the non GRPC connections
+func (m *Mux) Other() net.Listener {
+	return m.other
+}
+
+// Serve accepts the IPC connections and routes them, until the IPC listener is closed
+func (m *Mux) Serve() error {
+	defer func() {
+		_ = m.grpc.Close()
+		_ = m.other.Close()
+	}()
+
+	for {
+		conn, err := m.lis.Accept()
+		if err != nil {
+			return err
+		}
+
+		go m.route(conn)
+	}
+}
+
+// Close closes the IPC listener, and the listeners of the mux with it
+func (m *Mux) Close() error {
+	return m.lis.Close()
+}
+
+// route hands the connection to the listener matching its first byte
+func (m *Mux) route(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+
+	first, err := reader.Peek(1)
+	if err != nil {
+		_ = conn.Close()
+
+		return
+	}
+
+	bufConn := &bufferedConn{Conn: conn, reader: reader}
+
+	if first[0] == grpcPrefaceStart {
+		m.grpc.push(bufConn)
+	} else {
+		m.other.push(bufConn)
+	}
+}
+
+// bufferedConn is a connection whose first bytes were already read in the buffer
+type bufferedConn struct {
+	net.Conn
+
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// muxListener is a listener fed by the mux
+type muxListener struct {
+	addr      net.Addr
+	conns     chan net.Conn
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+func newMuxListener(addr net.Addr) *muxListener {
+	return &muxListener{
+		addr:    addr,
+		conns:   make(chan net.Conn),
+		closeCh: make(chan struct{}),
+	}
+}
+
+// push hands over the connection to the listener, it's closed if the listener is
+func (l *muxListener) push(conn net.Conn) {
+	select {
+	case l.conns <- conn:
+	case <-l.closeCh:
+		_ = conn.Close()
+	}
+}
+
+func (l *muxListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closeCh:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close stops the listener, the IPC listener is closed by the mux
+func (l *muxListener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.closeCh)
+	})
+
+	return nil
+}
+
+func (l *muxListener) Addr() net.Addr {
+	return l.addr
+}
@@ -0,0 +1,149 @@
+//go:build !windows
+// +build !windows
+
+package ipc
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func newTestMux(t *testing.T) (*Mux, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "secrypt.ipc")
+
+	lis, err := Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := NewMux(lis)
+
+	go func() {
+		_ = mux.Serve()
+	}()
+
+	t.Cleanup(func() {
+		_ = mux.Close()
+	})
+
+	return mux, path
+}
+
+func TestMux_Route(t *testing.T) {
+	t.Parallel()
+
+	mux, path := newTestMux(t)
+
+	// the GRPC connections are served by a GRPC server
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+
+	go func() {
+		_ = grpcServer.Serve(mux.GRPC())
+	}()
+
+	t.Cleanup(grpcServer.Stop)
+
+	// the other connections get their whole stream
+	go func() {
+		conn, err := mux.Other().Accept()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		_, _ = conn.Write([]byte(line))
+	}()
+
+	t.Run("other connection", func(t *testing.T) {
+		t.Parallel()
+
+		conn, err := DialTimeout(path, time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer conn.Close()
+
+		request := `{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}` + "\n"
+
+		_, err = conn.Write([]byte(request))
+		assert.NoError(t, err)
+
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		echo, err := bufio.NewReader(conn).ReadString('\n')
+		assert.NoError(t, err)
+		assert.Equal(t, request, echo)
+	})
+
+	t.Run("GRPC connection", func(t *testing.T) {
+		t.Parallel()
+
+		conn, err := grpc.Dial(
+			"passthrough:///"+path,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return Dial(path)
+			}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	})
+}
+
+func TestMux_Close(t *testing.T) {
+	t.Parallel()
+
+	mux, path := newTestMux(t)
+
+	assert.NoError(t, mux.Close())
+
+	// the listeners of the mux are closed with the IPC listener
+	for _, lis := range []net.Listener{mux.GRPC(), mux.Other()} {
+		accepted := make(chan error, 1)
+
+		go func(lis net.Listener) {
+			_, err := lis.Accept()
+			accepted <- err
+		}(lis)
+
+		select {
+		case err := <-accepted:
+			assert.ErrorIs(t, err, net.ErrClosed)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the mux listener isn't closed")
+		}
+	}
+
+	_, err := DialTimeout(path, time.Second)
+	assert.Error(t, err)
+}
@@ -0,0 +1,284 @@
+package jsonrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// header of the static API keys, they can also be sent as bearer tokens
+	apiKeyHeader = "X-API-Key"
+
+	// query parameter of the credentials of the websocket connections,
+	// as the browsers can't set the headers of the websocket handshake
+	tokenQueryParam = "token"
+
+	// access list entry allowing all the namespaces and methods
+	allowAll = "*"
+
+	// JWT subject matching all the tokens whose subject isn't configured
+	anyJWTSubject = "*"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidJWT         = errors.New("invalid jwt")
+	ErrExpiredJWT         = errors.New("expired jwt")
+)
+
+// AuthConfig is the authentication config of a JSON-RPC transport,
+// every entry of the access lists being either a namespace ("eth"),
+// a method ("eth_call") or "*" for all of them
+type AuthConfig struct {
+	// JWTSecret is the HS256 key of the JWT bearer tokens, they're rejected if empty
+	JWTSecret []byte
+
+	// JWTSubjects maps the subject claim of the JWT tokens to their access list,
+	// the "*" subject matching the tokens whose subject isn't listed
+	JWTSubjects map[string][]string
+
+	// APIKeys maps the static API keys to their access list
+	APIKeys map[string][]string
+
+	// Public is the access list of the requests without credentials
+	Public []string
+}
+
+// accessList is the set of the namespaces and methods a client is allowed to call
+type accessList struct {
+	all        bool
+	namespaces map[string]bool
+	methods    map[string]bool
+}
+
+func newAccessList(entries []string) *accessList {
+	list := &accessList{
+		namespaces: make(map[string]bool),
+		methods:    make(map[string]bool),
+	}
+
+	for _, entry := range entries {
+		switch {
+		case entry == allowAll:
+			list.all = true
+		case strings.Contains(entry, "_"):
+			list.methods[entry] = true
+		default:
+			list.namespaces[entry] = true
+		}
+	}
+
+	return list
+}
+
+// allows returns true if the method can be called
+func (l *accessList) allows(method string) bool {
+	if l.all || l.methods[method] {
+		return true
+	}
+
+	return l.namespaces[strings.SplitN(method, "_", 2)[0]]
+}
+
+// client is the caller of the requests, nil for the trusted ones (IPC)
+type client struct {
+	// identifies the client for the rate limiting: its API key, JWT subject or IP
+	id string
+
+	// namespaces and methods it's allowed to call, all of them if nil
+	acl *accessList
+}
+
+// allows returns true if the client can call the method
+func (c *client) allows(method string) bool {
+	return c == nil || c.acl == nil || c.acl.allows(method)
+}
+
+// authenticator authenticates the requests of a transport with its config
+type authenticator struct {
+	jwtSecret   []byte
+	jwtSubjects map[string]*accessList
+	apiKeys     map[string]*accessList
+	public      *accessList
+}
+
+// newAuthenticator returns the authenticator of the config,
+// nil if the transport isn't authenticated
+func newAuthenticator(config *AuthConfig) *authenticator {
+	if config == nil {
+		return nil
+	}
+
+	a := &authenticator{
+		jwtSecret:   config.JWTSecret,
+		jwtSubjects: make(map[string]*accessList, len(config.JWTSubjects)),
+		apiKeys:     make(map[string]*accessList, len(config.APIKeys)),
+		public:      newAccessList(config.Public),
+	}
+
+	for subject, entries := range config.JWTSubjects {
+		a.jwtSubjects[subject] = newAccessList(entries)
+	}
+
+	for key, entries := range config.APIKeys {
+		a.apiKeys[key] = newAccessList(entries)
+	}
+
+	return a
+}
+
+// authenticate returns the client of the request credentials, with the public access list
+// if the request has none. The transports without authenticator allow all the namespaces
+func (a *authenticator) authenticate(req *http.Request, allowQuery bool) (*client, error) {
+	ipClient := &client{id: "ip:" + remoteIP(req)}
+
+	if a == nil {
+		return ipClient, nil
+	}
+
+	credentials := req.Header.Get(apiKeyHeader)
+
+	if credentials == "" {
+		if bearer := req.Header.Get("Authorization"); bearer != "" {
+			if !strings.HasPrefix(bearer, "Bearer ") {
+				return nil, ErrInvalidCredentials
+			}
+
+			credentials = strings.TrimPrefix(bearer, "Bearer ")
+		}
+	}
+
+	if credentials == "" && allowQuery {
+		credentials = req.URL.Query().Get(tokenQueryParam)
+	}
+
+	if credentials == "" {
+		ipClient.acl = a.public
+
+		return ipClient, nil
+	}
+
+	if acl := a.apiKeyAccessList(credentials); acl != nil {
+		return &client{id: "key:" + credentials, acl: acl}, nil
+	}
+
+	// the credentials that aren't API keys can only be JWT tokens
+	if len(a.jwtSecret) == 0 || strings.Count(credentials, ".") != 2 {
+		return nil, ErrInvalidCredentials
+	}
+
+	claims, err := parseJWT(credentials, a.jwtSecret, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	acl, ok := a.jwtSubjects[claims.Subject]
+	if !ok {
+		if acl, ok = a.jwtSubjects[anyJWTSubject]; !ok {
+			return nil, fmt.Errorf("%w: unknown subject %q", ErrInvalidCredentials, claims.Subject)
+		}
+	}
+
+	return &client{id: "jwt:" + claims.Subject, acl: acl}, nil
+}
+
+// remoteIP returns the IP of the request peer
+func remoteIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
+
+// apiKeyAccessList returns the access list of the API key, nil if unknown.
+// The keys are compared in constant time so that they can't be guessed from the timing
+func (a *authenticator) apiKeyAccessList(apiKey string) *accessList {
+	var found *accessList
+
+	for key, acl := range a.apiKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+			found = acl
+		}
+	}
+
+	return found
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt *int64 `json:"exp"`
+	NotBefore *int64 `json:"nbf"`
+}
+
+// parseJWT verifies the HS256 signature and the time claims of the JWT token, returning its claims
+func parseJWT(token string, secret []byte, now time.Time) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidJWT
+	}
+
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+
+	// the algorithm is fixed, the tokens can't pick a weaker one (or none)
+	if header.Algorithm != "HS256" {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidJWT, header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidJWT
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("%w: invalid signature", ErrInvalidJWT)
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	if claims.ExpiresAt != nil && now.Unix() >= *claims.ExpiresAt {
+		return nil, ErrExpiredJWT
+	}
+
+	if claims.NotBefore != nil && now.Unix() < *claims.NotBefore {
+		return nil, fmt.Errorf("%w: not valid yet", ErrInvalidJWT)
+	}
+
+	return &claims, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return ErrInvalidJWT
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return ErrInvalidJWT
+	}
+
+	return nil
+}
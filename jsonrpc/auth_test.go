@@ -0,0 +1,245 @@
+package jsonrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testJWTSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// signTestJWT returns the token of the claims, signed with the algorithm of the header
+func signTestJWT(header, claims string, secret []byte) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseJWT(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	hs256 := `{"alg":"HS256","typ":"JWT"}`
+
+	tests := []struct {
+		name    string
+		token   string
+		subject string
+		err     error
+	}{
+		{
+			name:    "valid token",
+			token:   signTestJWT(hs256, `{"sub":"admin","exp":1700000060}`, testJWTSecret),
+			subject: "admin",
+		},
+		{
+			name:  "token without time claims",
+			token: signTestJWT(hs256, `{}`, testJWTSecret),
+		},
+		{
+			name:  "expired token",
+			token: signTestJWT(hs256, `{"sub":"admin","exp":1700000000}`, testJWTSecret),
+			err:   ErrExpiredJWT,
+		},
+		{
+			name:  "token not valid yet",
+			token: signTestJWT(hs256, `{"sub":"admin","nbf":1700000060}`, testJWTSecret),
+			err:   ErrInvalidJWT,
+		},
+		{
+			name:  "token signed with another secret",
+			token: signTestJWT(hs256, `{"sub":"admin"}`, []byte("another secret")),
+			err:   ErrInvalidJWT,
+		},
+		{
+			name:  "unsigned token",
+			token: signTestJWT(`{"alg":"none"}`, `{"sub":"admin"}`, testJWTSecret),
+			err:   ErrInvalidJWT,
+		},
+		{
+			name:  "malformed token",
+			token: "not.a.token",
+			err:   ErrInvalidJWT,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			claims, err := parseJWT(tt.token, testJWTSecret, now)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.subject, claims.Subject)
+		})
+	}
+}
+
+func TestAccessList(t *testing.T) {
+	t.Parallel()
+
+	list := newAccessList([]string{"eth", "debug_traceTransaction"})
+
+	assert.True(t, list.allows("eth_call"))
+	assert.True(t, list.allows("eth_subscribe"))
+	assert.True(t, list.allows("debug_traceTransaction"))
+	assert.False(t, list.allows("debug_traceBlockByNumber"))
+	assert.False(t, list.allows("txpool_content"))
+
+	assert.True(t, newAccessList([]string{"*"}).allows("txpool_content"))
+	assert.False(t, newAccessList(nil).allows("eth_call"))
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	auth := newAuthenticator(&AuthConfig{
+		JWTSecret: testJWTSecret,
+		JWTSubjects: map[string][]string{
+			"admin": {"*"},
+			"*":     {"eth", "net"},
+		},
+		APIKeys: map[string][]string{
+			"key": {"eth", "debug"},
+		},
+		Public: []string{"web3"},
+	})
+
+	hs256 := `{"alg":"HS256","typ":"JWT"}`
+
+	tests := []struct {
+		name       string
+		header     string
+		value      string
+		query      string
+		allowQuery bool
+		allowed    string
+		denied     string
+		fails      bool
+	}{
+		{
+			name:    "no credentials",
+			allowed: "web3_clientVersion",
+			denied:  "eth_call",
+		},
+		{
+			name:    "api key header",
+			header:  apiKeyHeader,
+			value:   "key",
+			allowed: "debug_traceTransaction",
+			denied:  "web3_clientVersion",
+		},
+		{
+			name:    "api key bearer",
+			header:  "Authorization",
+			value:   "Bearer key",
+			allowed: "debug_traceTransaction",
+			denied:  "txpool_content",
+		},
+		{
+			name:    "jwt of a listed subject",
+			header:  "Authorization",
+			value:   "Bearer " + signTestJWT(hs256, `{"sub":"admin"}`, testJWTSecret),
+			allowed: "txpool_content",
+		},
+		{
+			name:    "jwt of another subject",
+			header:  "Authorization",
+			value:   "Bearer " + signTestJWT(hs256, `{"sub":"user"}`, testJWTSecret),
+			allowed: "net_version",
+			denied:  "debug_traceTransaction",
+		},
+		{
+			name:       "query token",
+			query:      "key",
+			allowQuery: true,
+			allowed:    "debug_traceTransaction",
+		},
+		{
+			name:    "query token not allowed",
+			query:   "key",
+			allowed: "web3_clientVersion",
+			denied:  "debug_traceTransaction",
+		},
+		{
+			name:   "unknown api key",
+			header: apiKeyHeader,
+			value:  "unknown",
+			fails:  true,
+		},
+		{
+			name:   "invalid jwt",
+			header: "Authorization",
+			value:  "Bearer " + signTestJWT(hs256, `{"sub":"admin"}`, []byte("another secret")),
+			fails:  true,
+		},
+		{
+			name:   "not a bearer",
+			header: "Authorization",
+			value:  "Basic a2V5Og==",
+			fails:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "/?token="+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+
+			c, err := auth.authenticate(req, tt.allowQuery)
+
+			if tt.fails {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			if tt.allowed != "" {
+				assert.True(t, c.allows(tt.allowed))
+			}
+
+			if tt.denied != "" {
+				assert.False(t, c.allows(tt.denied))
+			}
+		})
+	}
+}
+
+func TestAuthenticator_NotAuthenticated(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+
+	c, err := newAuthenticator(nil).authenticate(req, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ip:10.0.0.1", c.id)
+	assert.True(t, c.allows("debug_traceTransaction"))
+}
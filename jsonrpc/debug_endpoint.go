@@ -27,6 +27,9 @@ type debugBlockchainStore interface {
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
+	// GetFinalizedHeader returns the last finalized header
+	GetFinalizedHeader() *types.Header
+
 	// GetHeaderByNumber gets a header using the provided number
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 
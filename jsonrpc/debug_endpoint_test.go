@@ -13,22 +13,27 @@ import (
 )
 
 type debugEndpointMockStore struct {
-	headerFn            func() *types.Header
-	getHeaderByNumberFn func(uint64) (*types.Header, bool)
-	readTxLookupFn      func(types.Hash) (types.Hash, bool)
-	getBlockByHashFn    func(types.Hash, bool) (*types.Block, bool)
-	getBlockByNumberFn  func(uint64, bool) (*types.Block, bool)
-	traceBlockFn        func(*types.Block, tracer.Tracer) ([]interface{}, error)
-	traceTxnFn          func(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
-	traceCallFn         func(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
-	getNonceFn          func(types.Address) uint64
-	getAccountFn        func(types.Hash, types.Address) (*Account, error)
+	headerFn             func() *types.Header
+	getFinalizedHeaderFn func() *types.Header
+	getHeaderByNumberFn  func(uint64) (*types.Header, bool)
+	readTxLookupFn       func(types.Hash) (types.Hash, bool)
+	getBlockByHashFn     func(types.Hash, bool) (*types.Block, bool)
+	getBlockByNumberFn   func(uint64, bool) (*types.Block, bool)
+	traceBlockFn         func(*types.Block, tracer.Tracer) ([]interface{}, error)
+	traceTxnFn           func(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
+	traceCallFn          func(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+	getNonceFn           func(types.Address) uint64
+	getAccountFn         func(types.Hash, types.Address) (*Account, error)
 }
 
 func (s *debugEndpointMockStore) Header() *types.Header {
 	return s.headerFn()
 }
 
+func (s *debugEndpointMockStore) GetFinalizedHeader() *types.Header {
+	return s.getFinalizedHeaderFn()
+}
+
 func (s *debugEndpointMockStore) GetHeaderByNumber(num uint64) (*types.Header, bool) {
 	return s.getHeaderByNumberFn(num)
 }
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/hashicorp/go-hclog"
@@ -30,11 +32,13 @@ func (f *funcData) numParams() int {
 }
 
 type endpoints struct {
-	Eth    *Eth
-	Web3   *Web3
-	Net    *Net
-	TxPool *TxPool
-	Debug  *Debug
+	Eth      *Eth
+	Web3     *Web3
+	Net      *Net
+	TxPool   *TxPool
+	Debug    *Debug
+	Trace    *Trace
+	Personal *Personal
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -44,6 +48,7 @@ type Dispatcher struct {
 	serviceMap    map[string]*serviceData
 	filterManager *FilterManager
 	endpoints     endpoints
+	rateLimiter   *rateLimiter
 
 	params *dispatcherParams
 }
@@ -55,6 +60,15 @@ type dispatcherParams struct {
 	priceLimit              uint64
 	jsonRPCBatchLengthLimit uint64
 	blockRangeLimit         uint64
+
+	// manager of the node accounts, nil if they're disabled
+	accounts AccountManager
+
+	// namespaces that aren't served
+	disabledNamespaces []string
+
+	// per client rate limiting of the requests, disabled if nil
+	rateLimit *RateLimitConfig
 }
 
 func newDispatcher(
@@ -63,8 +77,9 @@ func newDispatcher(
 	params *dispatcherParams,
 ) *Dispatcher {
 	d := &Dispatcher{
-		logger: logger.Named("dispatcher"),
-		params: params,
+		logger:      logger.Named("dispatcher"),
+		params:      params,
+		rateLimiter: newRateLimiter(params.rateLimit),
 	}
 
 	if store != nil {
@@ -83,7 +98,8 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) {
 		store,
 		d.params.chainID,
 		d.filterManager,
-		d.params.priceLimit,
+		newGasPriceOracle(store, d.params.priceLimit),
+		d.params.accounts,
 	}
 	d.endpoints.Net = &Net{
 		store,
@@ -99,12 +115,26 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) {
 	d.endpoints.Debug = &Debug{
 		store,
 	}
+	d.endpoints.Trace = &Trace{
+		store,
+		d.params.blockRangeLimit,
+	}
 
 	d.registerService("eth", d.endpoints.Eth)
 	d.registerService("net", d.endpoints.Net)
 	d.registerService("web3", d.endpoints.Web3)
 	d.registerService("txpool", d.endpoints.TxPool)
 	d.registerService("debug", d.endpoints.Debug)
+	d.registerService("trace", d.endpoints.Trace)
+
+	// the personal namespace is only available with the node accounts enabled
+	if d.params.accounts != nil {
+		d.endpoints.Personal = &Personal{
+			d.params.accounts,
+		}
+
+		d.registerService("personal", d.endpoints.Personal)
+	}
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -169,15 +199,28 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 	}
 
 	var filterID string
-	if subscribeMethod == "newHeads" {
+
+	switch subscribeMethod {
+	case "newHeads":
 		filterID = d.filterManager.NewBlockFilter(conn)
-	} else if subscribeMethod == "logs" {
+	case "logs":
 		logQuery, err := decodeLogQueryFromInterface(params[1])
 		if err != nil {
 			return "", NewInternalError(err.Error())
 		}
 		filterID = d.filterManager.NewLogFilter(logQuery, conn)
-	} else {
+	case "newPendingTransactions":
+		// the hashes are notified unless the full transactions are asked for
+		fullTx := false
+		if len(params) > 1 {
+			if fullTx, ok = params[1].(bool); !ok {
+				return "", NewInvalidParamsError("Invalid params")
+			}
+		}
+		filterID = d.filterManager.NewPendingTxFilter(fullTx, conn)
+	case "syncing":
+		filterID = d.filterManager.NewSyncingFilter(conn)
+	default:
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
 
@@ -206,41 +249,82 @@ func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 	d.filterManager.RemoveFilterByWs(conn)
 }
 
-func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
-	var req Request
-	if err := json.Unmarshal(reqBody, &req); err != nil {
-		return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
+// checkAccess returns an error if the method namespace is disabled or the client
+// isn't allowed to call it, the method being reported as not available either way
+func (d *Dispatcher) checkAccess(method string, c *client) Error {
+	namespace := strings.SplitN(method, "_", 2)[0]
+
+	if _, ok := d.serviceMap[namespace]; !ok || !c.allows(method) {
+		return NewMethodNotFoundError(method)
+	}
+
+	return nil
+}
+
+// checkRateLimit returns an error if the client exceeded its rate limit,
+// the trusted clients aren't rate limited
+func (d *Dispatcher) checkRateLimit(method string, c *client) Error {
+	if d.rateLimiter == nil || c == nil {
+		return nil
+	}
+
+	// the methods that aren't served are rejected before charging the bucket,
+	// the rate limiter metrics being labeled with the served methods only
+	if d.metricsMethod(method) == unknownMethodLabel {
+		return NewMethodNotFoundError(method)
+	}
+
+	if ok, retryAfter := d.rateLimiter.take(c.id, method); !ok {
+		return NewLimitExceededError(method, retryAfter)
+	}
+
+	return nil
+}
+
+// handleSubscriptionReq handles the eth_subscribe and eth_unsubscribe requests,
+// returning the ID of the new subscription or whether it was removed
+func (d *Dispatcher) handleSubscriptionReq(req Request, conn wsConn, c *client) (string, Error) {
+	if err := d.checkAccess(req.Method, c); err != nil {
+		return "", err
+	}
+
+	if err := d.checkRateLimit(req.Method, c); err != nil {
+		return "", err
 	}
 
 	// if the request method is eth_subscribe we need to create a
 	// new filter with ws connection
 	if req.Method == "eth_subscribe" {
-		filterID, err := d.handleSubscribe(req, conn)
-		if err != nil {
-			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
-		}
+		return d.handleSubscribe(req, conn)
+	}
 
-		resp, err := formatFilterResponse(req.ID, filterID)
+	ok, err := d.handleUnsubscribe(req)
+	if err != nil {
+		return "", err
+	}
 
-		if err != nil {
-			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
-		}
+	return strconv.FormatBool(ok), nil
+}
 
-		return []byte(resp), nil
+func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn, c *client) ([]byte, error) {
+	var req Request
+	if err := json.Unmarshal(reqBody, &req); err != nil {
+		return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 	}
 
-	if req.Method == "eth_unsubscribe" {
-		ok, err := d.handleUnsubscribe(req)
-		if err != nil {
-			return nil, err
-		}
+	// the subscriptions are handled here as they need the connection
+	if req.Method == "eth_subscribe" || req.Method == "eth_unsubscribe" {
+		start := time.Now()
+
+		result, err := d.handleSubscriptionReq(req, conn, c)
 
-		res := "false"
-		if ok {
-			res = "true"
+		d.observeRequest(req.Method, start, err)
+
+		if err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
 		}
 
-		resp, err := formatFilterResponse(req.ID, res)
+		resp, err := formatFilterResponse(req.ID, result)
 		if err != nil {
 			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
 		}
@@ -249,7 +333,7 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 	}
 
 	// its a normal query that we handle with the dispatcher
-	resp, err := d.handleReq(req)
+	resp, err := d.handleReq(req, c)
 	if err != nil {
 		return nil, err
 	}
@@ -257,7 +341,7 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 	return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 }
 
-func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
+func (d *Dispatcher) Handle(reqBody []byte, c *client) ([]byte, error) {
 	x := bytes.TrimLeft(reqBody, " \t\r\n")
 	if len(x) == 0 {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -273,7 +357,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 		}
 
-		resp, err := d.handleReq(req)
+		resp, err := d.handleReq(req, c)
 
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 	}
@@ -289,6 +373,8 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 		).Bytes()
 	}
 
+	observeBatch(len(requests))
+
 	// if not disabled, avoid handling long batch requests
 	if d.params.jsonRPCBatchLengthLimit != 0 && len(requests) > int(d.params.jsonRPCBatchLengthLimit) {
 		return NewRPCResponse(
@@ -302,7 +388,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	responses := make([]Response, 0)
 
 	for _, req := range requests {
-		var response, err = d.handleReq(req)
+		var response, err = d.handleReq(req, c)
 		if err != nil {
 			errorResponse := NewRPCResponse(req.ID, "2.0", nil, err)
 			responses = append(responses, errorResponse)
@@ -322,9 +408,28 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	return respBytes, nil
 }
 
-func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
+// handleReq handles the request with its service, recording its metrics
+func (d *Dispatcher) handleReq(req Request, c *client) ([]byte, Error) {
+	start := time.Now()
+
+	resp, err := d.callService(req, c)
+
+	d.observeRequest(req.Method, start, err)
+
+	return resp, err
+}
+
+func (d *Dispatcher) callService(req Request, c *client) ([]byte, Error) {
 	d.logger.Debug("request", "method", req.Method, "id", req.ID)
 
+	if err := d.checkAccess(req.Method, c); err != nil {
+		return nil, err
+	}
+
+	if err := d.checkRateLimit(req.Method, c); err != nil {
+		return nil, err
+	}
+
 	service, fd, ferr := d.getFnHandler(req)
 	if ferr != nil {
 		return nil, ferr
@@ -384,6 +489,14 @@ func (d *Dispatcher) registerService(serviceName string, service interface{}) {
 		panic("jsonrpc: serviceName cannot be empty")
 	}
 
+	for _, disabled := range d.params.disabledNamespaces {
+		if disabled == serviceName {
+			d.logger.Info("namespace disabled", "namespace", serviceName)
+
+			return
+		}
+	}
+
 	st := reflect.TypeOf(service)
 	if st.Kind() == reflect.Struct {
 		panic(fmt.Sprintf("jsonrpc: service '%s' must be a pointer to struct", serviceName))
@@ -79,7 +79,7 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 		"method": "eth_subscribe",
 		"params": ["newHeads"]
 	}`)
-		if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+		if _, err := dispatcher.HandleWs(req, mockConnection, nil); err != nil {
 			t.Fatal(err)
 		}
 
@@ -99,6 +99,66 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 			t.Fatal("\"newHeads\" event not received in 2 seconds")
 		}
 	})
+
+	t.Run("clients should be able to receive \"newPendingTransactions\" event thru eth_subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore()
+		dispatcher := newDispatcher(
+			hclog.NewNullLogger(),
+			store,
+			&dispatcherParams{
+				chainID:                 0,
+				priceLimit:              0,
+				jsonRPCBatchLengthLimit: 20,
+				blockRangeLimit:         1000,
+			},
+		)
+
+		mockConnection, msgCh := newMockWsConnWithMsgCh()
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions", true]
+	}`)
+		if _, err := dispatcher.HandleWs(req, mockConnection, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		store.emitPendingTx(newTestTransaction(1, addr1))
+
+		select {
+		case <-msgCh:
+		case <-time.After(2 * time.Second):
+			t.Fatal("\"newPendingTransactions\" event not received in 2 seconds")
+		}
+	})
+
+	t.Run("clients should not be able to subscribe with invalid \"newPendingTransactions\" params", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newDispatcher(
+			hclog.NewNullLogger(),
+			newMockStore(),
+			&dispatcherParams{
+				chainID:                 0,
+				priceLimit:              0,
+				jsonRPCBatchLengthLimit: 20,
+				blockRangeLimit:         1000,
+			},
+		)
+
+		mockConnection, _ := newMockWsConnWithMsgCh()
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions", "full"]
+	}`)
+
+		resp, err := dispatcher.HandleWs(req, mockConnection, nil)
+		assert.NoError(t, err)
+		assert.Contains(t, string(resp), "Invalid params")
+	})
 }
 
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
@@ -161,7 +221,7 @@ func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 		},
 	}
 	for _, c := range cases {
-		data, err := dispatcher.HandleWs(c.msg, mockConnection)
+		data, err := dispatcher.HandleWs(c.msg, mockConnection, nil)
 		resp := new(SuccessResponse)
 		merr := json.Unmarshal(data, resp)
 
@@ -230,7 +290,7 @@ func TestDispatcherFuncDecode(t *testing.T) {
 		_, err := dispatcher.handleReq(Request{
 			Method: "mock_" + typ,
 			Params: []byte(msg),
-		})
+		}, nil)
 		assert.NoError(t, err)
 
 		return <-srv.msgCh
@@ -290,7 +350,7 @@ func TestDispatcherFuncDecode(t *testing.T) {
 
 func TestDispatcherBatchRequest(t *testing.T) {
 	handle := func(dispatcher *Dispatcher, reqBody []byte) []byte {
-		res, _ := dispatcher.Handle(reqBody)
+		res, _ := dispatcher.Handle(reqBody, nil)
 
 		return res
 	}
@@ -455,3 +515,95 @@ func TestDispatcherBatchRequest(t *testing.T) {
 		}
 	}
 }
+
+func methodNotFoundObjectError(method string) *ObjectError {
+	return &ObjectError{Code: -32601, Message: NewMethodNotFoundError(method).Error()}
+}
+
+func expectMethodNotFound(t *testing.T, data []byte, method string) {
+	t.Helper()
+
+	assert.Equal(t, methodNotFoundObjectError(method), expectJSONResult(data, nil))
+}
+
+func TestDispatcher_DisabledNamespaces(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newDispatcher(
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:            100,
+			disabledNamespaces: []string{"debug", "eth"},
+		},
+	)
+
+	resp, err := dispatcher.Handle([]byte(`{"id":1,"method":"debug_traceTransaction","params":["0x1"]}`), nil)
+	assert.NoError(t, err)
+	expectMethodNotFound(t, resp, "debug_traceTransaction")
+
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	resp, err = dispatcher.HandleWs([]byte(`{"id":2,"method":"eth_subscribe","params":["newHeads"]}`), mockConnection, nil)
+	assert.NoError(t, err)
+	expectMethodNotFound(t, resp, "eth_subscribe")
+
+	// the other namespaces are still served
+	var res string
+
+	resp, err = dispatcher.Handle([]byte(`{"id":3,"method":"net_version","params":[]}`), nil)
+	assert.NoError(t, err)
+	assert.NoError(t, expectJSONResult(resp, &res))
+	assert.Equal(t, "100", res)
+}
+
+func TestDispatcher_ClientAccessList(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newDispatcher(
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:                 100,
+			jsonRPCBatchLengthLimit: 20,
+		},
+	)
+
+	c := &client{acl: newAccessList([]string{"net", "eth_subscribe"})}
+
+	resp, err := dispatcher.Handle([]byte(`{"id":1,"method":"eth_chainId","params":[]}`), c)
+	assert.NoError(t, err)
+	expectMethodNotFound(t, resp, "eth_chainId")
+
+	// the access list applies to each request of the batches
+	var batch []*SuccessResponse
+
+	resp, err = dispatcher.Handle([]byte(`[
+		{"id":1,"jsonrpc":"2.0","method":"net_version","params":[]},
+		{"id":2,"jsonrpc":"2.0","method":"eth_chainId","params":[]}]`), c)
+	assert.NoError(t, err)
+	assert.NoError(t, expectBatchJSONResult(resp, &batch))
+
+	if assert.Len(t, batch, 2) {
+		assert.Nil(t, batch[0].Error)
+		assert.Equal(t, methodNotFoundObjectError("eth_chainId"), batch[1].Error)
+	}
+
+	// the subscriptions are allowed, not the unsubscriptions
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	var subscriptionID string
+
+	resp, err = dispatcher.HandleWs([]byte(`{"id":3,"method":"eth_subscribe","params":["newHeads"]}`), mockConnection, c)
+	assert.NoError(t, err)
+	assert.NoError(t, expectJSONResult(resp, &subscriptionID))
+	assert.NotEmpty(t, subscriptionID)
+
+	resp, err = dispatcher.HandleWs(
+		[]byte(`{"id":4,"method":"eth_unsubscribe","params":["`+subscriptionID+`"]}`),
+		mockConnection,
+		c,
+	)
+	assert.NoError(t, err)
+	expectMethodNotFound(t, resp, "eth_unsubscribe")
+}
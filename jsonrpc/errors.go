@@ -3,6 +3,8 @@ package jsonrpc
 import (
 	"errors"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/SECRYPT-2022/SECRYPT/state/runtime"
 	"github.com/umbracle/ethgo/abi"
@@ -16,6 +18,12 @@ type Error interface {
 	Error() string
 	ErrorCode() int
 }
+
+// dataError is an Error carrying additional information in the data field of the response
+type dataError interface {
+	Error
+	ErrorData() interface{}
+}
 type invalidParamsError struct {
 	err string
 }
@@ -76,6 +84,26 @@ func (e *methodNotFoundError) ErrorCode() int {
 	return -32601
 }
 
+type limitExceededError struct {
+	err        string
+	retryAfter time.Duration
+}
+
+func (e *limitExceededError) Error() string {
+	return e.err
+}
+
+func (e *limitExceededError) ErrorCode() int {
+	return -32005
+}
+
+// ErrorData returns the number of seconds to wait before retrying
+func (e *limitExceededError) ErrorData() interface{} {
+	return map[string]interface{}{
+		"retryAfter": math.Ceil(e.retryAfter.Seconds()),
+	}
+}
+
 func NewMethodNotFoundError(method string) *methodNotFoundError {
 	return &methodNotFoundError{fmt.Sprintf("the method %s does not exist/is not available", method)}
 }
@@ -90,6 +118,13 @@ func NewInternalError(msg string) *internalError {
 	return &internalError{msg}
 }
 
+func NewLimitExceededError(method string, retryAfter time.Duration) *limitExceededError {
+	return &limitExceededError{
+		fmt.Sprintf("rate limit exceeded for method %s, retry in %s", method, retryAfter.Round(time.Millisecond)),
+		retryAfter,
+	}
+}
+
 func NewSubscriptionNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("subscribe method %s not found", method)}
 }
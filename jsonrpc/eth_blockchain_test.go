@@ -50,6 +50,28 @@ func TestEth_Block_GetBlockByNumber(t *testing.T) {
 	}
 }
 
+func TestEth_Block_GetBlockByNumber_Finalized(t *testing.T) {
+	t.Parallel()
+
+	store := &mockBlockStore{}
+	for i := 0; i < 10; i++ {
+		store.add(newTestBlock(uint64(i), hash1))
+	}
+
+	store.finalized = store.blocks[7].Header
+
+	eth := newTestEthEndpoint(store)
+
+	for _, number := range []BlockNumber{SafeBlockNumber, FinalizedBlockNumber} {
+		res, err := eth.GetBlockByNumber(number, false)
+		assert.NoError(t, err)
+
+		result, ok := res.(*block)
+		assert.True(t, ok)
+		assert.Equal(t, argUint64(7), result.Number)
+	}
+}
+
 func TestEth_Block_GetBlockByHash(t *testing.T) {
 	store := &mockBlockStore{}
 	store.add(newTestBlock(1, hash1))
@@ -215,6 +237,179 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 	})
 }
 
+func TestEth_GetBlockReceipts(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+
+	block := newTestBlock(1, hash4)
+	store.add(block)
+
+	for i := 0; i < 3; i++ {
+		block.Transactions = append(block.Transactions, newTestTransaction(uint64(i), addr0))
+
+		rec := &types.Receipt{CumulativeGasUsed: uint64(i+1) * 21000}
+		rec.SetStatus(types.ReceiptSuccess)
+		store.receipts[hash4] = append(store.receipts[hash4], rec)
+	}
+
+	blockNumber := BlockNumber(1)
+	unknownNumber := BlockNumber(2)
+	blockHash := hash4
+	unknownHash := hash1
+
+	testCases := []struct {
+		name     string
+		filter   BlockNumberOrHash
+		expected int
+	}{
+		{"by number", BlockNumberOrHash{BlockNumber: &blockNumber}, 3},
+		{"by hash", BlockNumberOrHash{BlockHash: &blockHash}, 3},
+		{"latest by default", BlockNumberOrHash{}, 3},
+		{"unknown number", BlockNumberOrHash{BlockNumber: &unknownNumber}, -1},
+		{"unknown hash", BlockNumberOrHash{BlockHash: &unknownHash}, -1},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := eth.GetBlockReceipts(tc.filter)
+			assert.NoError(t, err)
+
+			if tc.expected < 0 {
+				assert.Nil(t, res)
+
+				return
+			}
+
+			//nolint:forcetypeassert
+			receipts := res.([]*receipt)
+			assert.Len(t, receipts, tc.expected)
+
+			for idx, rec := range receipts {
+				assert.Equal(t, block.Transactions[idx].Hash, rec.TxHash)
+				assert.Equal(t, argUint64(idx), rec.TxIndex)
+				assert.Equal(t, hash4, rec.BlockHash)
+			}
+		})
+	}
+}
+
+func TestEth_GetTransactionByBlockAndIndex(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+
+	block := newTestBlock(1, hash1)
+	for i := 0; i < 3; i++ {
+		block.Transactions = append(block.Transactions, newTestTransaction(uint64(i), addr0))
+	}
+
+	store.add(block)
+
+	res, err := eth.GetTransactionByBlockHashAndIndex(hash1, argUint64(2))
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	txn := res.(*transaction)
+	assert.Equal(t, block.Transactions[2].Hash, txn.Hash)
+	assert.Equal(t, argUint64(2), *txn.TxIndex)
+	assert.Equal(t, hash1, *txn.BlockHash)
+
+	res, err = eth.GetTransactionByBlockNumberAndIndex(LatestBlockNumber, argUint64(1))
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	assert.Equal(t, block.Transactions[1].Hash, res.(*transaction).Hash)
+
+	// out of range index and unknown block
+	res, err = eth.GetTransactionByBlockHashAndIndex(hash1, argUint64(3))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetTransactionByBlockHashAndIndex(hash2, argUint64(0))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetTransactionByBlockNumberAndIndex(BlockNumber(5), argUint64(0))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestEth_Block_CountsAndUncles(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+
+	block := newTestBlock(1, hash1)
+	block.Transactions = []*types.Transaction{{Nonce: 0, From: addr0}, {Nonce: 1, From: addr0}}
+	store.add(block)
+
+	res, err := eth.GetBlockTransactionCountByHash(hash1)
+	assert.NoError(t, err)
+	assert.Equal(t, argUintPtr(2), res)
+
+	res, err = eth.GetBlockTransactionCountByHash(hash2)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetUncleCountByBlockHash(hash1)
+	assert.NoError(t, err)
+	assert.Equal(t, argUintPtr(0), res)
+
+	res, err = eth.GetUncleCountByBlockNumber(BlockNumber(1))
+	assert.NoError(t, err)
+	assert.Equal(t, argUintPtr(0), res)
+
+	res, err = eth.GetUncleCountByBlockNumber(BlockNumber(2))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetUncleByBlockHashAndIndex(hash1, argUint64(0))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetUncleByBlockNumberAndIndex(LatestBlockNumber, argUint64(0))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestEth_NodeInfo(t *testing.T) {
+	t.Parallel()
+
+	eth := newTestEthEndpoint(newMockBlockStore())
+
+	testCases := []struct {
+		name     string
+		method   func() (interface{}, error)
+		expected interface{}
+	}{
+		{"accounts", eth.Accounts, []types.Address{}},
+		{"protocol version", eth.ProtocolVersion, argUintPtr(ethProtocolVersion)},
+		{"mining", eth.Mining, false},
+		{"hashrate", eth.Hashrate, argUintPtr(0)},
+		{"coinbase", eth.Coinbase, types.ZeroAddress},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := tc.method()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+}
+
 func TestEth_Syncing(t *testing.T) {
 	store := newMockBlockStore()
 	eth := newTestEthEndpoint(store)
@@ -250,6 +445,7 @@ func TestEth_Syncing(t *testing.T) {
 func TestEth_GetPrice_PriceLimitSet(t *testing.T) {
 	priceLimit := uint64(100333)
 	store := newMockBlockStore()
+	store.add(newTestBlock(0, hash1))
 	// not using newTestEthEndpoint as we need to set priceLimit
 	eth := newTestEthEndpointWithPriceLimit(store, priceLimit)
 
@@ -274,6 +470,7 @@ func TestEth_GetPrice_PriceLimitSet(t *testing.T) {
 
 func TestEth_GasPrice(t *testing.T) {
 	store := newMockBlockStore()
+	store.add(newTestBlock(0, hash1))
 	store.averageGasPrice = 9999
 	eth := newTestEthEndpoint(store)
 
@@ -348,6 +545,9 @@ type mockBlockStore struct {
 	isSyncing       bool
 	averageGasPrice int64
 	ethCallError    error
+
+	// finalized header, the latest one if nil
+	finalized *types.Header
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -478,6 +678,14 @@ func (m *mockBlockStore) Header() *types.Header {
 	return m.blocks[len(m.blocks)-1].Header
 }
 
+func (m *mockBlockStore) GetFinalizedHeader() *types.Header {
+	if m.finalized != nil {
+		return m.finalized
+	}
+
+	return m.Header()
+}
+
 func (m *mockBlockStore) ReadTxLookup(txnHash types.Hash) (types.Hash, bool) {
 	for _, block := range m.blocks {
 		for _, txn := range block.Transactions {
@@ -525,6 +733,10 @@ func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
 
+func (m *mockBlockStore) SubscribePendingTxs() (<-chan types.Hash, func()) {
+	return nil, func() {}
+}
+
 func newTestBlock(number uint64, hash types.Hash) *types.Block {
 	return &types.Block{
 		Header: &types.Header{
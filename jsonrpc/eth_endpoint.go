@@ -4,12 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/umbracle/fastrlp"
 
+	"github.com/SECRYPT-2022/SECRYPT/accounts"
 	"github.com/SECRYPT-2022/SECRYPT/chain"
-	"github.com/SECRYPT-2022/SECRYPT/helper/common"
 	"github.com/SECRYPT-2022/SECRYPT/helper/progress"
 	"github.com/SECRYPT-2022/SECRYPT/state"
 	"github.com/SECRYPT-2022/SECRYPT/state/runtime"
@@ -20,11 +21,21 @@ type ethTxPoolStore interface {
 	// AddTx adds a new transaction to the tx pool
 	AddTx(tx *types.Transaction) error
 
+	// AddPrivateTx adds a new transaction to the tx pool without gossiping it,
+	// dropping it after the max block number
+	AddPrivateTx(tx *types.Transaction, maxBlockNumber uint64) error
+
+	// AddBundle adds a new bundle of transactions, included all together in the target block or not at all
+	AddBundle(txs []*types.Transaction, blockNumber uint64, revertingTxHashes []types.Hash) (types.Hash, error)
+
 	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
 	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
 
 	// GetNonce returns the next nonce for this address
 	GetNonce(addr types.Address) uint64
+
+	// GetTxStatus returns the state of the transaction in the tx pool, along with its lifecycle history
+	GetTxStatus(txHash types.Hash) *TxStatus
 }
 
 type Account struct {
@@ -32,6 +43,28 @@ type Account struct {
 	Nonce   uint64
 }
 
+// TxStatus is the state of a transaction in the tx pool, along with its lifecycle history
+type TxStatus struct {
+	State string
+
+	// only known while the transaction is in the pool
+	From          *types.Address
+	Nonce         uint64
+	NextNonce     uint64
+	MissingNonces []uint64
+	GasPrice      *big.Int
+
+	RequiredGasPrice *big.Int
+	History          []*TxEvent
+}
+
+// TxEvent is an entry of the lifecycle history of a transaction
+type TxEvent struct {
+	Type   string
+	Reason string
+	Time   time.Time
+}
+
 type ethStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
@@ -43,6 +76,9 @@ type ethBlockchainStore interface {
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
+	// GetFinalizedHeader returns the last finalized header
+	GetFinalizedHeader() *types.Header
+
 	// GetHeaderByNumber gets a header using the provided number
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 
@@ -81,13 +117,20 @@ type Eth struct {
 	store         ethStore
 	chainID       uint64
 	filterManager *FilterManager
-	priceLimit    uint64
+	gasOracle     *gasPriceOracle
+
+	// manager of the node accounts, nil if they're disabled
+	accounts AccountManager
 }
 
 var (
 	ErrInsufficientFunds = errors.New("insufficient funds for execution")
+	ErrMissingSender     = errors.New("missing transaction sender")
 )
 
+// version of the eth protocol reported by eth_protocolVersion (eth/65)
+const ethProtocolVersion = 65
+
 // ChainId returns the chain id of the client
 //
 //nolint:stylecheck
@@ -98,12 +141,7 @@ func (e *Eth) ChainId() (interface{}, error) {
 func (e *Eth) Syncing() (interface{}, error) {
 	if syncProgression := e.store.GetSyncProgression(); syncProgression != nil {
 		// Node is bulk syncing, return the status
-		return progression{
-			Type:          string(syncProgression.SyncType),
-			StartingBlock: argUint64(syncProgression.StartingBlock),
-			CurrentBlock:  argUint64(syncProgression.CurrentBlock),
-			HighestBlock:  argUint64(syncProgression.HighestBlock),
-		}, nil
+		return toProgression(syncProgression), nil
 	}
 
 	// Node is not bulk syncing
@@ -176,10 +214,187 @@ func (e *Eth) SendRawTransaction(buf argBytes) (interface{}, error) {
 	return tx.Hash.String(), nil
 }
 
-// SendTransaction rejects eth_sendTransaction json-rpc call as we don't support wallet management
-func (e *Eth) SendTransaction(_ *txnArgs) (interface{}, error) {
-	return nil, fmt.Errorf("request calls to eth_sendTransaction method are not supported," +
-		" use eth_sendRawTransaction insead")
+// SendPrivateTransaction sends a raw transaction only to the validators,
+// without broadcasting it to the network
+func (e *Eth) SendPrivateTransaction(args *privateTxArgs) (interface{}, error) {
+	if args.Tx == nil {
+		return nil, errors.New("missing value for required argument 'tx'")
+	}
+
+	tx := &types.Transaction{}
+	if err := tx.UnmarshalRLP(*args.Tx); err != nil {
+		return nil, err
+	}
+
+	tx.ComputeHash()
+
+	var maxBlockNumber uint64
+	if args.MaxBlockNumber != nil {
+		maxBlockNumber = uint64(*args.MaxBlockNumber)
+	}
+
+	if err := e.store.AddPrivateTx(tx, maxBlockNumber); err != nil {
+		return nil, err
+	}
+
+	return tx.Hash.String(), nil
+}
+
+// SendBundle sends an ordered list of raw transactions to the proposer of the target block,
+// which includes them all or none of them
+func (e *Eth) SendBundle(args *bundleArgs) (interface{}, error) {
+	if args.BlockNumber == nil {
+		return nil, errors.New("missing value for required argument 'blockNumber'")
+	}
+
+	txs := make([]*types.Transaction, len(args.Txs))
+
+	for idx, raw := range args.Txs {
+		tx := &types.Transaction{}
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			return nil, fmt.Errorf("invalid bundle tx at index %d, %w", idx, err)
+		}
+
+		tx.ComputeHash()
+
+		txs[idx] = tx
+	}
+
+	hash, err := e.store.AddBundle(txs, uint64(*args.BlockNumber), args.RevertingTxHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bundleResult{BundleHash: hash}, nil
+}
+
+// GetTransactionStatus returns the state of the transaction: included in a block,
+// or in the pool along with the missing nonces, the required gas price and its lifecycle history
+func (e *Eth) GetTransactionStatus(hash types.Hash) (interface{}, error) {
+	if blockHash, ok := e.store.ReadTxLookup(hash); ok {
+		if block, ok := e.store.GetBlockByHash(blockHash, false); ok {
+			return &txStatusResult{
+				Status:      txStatusIncluded,
+				BlockHash:   argHashPtr(blockHash),
+				BlockNumber: argUintPtr(block.Number()),
+				History:     []*txEventResult{},
+			}, nil
+		}
+	}
+
+	return toTxStatusResult(e.store.GetTxStatus(hash)), nil
+}
+
+// SendTransaction signs the transaction with the key of the unlocked sender account
+// and adds it to the pool, the missing nonce, gas price and gas being filled in
+func (e *Eth) SendTransaction(arg *txnArgs) (interface{}, error) {
+	if e.accounts == nil {
+		return nil, fmt.Errorf("request calls to eth_sendTransaction method are not supported," +
+			" use eth_sendRawTransaction insead")
+	}
+
+	tx, err := e.signTxArgs(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.store.AddTx(tx); err != nil {
+		return nil, err
+	}
+
+	return tx.Hash.String(), nil
+}
+
+// SignTransaction signs the transaction with the key of the unlocked sender account,
+// the missing nonce, gas price and gas being filled in
+func (e *Eth) SignTransaction(arg *txnArgs) (interface{}, error) {
+	if e.accounts == nil {
+		return nil, ErrAccountsDisabled
+	}
+
+	tx, err := e.signTxArgs(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signTransactionResult{
+		Raw: tx.MarshalRLP(),
+		Tx:  toPendingTransaction(tx),
+	}, nil
+}
+
+// Sign signs the message with the key of the unlocked account, prefixed as per EIP-191
+func (e *Eth) Sign(address types.Address, data argBytes) (interface{}, error) {
+	return e.signHash(address, accounts.TextHash(data))
+}
+
+// SignTypedData_v4 signs the typed data with the key of the unlocked account, as per EIP-712
+//
+//nolint:stylecheck,revive
+func (e *Eth) SignTypedData_v4(address types.Address, typedData *accounts.TypedData) (interface{}, error) {
+	if typedData == nil {
+		return nil, fmt.Errorf("%w: missing typed data", accounts.ErrInvalidTypedData)
+	}
+
+	hash, err := typedData.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.signHash(address, hash)
+}
+
+// signHash signs the hash with the key of the unlocked account, V being 27 or 28
+func (e *Eth) signHash(address types.Address, hash []byte) (interface{}, error) {
+	if e.accounts == nil {
+		return nil, ErrAccountsDisabled
+	}
+
+	sig, err := e.accounts.SignHash(address, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	sig[64] += 27
+
+	return argBytesPtr(sig), nil
+}
+
+// signTxArgs fills in the missing transaction fields and signs it
+// with the key of the unlocked sender account
+func (e *Eth) signTxArgs(arg *txnArgs) (*types.Transaction, error) {
+	if arg == nil || arg.From == nil {
+		return nil, ErrMissingSender
+	}
+
+	if arg.Nonce == nil {
+		arg.Nonce = argUintPtr(e.store.GetNonce(*arg.From))
+	}
+
+	if arg.GasPrice == nil {
+		arg.GasPrice = argBytesPtr(e.gasOracle.SuggestGasPrice().Bytes())
+	}
+
+	if arg.Gas == nil {
+		estimate, err := e.EstimateGas(arg, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+
+		gas, ok := estimate.(argUint64)
+		if !ok {
+			return nil, fmt.Errorf("failed to estimate gas")
+		}
+
+		arg.Gas = &gas
+	}
+
+	tx, err := DecodeTxn(arg, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.accounts.SignTx(tx)
 }
 
 // GetTransactionByHash returns a transaction by its hash.
@@ -300,43 +515,183 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 		return nil, nil
 	}
 
-	txn := block.Transactions[indx]
-	raw := receipts[indx]
-
-	logs := make([]*Log, len(raw.Logs))
-	for indx, elem := range raw.Logs {
-		logs[indx] = &Log{
-			Address:     elem.Address,
-			Topics:      elem.Topics,
-			Data:        argBytes(elem.Data),
-			BlockHash:   block.Hash(),
-			BlockNumber: argUint64(block.Number()),
-			TxHash:      txn.Hash,
-			TxIndex:     argUint64(indx),
-			LogIndex:    argUint64(indx),
-			Removed:     false,
-		}
+	return toReceipt(receipts[indx], block.Transactions[indx], uint64(indx), block), nil
+}
+
+// GetBlockReceipts returns the receipts of all the transactions of a block
+func (e *Eth) GetBlockReceipts(filter BlockNumberOrHash) (interface{}, error) {
+	block, err := e.getBlock(filter)
+	if err != nil || block == nil {
+		return nil, err
 	}
 
-	res := &receipt{
-		Root:              raw.Root,
-		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
-		LogsBloom:         raw.LogsBloom,
-		Status:            argUint64(*raw.Status),
-		TxHash:            txn.Hash,
-		TxIndex:           argUint64(indx),
-		BlockHash:         block.Hash(),
-		BlockNumber:       argUint64(block.Number()),
-		GasUsed:           argUint64(raw.GasUsed),
-		ContractAddress:   raw.ContractAddress,
-		FromAddr:          txn.From,
-		ToAddr:            txn.To,
-		Logs:              logs,
+	receipts, err := e.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		// receipts not written yet on the db
+		e.logger.Warn(
+			fmt.Sprintf("No receipts found for block with hash [%s]", block.Hash().String()),
+		)
+
+		return nil, nil
+	}
+
+	res := make([]*receipt, len(receipts))
+	for idx, raw := range receipts {
+		res[idx] = toReceipt(raw, block.Transactions[idx], uint64(idx), block)
 	}
 
 	return res, nil
 }
 
+// GetTransactionByBlockHashAndIndex returns the transaction at the index of the block with the given hash
+func (e *Eth) GetTransactionByBlockHashAndIndex(hash types.Hash, index argUint64) (interface{}, error) {
+	block, ok := e.store.GetBlockByHash(hash, true)
+	if !ok {
+		return nil, nil
+	}
+
+	txn := toTransactionAt(block, index)
+	if txn == nil {
+		return nil, nil
+	}
+
+	return txn, nil
+}
+
+// GetTransactionByBlockNumberAndIndex returns the transaction at the index of the block with the given number
+func (e *Eth) GetTransactionByBlockNumberAndIndex(number BlockNumber, index argUint64) (interface{}, error) {
+	num, err := GetNumericBlockNumber(number, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := e.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, nil
+	}
+
+	txn := toTransactionAt(block, index)
+	if txn == nil {
+		return nil, nil
+	}
+
+	return txn, nil
+}
+
+// GetBlockTransactionCountByHash returns the number of transactions in the block with the given hash
+func (e *Eth) GetBlockTransactionCountByHash(hash types.Hash) (interface{}, error) {
+	block, ok := e.store.GetBlockByHash(hash, true)
+	if !ok {
+		return nil, nil
+	}
+
+	return argUintPtr(uint64(len(block.Transactions))), nil
+}
+
+// GetUncleCountByBlockHash returns the number of uncles in the block with the given hash,
+// always zero as the consensus doesn't produce uncles
+func (e *Eth) GetUncleCountByBlockHash(hash types.Hash) (interface{}, error) {
+	if _, ok := e.store.GetBlockByHash(hash, false); !ok {
+		return nil, nil
+	}
+
+	return argUintPtr(0), nil
+}
+
+// GetUncleCountByBlockNumber returns the number of uncles in the block with the given number,
+// always zero as the consensus doesn't produce uncles
+func (e *Eth) GetUncleCountByBlockNumber(number BlockNumber) (interface{}, error) {
+	num, err := GetNumericBlockNumber(number, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := e.store.GetBlockByNumber(num, false); !ok {
+		return nil, nil
+	}
+
+	return argUintPtr(0), nil
+}
+
+// GetUncleByBlockHashAndIndex returns the uncle at the index of the block with the given hash,
+// always null as the consensus doesn't produce uncles
+func (e *Eth) GetUncleByBlockHashAndIndex(_ types.Hash, _ argUint64) (interface{}, error) {
+	return nil, nil
+}
+
+// GetUncleByBlockNumberAndIndex returns the uncle at the index of the block with the given number,
+// always null as the consensus doesn't produce uncles
+func (e *Eth) GetUncleByBlockNumberAndIndex(number BlockNumber, _ argUint64) (interface{}, error) {
+	if _, err := GetNumericBlockNumber(number, e.store); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Accounts returns the accounts managed by the node, none if they're disabled
+func (e *Eth) Accounts() (interface{}, error) {
+	if e.accounts == nil {
+		return []types.Address{}, nil
+	}
+
+	return e.accounts.Accounts(), nil
+}
+
+// ProtocolVersion returns the version of the eth protocol supported by the node
+func (e *Eth) ProtocolVersion() (interface{}, error) {
+	return argUintPtr(ethProtocolVersion), nil
+}
+
+// Mining returns whether the node is mining, always false as blocks are sealed by the consensus
+func (e *Eth) Mining() (interface{}, error) {
+	return false, nil
+}
+
+// Hashrate returns the number of hashes per second of the node, always zero as there's no proof of work
+func (e *Eth) Hashrate() (interface{}, error) {
+	return argUintPtr(0), nil
+}
+
+// Coinbase returns the address receiving the mining rewards, the zero address
+// as the block rewards go to the validator sealing the block
+func (e *Eth) Coinbase() (interface{}, error) {
+	return types.ZeroAddress, nil
+}
+
+// getBlock returns the block referenced by the number or hash, or nil if it's unknown
+func (e *Eth) getBlock(filter BlockNumberOrHash) (*types.Block, error) {
+	if filter.BlockHash != nil {
+		block, ok := e.store.GetBlockByHash(*filter.BlockHash, true)
+		if !ok {
+			return nil, nil
+		}
+
+		return block, nil
+	}
+
+	number := LatestBlockNumber
+	if filter.BlockNumber != nil {
+		number = *filter.BlockNumber
+	}
+
+	num, err := GetNumericBlockNumber(number, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := e.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, nil
+	}
+
+	return block, nil
+}
+
 // GetStorageAt returns the contract storage at the index position
 func (e *Eth) GetStorageAt(
 	address types.Address,
@@ -377,14 +732,37 @@ func (e *Eth) GetStorageAt(
 	return argBytesPtr(types.BytesToHash(data).Bytes()), nil
 }
 
-// GasPrice returns the average gas price based on the last x blocks
+// GasPrice returns the gas price suggested by the latest blocks transactions,
+// or the average gas price without recent transactions,
 // taking into consideration operator defined price limit
 func (e *Eth) GasPrice() (interface{}, error) {
-	// Fetch average gas price in uint64
-	avgGasPrice := e.store.GetAvgGasPrice().Uint64()
+	return argUint64(e.gasOracle.SuggestGasPrice().Uint64()), nil
+}
 
-	// Return --price-limit flag defined value if it is greater than avgGasPrice
-	return argUint64(common.Max(e.priceLimit, avgGasPrice)), nil
+// MaxPriorityFeePerGas returns the suggested priority fee,
+// the whole gas price as the chain has no base fee
+func (e *Eth) MaxPriorityFeePerGas() (interface{}, error) {
+	return argBigPtr(e.gasOracle.SuggestGasPrice()), nil
+}
+
+// FeeHistory returns the gas used ratios and the rewards percentiles
+// of the blockCount blocks up to the newest one
+func (e *Eth) FeeHistory(
+	blockCount argUint64,
+	newestBlock BlockNumber,
+	rewardPercentiles []float64,
+) (interface{}, error) {
+	newest, err := GetNumericBlockNumber(newestBlock, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := e.gasOracle.FeeHistory(uint64(blockCount), newest, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return toFeeHistoryResult(history), nil
 }
 
 // Call executes a smart contract call using the transaction object data
@@ -711,6 +1089,11 @@ func (e *Eth) NewBlockFilter() (interface{}, error) {
 	return e.filterManager.NewBlockFilter(nil), nil
 }
 
+// NewPendingTransactionFilter creates a filter in the node, to notify when new transactions become pending
+func (e *Eth) NewPendingTransactionFilter() (interface{}, error) {
+	return e.filterManager.NewPendingTxFilter(false, nil), nil
+}
+
 // GetFilterChanges is a polling method for a filter, which returns an array of logs which occurred since last poll.
 func (e *Eth) GetFilterChanges(id string) (interface{}, error) {
 	return e.filterManager.GetFilterChanges(id)
@@ -3,6 +3,7 @@ package jsonrpc
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/SECRYPT-2022/SECRYPT/types"
 	"github.com/stretchr/testify/assert"
@@ -46,10 +47,241 @@ func TestEth_TxnPool_SendTransaction(t *testing.T) {
 	assert.NotEqual(t, store.txn.Hash, types.ZeroHash)
 }
 
+func TestEth_TxnPool_SendPrivateTransaction(t *testing.T) {
+	t.Parallel()
+
+	txn := &types.Transaction{
+		From: addr0,
+		V:    big.NewInt(1),
+	}
+	txn.ComputeHash()
+
+	data := argBytes(txn.MarshalRLP())
+
+	t.Run("forwarded with max block number", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockStoreTxn{}
+		eth := newTestEthEndpoint(store)
+
+		hash, err := eth.SendPrivateTransaction(&privateTxArgs{
+			Tx:             &data,
+			MaxBlockNumber: argUintPtr(10),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, txn.Hash.String(), hash)
+
+		assert.True(t, store.private)
+		assert.Equal(t, uint64(10), store.maxBlockNumber)
+		assert.Equal(t, txn.Hash, store.txn.Hash)
+	})
+
+	t.Run("default max block number", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockStoreTxn{}
+		eth := newTestEthEndpoint(store)
+
+		_, err := eth.SendPrivateTransaction(&privateTxArgs{
+			Tx: &data,
+		})
+		assert.NoError(t, err)
+
+		assert.True(t, store.private)
+		assert.Equal(t, uint64(0), store.maxBlockNumber)
+	})
+
+	t.Run("missing tx", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockStoreTxn{}
+		eth := newTestEthEndpoint(store)
+
+		_, err := eth.SendPrivateTransaction(&privateTxArgs{})
+		assert.Error(t, err)
+		assert.Nil(t, store.txn)
+	})
+}
+
+func TestEth_TxnPool_SendBundle(t *testing.T) {
+	t.Parallel()
+
+	txs := make([]argBytes, 2)
+	hashes := make([]types.Hash, 2)
+
+	for idx := range txs {
+		txn := &types.Transaction{
+			From:  addr0,
+			Nonce: uint64(idx),
+			V:     big.NewInt(1),
+		}
+		txn.ComputeHash()
+
+		txs[idx] = txn.MarshalRLP()
+		hashes[idx] = txn.Hash
+	}
+
+	t.Run("bundle added", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockStoreTxn{}
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.SendBundle(&bundleArgs{
+			Txs:               txs,
+			BlockNumber:       argUintPtr(5),
+			RevertingTxHashes: hashes[1:],
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, &bundleResult{BundleHash: types.StringToHash("0x1")}, res)
+
+		assert.Len(t, store.bundle, 2)
+		assert.Equal(t, hashes[0], store.bundle[0].Hash)
+		assert.Equal(t, hashes[1], store.bundle[1].Hash)
+		assert.Equal(t, uint64(5), store.maxBlockNumber)
+		assert.Equal(t, hashes[1:], store.revertingTxHashes)
+	})
+
+	t.Run("missing block number", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockStoreTxn{}
+		eth := newTestEthEndpoint(store)
+
+		_, err := eth.SendBundle(&bundleArgs{
+			Txs: txs,
+		})
+		assert.Error(t, err)
+		assert.Nil(t, store.bundle)
+	})
+
+	t.Run("invalid tx", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockStoreTxn{}
+		eth := newTestEthEndpoint(store)
+
+		_, err := eth.SendBundle(&bundleArgs{
+			Txs:         []argBytes{txs[0], {0x1}},
+			BlockNumber: argUintPtr(5),
+		})
+		assert.Error(t, err)
+		assert.Nil(t, store.bundle)
+	})
+}
+
+func TestEth_TxnPool_GetTransactionStatus(t *testing.T) {
+	t.Parallel()
+
+	hash := types.StringToHash("0x1")
+
+	t.Run("included", func(t *testing.T) {
+		t.Parallel()
+
+		block := &types.Block{Header: &types.Header{Number: 5}}
+		block.Header.ComputeHash()
+
+		eth := newTestEthEndpoint(&mockStoreTxn{sealedBlock: block})
+
+		res, err := eth.GetTransactionStatus(hash)
+		assert.NoError(t, err)
+
+		status, ok := res.(*txStatusResult)
+		assert.True(t, ok)
+		assert.Equal(t, txStatusIncluded, status.Status)
+		assert.Equal(t, argUint64(5), *status.BlockNumber)
+		assert.Equal(t, block.Hash(), *status.BlockHash)
+	})
+
+	t.Run("enqueued", func(t *testing.T) {
+		t.Parallel()
+
+		from := addr0
+		now := time.Now()
+
+		eth := newTestEthEndpoint(&mockStoreTxn{
+			txStatus: &TxStatus{
+				State:            "enqueued",
+				From:             &from,
+				Nonce:            4,
+				NextNonce:        1,
+				MissingNonces:    []uint64{1, 2, 3},
+				GasPrice:         big.NewInt(10),
+				RequiredGasPrice: big.NewInt(1),
+				History: []*TxEvent{
+					{Type: "added", Time: now},
+					{Type: "enqueued", Time: now},
+				},
+			},
+		})
+
+		res, err := eth.GetTransactionStatus(hash)
+		assert.NoError(t, err)
+
+		status, ok := res.(*txStatusResult)
+		assert.True(t, ok)
+		assert.Equal(t, "enqueued", status.Status)
+		assert.Nil(t, status.BlockHash)
+		assert.Equal(t, &from, status.From)
+		assert.Equal(t, []argUint64{1, 2, 3}, status.MissingNonces)
+		assert.Equal(t, *argBigPtr(big.NewInt(1)), *status.RequiredGasPrice)
+		assert.Len(t, status.History, 2)
+		assert.Equal(t, "enqueued", status.LastEvent.Type)
+		assert.Equal(t, argUint64(now.UnixMilli()), status.LastEvent.Time)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		eth := newTestEthEndpoint(&mockStoreTxn{
+			txStatus: &TxStatus{
+				State:            "unknown",
+				RequiredGasPrice: big.NewInt(1),
+			},
+		})
+
+		res, err := eth.GetTransactionStatus(hash)
+		assert.NoError(t, err)
+
+		status, ok := res.(*txStatusResult)
+		assert.True(t, ok)
+		assert.Equal(t, "unknown", status.Status)
+		assert.Nil(t, status.From)
+		assert.Nil(t, status.LastEvent)
+		assert.Len(t, status.History, 0)
+	})
+}
+
 type mockStoreTxn struct {
 	ethStore
-	accounts map[types.Address]*mockAccount
-	txn      *types.Transaction
+	accounts          map[types.Address]*mockAccount
+	txn               *types.Transaction
+	private           bool
+	maxBlockNumber    uint64
+	bundle            []*types.Transaction
+	revertingTxHashes []types.Hash
+	txStatus          *TxStatus
+	sealedBlock       *types.Block
+}
+
+func (m *mockStoreTxn) GetTxStatus(txHash types.Hash) *TxStatus {
+	return m.txStatus
+}
+
+func (m *mockStoreTxn) ReadTxLookup(txnHash types.Hash) (types.Hash, bool) {
+	if m.sealedBlock == nil {
+		return types.ZeroHash, false
+	}
+
+	return m.sealedBlock.Hash(), true
+}
+
+func (m *mockStoreTxn) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
+	if m.sealedBlock == nil || m.sealedBlock.Hash() != hash {
+		return nil, false
+	}
+
+	return m.sealedBlock, true
 }
 
 func (m *mockStoreTxn) AddTx(tx *types.Transaction) error {
@@ -58,6 +290,26 @@ func (m *mockStoreTxn) AddTx(tx *types.Transaction) error {
 	return nil
 }
 
+func (m *mockStoreTxn) AddPrivateTx(tx *types.Transaction, maxBlockNumber uint64) error {
+	m.txn = tx
+	m.private = true
+	m.maxBlockNumber = maxBlockNumber
+
+	return nil
+}
+
+func (m *mockStoreTxn) AddBundle(
+	txs []*types.Transaction,
+	blockNumber uint64,
+	revertingTxHashes []types.Hash,
+) (types.Hash, error) {
+	m.bundle = txs
+	m.maxBlockNumber = blockNumber
+	m.revertingTxHashes = revertingTxHashes
+
+	return types.StringToHash("0x1"), nil
+}
+
 func (m *mockStoreTxn) GetNonce(addr types.Address) uint64 {
 	return 1
 }
@@ -81,6 +333,10 @@ func (m *mockStoreTxn) Header() *types.Header {
 	return &types.Header{}
 }
 
+func (m *mockStoreTxn) GetFinalizedHeader() *types.Header {
+	return &types.Header{}
+}
+
 func (m *mockStoreTxn) GetAccount(root types.Hash, addr types.Address) (*Account, error) {
 	acct, ok := m.accounts[addr]
 	if !ok {
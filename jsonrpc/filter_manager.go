@@ -6,12 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/SECRYPT-2022/SECRYPT/blockchain"
+	"github.com/SECRYPT-2022/SECRYPT/helper/progress"
 	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/armon/go-metrics"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
@@ -30,12 +33,16 @@ var (
 // defaultTimeout is the timeout to remove the filters that don't have a web socket stream
 var defaultTimeout = 1 * time.Minute
 
+// syncProgressionInterval is the interval between the checks of the sync progression,
+// notified to the syncing filters when it changes
+const syncProgressionInterval = time.Second
+
 const (
 	// The index in heap which is indicating the element is not in the heap
 	NoIndexInHeap = -1
 )
 
-// filter is an interface that BlockFilter and LogFilter implement
+// filter is an interface that the BlockFilter, LogFilter, PendingTxFilter and SyncingFilter implement
 type filter interface {
 	// hasWSConn returns the flag indicating the filter has web socket stream
 	hasWSConn() bool
@@ -212,11 +219,90 @@ func (f *logFilter) sendUpdates() error {
 	return nil
 }
 
+// updateQueue stores the updates of a filter until they're taken
+type updateQueue struct {
+	lock    sync.Mutex
+	updates []interface{}
+}
+
+// push appends the update to the queue
+func (q *updateQueue) push(update interface{}) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.updates = append(q.updates, update)
+}
+
+// take returns the queued updates and empties the queue
+func (q *updateQueue) take() []interface{} {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	updates := q.updates
+	q.updates = []interface{}{}
+
+	return updates
+}
+
+// send writes the queued updates to the web socket stream of the filter
+func (q *updateQueue) send(base *filterBase) error {
+	for _, update := range q.take() {
+		raw, err := json.Marshal(update)
+		if err != nil {
+			return err
+		}
+
+		if err := base.writeMessageToWs(string(raw)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pendingTxFilter is a filter to store the transactions becoming pending in the tx pool
+type pendingTxFilter struct {
+	filterBase
+	updateQueue
+
+	// the full transactions are stored instead of their hashes
+	fullTx bool
+}
+
+// getUpdates returns the stored transactions
+func (f *pendingTxFilter) getUpdates() (interface{}, error) {
+	return f.take(), nil
+}
+
+// sendUpdates writes the stored transactions to web socket stream
+func (f *pendingTxFilter) sendUpdates() error {
+	return f.send(&f.filterBase)
+}
+
+// syncingFilter is a filter to store the changes of the sync progression
+type syncingFilter struct {
+	filterBase
+	updateQueue
+}
+
+// getUpdates returns the stored sync statuses
+func (f *syncingFilter) getUpdates() (interface{}, error) {
+	return f.take(), nil
+}
+
+// sendUpdates writes the stored sync statuses to web socket stream
+func (f *syncingFilter) sendUpdates() error {
+	return f.send(&f.filterBase)
+}
+
 // filterManagerStore provides methods required by FilterManager
 type filterManagerStore interface {
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
+	// GetFinalizedHeader returns the last finalized header
+	GetFinalizedHeader() *types.Header
+
 	// SubscribeEvents subscribes for chain head events
 	SubscribeEvents() blockchain.Subscription
 
@@ -228,6 +314,16 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
+	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
+
+	// SubscribePendingTxs subscribes to the hashes of the transactions becoming pending in the tx pool,
+	// the channel being closed by the returned cancel function
+	SubscribePendingTxs() (<-chan types.Hash, func())
+
+	// GetSyncProgression retrieves the current sync progression, if any
+	GetSyncProgression() *progress.Progression
 }
 
 // FilterManager manages all running filters
@@ -243,6 +339,13 @@ type FilterManager struct {
 	blockStream     *blockStream
 	blockRangeLimit uint64
 
+	// new pending transactions of the tx pool
+	pendingTxCh      <-chan types.Hash
+	cancelPendingTxs func()
+
+	// last sync progression notified, nil while not syncing
+	lastSync *progression
+
 	filters  map[string]filter
 	timeouts timeHeapImpl
 
@@ -272,6 +375,11 @@ func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeL
 	// start the head watcher
 	m.subscription = store.SubscribeEvents()
 
+	// start the pending transactions watcher
+	m.pendingTxCh, m.cancelPendingTxs = store.SubscribePendingTxs()
+
+	m.lastSync = m.currentSync()
+
 	return m
 }
 
@@ -292,6 +400,9 @@ func (f *FilterManager) Run() {
 
 	var timeoutCh <-chan time.Time
 
+	syncTicker := time.NewTicker(syncProgressionInterval)
+	defer syncTicker.Stop()
+
 	for {
 		// check for the next filter to be removed
 		filterID, filterExpiresAt := f.nextTimeoutFilter()
@@ -308,6 +419,25 @@ func (f *FilterManager) Run() {
 				f.logger.Error("failed to dispatch event", "err", err)
 			}
 
+		case hash, ok := <-f.pendingTxCh:
+			if !ok {
+				// the tx pool subscription is closed
+				f.pendingTxCh = nil
+
+				break
+			}
+
+			// new pending transaction
+			if err := f.dispatchPendingTx(hash); err != nil {
+				f.logger.Error("failed to dispatch pending tx", "err", err)
+			}
+
+		case <-syncTicker.C:
+			// check for changes of the sync progression
+			if err := f.dispatchSyncProgression(); err != nil {
+				f.logger.Error("failed to dispatch sync progression", "err", err)
+			}
+
 		case <-timeoutCh:
 			// timeout for filter
 			// if filter still exists
@@ -327,6 +457,7 @@ func (f *FilterManager) Run() {
 
 // Close closed closeCh so that terminate worker
 func (f *FilterManager) Close() {
+	f.cancelPendingTxs()
 	close(f.closeCh)
 }
 
@@ -358,6 +489,33 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	return f.addFilter(filter)
 }
 
+// NewPendingTxFilter adds new PendingTxFilter, storing the full transactions instead of their hashes if fullTx is set
+func (f *FilterManager) NewPendingTxFilter(fullTx bool, ws wsConn) string {
+	filter := &pendingTxFilter{
+		filterBase: newFilterBase(ws),
+		fullTx:     fullTx,
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
+// NewSyncingFilter adds new SyncingFilter
+func (f *FilterManager) NewSyncingFilter(ws wsConn) string {
+	filter := &syncingFilter{
+		filterBase: newFilterBase(ws),
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
 // Exists checks the filter with given ID exists
 func (f *FilterManager) Exists(id string) bool {
 	f.RLock()
@@ -546,6 +704,7 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 	}
 
 	delete(f.filters, id)
+	f.setFiltersGauge()
 
 	if removed := f.timeouts.removeFilter(filter.getFilterBase()); removed {
 		f.emitSignalToUpdateCh()
@@ -562,6 +721,11 @@ func (f *FilterManager) RemoveFilterByWs(ws wsConn) {
 	f.removeFilterByID(ws.GetFilterID())
 }
 
+// setFiltersGauge records the number of installed filters [NOT Thread Safe]
+func (f *FilterManager) setFiltersGauge() {
+	metrics.SetGauge([]string{jsonRPCMetrics, "filters"}, float32(len(f.filters)))
+}
+
 // refreshFilterTimeout updates the timeout for a filter to the current time
 func (f *FilterManager) refreshFilterTimeout(filter *filterBase) {
 	f.timeouts.removeFilter(filter)
@@ -583,6 +747,7 @@ func (f *FilterManager) addFilter(filter filter) string {
 	base := filter.getFilterBase()
 
 	f.filters[base.id] = filter
+	f.setFiltersGauge()
 
 	// Set timeout and add to heap if filter doesn't have web socket connection
 	if !filter.hasWSConn() {
@@ -634,21 +799,47 @@ func (f *FilterManager) processEvent(evnt *blockchain.Event) {
 	f.RLock()
 	defer f.RUnlock()
 
-	for _, header := range evnt.NewChain {
+	// on reorgs, the logs of the old chain are removed before the new chain's logs are added.
+	// The old chain of a fork event is a side block, whose logs were never added
+	if evnt.Type != blockchain.EventFork {
+		for _, header := range sortHeadersByNumber(evnt.OldChain) {
+			block := toBlock(&types.Block{Header: header}, false)
+
+			if processErr := f.appendLogsToFilters(block, true); processErr != nil {
+				f.logger.Error(fmt.Sprintf("Unable to process removed block, %v", processErr))
+			}
+		}
+	}
+
+	for _, header := range sortHeadersByNumber(evnt.NewChain) {
 		block := toBlock(&types.Block{Header: header}, false)
 
 		// first include all the new headers in the blockstream for BlockFilter
 		f.blockStream.push(block)
 
 		// process new chain to include new logs for LogFilter
-		if processErr := f.appendLogsToFilters(block); processErr != nil {
+		if processErr := f.appendLogsToFilters(block, false); processErr != nil {
 			f.logger.Error(fmt.Sprintf("Unable to process block, %v", processErr))
 		}
 	}
 }
 
-// appendLogsToFilters makes each LogFilters append logs in the header
-func (f *FilterManager) appendLogsToFilters(header *block) error {
+// sortHeadersByNumber returns a copy of the headers sorted by number,
+// so that the reorged chains are processed from their oldest block
+func sortHeadersByNumber(headers []*types.Header) []*types.Header {
+	sorted := make([]*types.Header, len(headers))
+	copy(sorted, headers)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Number < sorted[j].Number
+	})
+
+	return sorted
+}
+
+// appendLogsToFilters makes each LogFilters append logs in the header,
+// flagged as removed if the block left the canonical chain
+func (f *FilterManager) appendLogsToFilters(header *block, removed bool) error {
 	receipts, err := f.store.GetReceiptsByHash(header.Hash)
 	if err != nil {
 		return err
@@ -691,7 +882,7 @@ func (f *FilterManager) appendLogsToFilters(header *block) error {
 						BlockHash:   header.Hash,
 						TxHash:      receipt.TxHash,
 						TxIndex:     argUint64(indx),
-						Removed:     false,
+						Removed:     removed,
 					})
 				}
 			}
@@ -701,6 +892,94 @@ func (f *FilterManager) appendLogsToFilters(header *block) error {
 	return nil
 }
 
+// dispatchPendingTx is an event handler for new pending transaction
+func (f *FilterManager) dispatchPendingTx(hash types.Hash) error {
+	f.appendPendingTxToFilters(hash)
+
+	// send data to web socket stream
+	return f.flushWsFilters()
+}
+
+// appendPendingTxToFilters makes each PendingTxFilter append the new pending transaction
+func (f *FilterManager) appendPendingTxToFilters(hash types.Hash) {
+	f.RLock()
+	defer f.RUnlock()
+
+	// fetched once, only if a filter asks for the full transactions
+	var fullTx *transaction
+
+	for _, filter := range f.filters {
+		txFilter, ok := filter.(*pendingTxFilter)
+		if !ok {
+			continue
+		}
+
+		if !txFilter.fullTx {
+			txFilter.push(hash)
+
+			continue
+		}
+
+		if fullTx == nil {
+			tx, ok := f.store.GetPendingTx(hash)
+			if !ok {
+				// the transaction already left the pool
+				continue
+			}
+
+			fullTx = toPendingTransaction(tx)
+		}
+
+		txFilter.push(fullTx)
+	}
+}
+
+// currentSync returns the current sync progression, nil while not syncing
+func (f *FilterManager) currentSync() *progression {
+	syncProgression := f.store.GetSyncProgression()
+	if syncProgression == nil {
+		return nil
+	}
+
+	current := toProgression(syncProgression)
+
+	return &current
+}
+
+// dispatchSyncProgression makes each SyncingFilter append the sync progression if it changed
+func (f *FilterManager) dispatchSyncProgression() error {
+	current := f.currentSync()
+
+	if (current == nil && f.lastSync == nil) ||
+		(current != nil && f.lastSync != nil && *current == *f.lastSync) {
+		return nil
+	}
+
+	f.lastSync = current
+
+	// false once the sync is over
+	var status interface{} = false
+	if current != nil {
+		status = &syncStatus{
+			Syncing: true,
+			Status:  *current,
+		}
+	}
+
+	f.RLock()
+
+	for _, filter := range f.filters {
+		if syncFilter, ok := filter.(*syncingFilter); ok {
+			syncFilter.push(status)
+		}
+	}
+
+	f.RUnlock()
+
+	// send data to web socket stream
+	return f.flushWsFilters()
+}
+
 // flushWsFilters make each filters with web socket connection write the updates to web socket stream
 // flushWsFilters also removes the filters if flushWsFilters notices the connection is closed
 func (f *FilterManager) flushWsFilters() error {
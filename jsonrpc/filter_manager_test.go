@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/SECRYPT-2022/SECRYPT/blockchain"
+	"github.com/SECRYPT-2022/SECRYPT/helper/progress"
 	"github.com/SECRYPT-2022/SECRYPT/types"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
@@ -241,6 +243,158 @@ func TestFilterLog(t *testing.T) {
 	}
 }
 
+func TestFilterLog_Reorg(t *testing.T) {
+	t.Parallel()
+
+	// mockLogHeader returns a block of the given number, with a log of the given topic
+	mockLogHeader := func(store *mockStore, number uint64, hash, topic types.Hash) *mockHeader {
+		header := &types.Header{
+			Number: number,
+			Hash:   hash,
+		}
+
+		store.addHeader(header)
+
+		return &mockHeader{
+			header: header,
+			receipts: []*types.Receipt{
+				{
+					Logs: []*types.Log{
+						{
+							Topics: []types.Hash{topic},
+						},
+					},
+					TxHash: hash,
+				},
+			},
+		}
+	}
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	id := m.NewLogFilter(&LogQuery{}, nil)
+
+	var (
+		old1 = mockLogHeader(store, 1, types.StringToHash("0x11"), hash1)
+		old2 = mockLogHeader(store, 2, types.StringToHash("0x12"), hash1)
+		new1 = mockLogHeader(store, 1, types.StringToHash("0x21"), hash2)
+		new2 = mockLogHeader(store, 2, types.StringToHash("0x22"), hash2)
+		new3 = mockLogHeader(store, 3, types.StringToHash("0x23"), hash2)
+		fork = mockLogHeader(store, 3, types.StringToHash("0x33"), hash3)
+	)
+
+	store.emitEvent(&mockEvent{
+		Type:     blockchain.EventHead,
+		NewChain: []*mockHeader{old1, old2},
+	})
+
+	// the reorged headers are given in no particular order
+	store.emitEvent(&mockEvent{
+		Type:     blockchain.EventReorg,
+		OldChain: []*mockHeader{old1, old2},
+		NewChain: []*mockHeader{new3, new2, new1},
+	})
+
+	// the side block of a fork never had its logs added
+	store.emitEvent(&mockEvent{
+		Type:     blockchain.EventFork,
+		OldChain: []*mockHeader{fork},
+	})
+
+	type expectedLog struct {
+		blockHash types.Hash
+		removed   bool
+	}
+
+	expected := []expectedLog{
+		{old1.header.Hash, false},
+		{old2.header.Hash, false},
+		{old1.header.Hash, true},
+		{old2.header.Hash, true},
+		{new1.header.Hash, false},
+		{new2.header.Hash, false},
+		{new3.header.Hash, false},
+	}
+
+	logs := []*Log{}
+
+	assert.Eventually(t, func() bool {
+		res, err := m.GetFilterChanges(id)
+		assert.NoError(t, err)
+
+		changes, _ := res.([]*Log)
+		logs = append(logs, changes...)
+
+		return len(logs) >= len(expected)
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// leave time for the fork event to be wrongly processed
+	time.Sleep(100 * time.Millisecond)
+
+	res, err := m.GetFilterChanges(id)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	actual := make([]expectedLog, len(logs))
+	for idx, log := range logs {
+		actual[idx] = expectedLog{log.BlockHash, log.Removed}
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestFilterLogWebsocket_Reorg(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	mock, msgCh := newMockWsConnWithMsgCh()
+
+	m.NewLogFilter(&LogQuery{}, mock)
+
+	old := &types.Header{
+		Number: 1,
+		Hash:   types.StringToHash("0x11"),
+	}
+
+	store.addHeader(old)
+
+	store.emitEvent(&mockEvent{
+		Type: blockchain.EventReorg,
+		OldChain: []*mockHeader{
+			{
+				header: old,
+				receipts: []*types.Receipt{
+					{
+						Logs: []*types.Log{
+							{
+								Topics: []types.Hash{hash1},
+							},
+						},
+						TxHash: hash1,
+					},
+				},
+			},
+		},
+	})
+
+	var log Log
+
+	assert.NoError(t, json.Unmarshal(waitForSubscriptionResult(t, msgCh), &log))
+	assert.Equal(t, old.Hash, log.BlockHash)
+	assert.True(t, log.Removed)
+}
+
 func TestFilterBlock(t *testing.T) {
 	t.Parallel()
 
@@ -469,6 +623,118 @@ func TestFilterWebsocket(t *testing.T) {
 	}
 }
 
+func TestFilterPendingTx(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	id := m.NewPendingTxFilter(false, nil)
+
+	tx := newTestTransaction(1, addr1)
+	store.emitPendingTx(tx)
+
+	assert.Eventually(t, func() bool {
+		res, err := m.GetFilterChanges(id)
+		assert.NoError(t, err)
+
+		updates, _ := res.([]interface{})
+
+		return len(updates) == 1 && updates[0] == tx.Hash
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestFilterPendingTxWebsocket(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	hashConn, hashMsgCh := newMockWsConnWithMsgCh()
+	fullConn, fullMsgCh := newMockWsConnWithMsgCh()
+
+	m.NewPendingTxFilter(false, hashConn)
+	m.NewPendingTxFilter(true, fullConn)
+
+	tx := newTestTransaction(1, addr1)
+	store.emitPendingTx(tx)
+
+	var hash types.Hash
+
+	assert.NoError(t, json.Unmarshal(waitForSubscriptionResult(t, hashMsgCh), &hash))
+	assert.Equal(t, tx.Hash, hash)
+
+	var fullTx transaction
+
+	assert.NoError(t, json.Unmarshal(waitForSubscriptionResult(t, fullMsgCh), &fullTx))
+	assert.Equal(t, tx.Hash, fullTx.Hash)
+	assert.Equal(t, argUint64(tx.Nonce), fullTx.Nonce)
+	assert.Nil(t, fullTx.BlockNumber)
+}
+
+func TestFilterSyncingWebsocket(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	mock, msgCh := newMockWsConnWithMsgCh()
+
+	m.NewSyncingFilter(mock)
+
+	store.setSyncProgression(&progress.Progression{
+		SyncType:      progress.ChainSyncBulk,
+		StartingBlock: 1,
+		CurrentBlock:  5,
+		HighestBlock:  10,
+	})
+
+	var status syncStatus
+
+	assert.NoError(t, json.Unmarshal(waitForSubscriptionResult(t, msgCh), &status))
+	assert.True(t, status.Syncing)
+	assert.Equal(t, argUint64(5), status.Status.CurrentBlock)
+	assert.Equal(t, argUint64(10), status.Status.HighestBlock)
+
+	store.setSyncProgression(nil)
+
+	assert.Equal(t, "false", string(waitForSubscriptionResult(t, msgCh)))
+}
+
+// waitForSubscriptionResult returns the result of the next message written to the websocket
+func waitForSubscriptionResult(t *testing.T, msgCh <-chan []byte) json.RawMessage {
+	t.Helper()
+
+	select {
+	case msg := <-msgCh:
+		var notification struct {
+			Params struct {
+				Result json.RawMessage `json:"result"`
+			} `json:"params"`
+		}
+
+		assert.NoError(t, json.Unmarshal(msg, &notification))
+
+		return notification.Params.Result
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message written to the websocket")
+	}
+
+	return nil
+}
+
 type mockWsConn struct {
 	SetFilterIDFn  func(string)
 	GetFilterIDFn  func() string
@@ -0,0 +1,282 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	// number of latest blocks sampled for the gas price suggestions
+	gasPriceSampleBlocks = 20
+
+	// number of the lowest gas prices sampled in each block
+	gasPriceSamplesPerBlock = 3
+
+	// percentile of the sampled gas prices suggested
+	gasPricePercentile = 60
+
+	// maximum number of blocks returned by eth_feeHistory
+	maxFeeHistoryBlocks = 1024
+
+	// number of blocks whose fees are cached
+	blockFeesCacheSize = 2048
+)
+
+var (
+	ErrInvalidBlockCount       = errors.New("block count must be positive")
+	ErrInvalidRewardPercentile = errors.New("reward percentiles must be increasing, between 0 and 100")
+)
+
+type gasPriceOracleStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetBlockByNumber returns a block using the provided number
+	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// GetReceiptsByHash returns the receipts for a block hash
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+
+	// GetAvgGasPrice returns the average gas price
+	GetAvgGasPrice() *big.Int
+}
+
+// txFee is the gas price paid by a transaction, along with the gas it used
+type txFee struct {
+	gasPrice *big.Int
+	gasUsed  uint64
+}
+
+// blockFees are the fees sampled from a block
+type blockFees struct {
+	gasUsedRatio float64
+
+	// transactions fees, sorted by gas price
+	txs []txFee
+}
+
+// gasPriceOracle suggests gas prices based on the transactions of the latest blocks
+type gasPriceOracle struct {
+	store      gasPriceOracleStore
+	priceLimit *big.Int
+
+	// block hash -> *blockFees
+	cache *lru.Cache
+
+	// suggestion for the last head
+	lastLock  sync.Mutex
+	lastHead  types.Hash
+	lastPrice *big.Int
+}
+
+func newGasPriceOracle(store gasPriceOracleStore, priceLimit uint64) *gasPriceOracle {
+	// the cache size is constant and positive, lru.New can't fail
+	cache, _ := lru.New(blockFeesCacheSize)
+
+	return &gasPriceOracle{
+		store:      store,
+		priceLimit: new(big.Int).SetUint64(priceLimit),
+		cache:      cache,
+	}
+}
+
+// blockFees returns the fees of the given block, from the cache if possible
+func (o *gasPriceOracle) blockFees(block *types.Block) *blockFees {
+	hash := block.Hash()
+
+	if cached, ok := o.cache.Get(hash); ok {
+		fees, _ := cached.(*blockFees)
+
+		return fees
+	}
+
+	fees := &blockFees{
+		txs: make([]txFee, 0, len(block.Transactions)),
+	}
+
+	if block.Header.GasLimit > 0 {
+		fees.gasUsedRatio = float64(block.Header.GasUsed) / float64(block.Header.GasLimit)
+	}
+
+	// the receipts give the gas used by each transaction, the gas limit is used without them
+	receipts, err := o.store.GetReceiptsByHash(hash)
+	if err != nil || len(receipts) != len(block.Transactions) {
+		receipts = nil
+	}
+
+	cumulativeGasUsed := uint64(0)
+
+	for idx, tx := range block.Transactions {
+		gasUsed := tx.Gas
+
+		if receipts != nil {
+			gasUsed = receipts[idx].CumulativeGasUsed - cumulativeGasUsed
+			cumulativeGasUsed = receipts[idx].CumulativeGasUsed
+		}
+
+		fees.txs = append(fees.txs, txFee{
+			gasPrice: new(big.Int).Set(tx.GasPrice),
+			gasUsed:  gasUsed,
+		})
+	}
+
+	sort.Slice(fees.txs, func(i, j int) bool {
+		return fees.txs[i].gasPrice.Cmp(fees.txs[j].gasPrice) < 0
+	})
+
+	o.cache.Add(hash, fees)
+
+	return fees
+}
+
+// SuggestGasPrice returns the gas price percentile of the lowest priced transactions
+// of the latest blocks, never lower than the price limit
+func (o *gasPriceOracle) SuggestGasPrice() *big.Int {
+	head := o.store.Header()
+
+	o.lastLock.Lock()
+	defer o.lastLock.Unlock()
+
+	if o.lastPrice != nil && o.lastHead == head.Hash {
+		return new(big.Int).Set(o.lastPrice)
+	}
+
+	prices := make([]*big.Int, 0, gasPriceSampleBlocks*gasPriceSamplesPerBlock)
+
+	for i := uint64(0); i < gasPriceSampleBlocks && i <= head.Number; i++ {
+		block, ok := o.store.GetBlockByNumber(head.Number-i, true)
+		if !ok {
+			break
+		}
+
+		for idx, fee := range o.blockFees(block).txs {
+			if idx == gasPriceSamplesPerBlock {
+				break
+			}
+
+			prices = append(prices, fee.gasPrice)
+		}
+	}
+
+	// the average gas price is used while there are no recent transactions,
+	// it isn't cached as it changes with the transactions in the pool
+	if len(prices) == 0 {
+		return o.atLeastPriceLimit(o.store.GetAvgGasPrice())
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+
+	price := o.atLeastPriceLimit(prices[(len(prices)-1)*gasPricePercentile/100])
+
+	o.lastHead = head.Hash
+	o.lastPrice = new(big.Int).Set(price)
+
+	return price
+}
+
+// atLeastPriceLimit returns a copy of the price, raised to the price limit if lower
+func (o *gasPriceOracle) atLeastPriceLimit(price *big.Int) *big.Int {
+	if price == nil || price.Cmp(o.priceLimit) < 0 {
+		return new(big.Int).Set(o.priceLimit)
+	}
+
+	return new(big.Int).Set(price)
+}
+
+// feeHistory is the fee history of a range of blocks
+type feeHistory struct {
+	oldestBlock  uint64
+	gasUsedRatio []float64
+
+	// rewards per block for each requested percentile, nil without percentiles
+	reward [][]*big.Int
+}
+
+// FeeHistory returns the gas used ratios and the percentiles of the gas prices,
+// weighted by gas used, of the blockCount blocks up to the newest one
+func (o *gasPriceOracle) FeeHistory(blockCount, newest uint64, rewardPercentiles []float64) (*feeHistory, error) {
+	if blockCount == 0 {
+		return nil, ErrInvalidBlockCount
+	}
+
+	for idx, percentile := range rewardPercentiles {
+		if percentile < 0 || percentile > 100 || (idx > 0 && percentile < rewardPercentiles[idx-1]) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRewardPercentile, rewardPercentiles)
+		}
+	}
+
+	if head := o.store.Header(); newest > head.Number {
+		newest = head.Number
+	}
+
+	if blockCount > maxFeeHistoryBlocks {
+		blockCount = maxFeeHistoryBlocks
+	}
+
+	if blockCount > newest+1 {
+		blockCount = newest + 1
+	}
+
+	history := &feeHistory{
+		oldestBlock:  newest + 1 - blockCount,
+		gasUsedRatio: make([]float64, 0, blockCount),
+	}
+
+	if len(rewardPercentiles) > 0 {
+		history.reward = make([][]*big.Int, 0, blockCount)
+	}
+
+	for number := history.oldestBlock; number <= newest; number++ {
+		block, ok := o.store.GetBlockByNumber(number, true)
+		if !ok {
+			return nil, fmt.Errorf("block %d not found", number)
+		}
+
+		fees := o.blockFees(block)
+
+		history.gasUsedRatio = append(history.gasUsedRatio, fees.gasUsedRatio)
+
+		if history.reward != nil {
+			history.reward = append(history.reward, rewards(fees, block.Header.GasUsed, rewardPercentiles))
+		}
+	}
+
+	return history, nil
+}
+
+// rewards returns the gas prices at the given percentiles of the gas used in the block
+func rewards(fees *blockFees, gasUsed uint64, percentiles []float64) []*big.Int {
+	reward := make([]*big.Int, len(percentiles))
+
+	if len(fees.txs) == 0 {
+		for idx := range reward {
+			reward[idx] = big.NewInt(0)
+		}
+
+		return reward
+	}
+
+	txIdx := 0
+	sumGasUsed := fees.txs[0].gasUsed
+
+	for idx, percentile := range percentiles {
+		threshold := uint64(float64(gasUsed) * percentile / 100)
+
+		for sumGasUsed < threshold && txIdx < len(fees.txs)-1 {
+			txIdx++
+			sumGasUsed += fees.txs[txIdx].gasUsed
+		}
+
+		reward[idx] = new(big.Int).Set(fees.txs[txIdx].gasPrice)
+	}
+
+	return reward
+}
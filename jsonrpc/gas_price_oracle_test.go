@@ -0,0 +1,197 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFeesTestBlock returns a block with transactions of the given gas prices,
+// each using the given gas, and stores their receipts
+func newFeesTestBlock(store *mockBlockStore, number uint64, gasUsed uint64, gasPrices ...int64) *types.Block {
+	block := newTestBlock(number, types.StringToHash(big.NewInt(int64(number)+1).String()))
+	block.Header.GasLimit = 4 * gasUsed * uint64(len(gasPrices)+1)
+	block.Header.GasUsed = gasUsed * uint64(len(gasPrices))
+
+	receipts := make([]*types.Receipt, len(gasPrices))
+
+	for idx, gasPrice := range gasPrices {
+		block.Transactions = append(block.Transactions, &types.Transaction{
+			Nonce:    uint64(idx),
+			GasPrice: big.NewInt(gasPrice),
+			Gas:      2 * gasUsed,
+		})
+
+		receipts[idx] = &types.Receipt{
+			CumulativeGasUsed: gasUsed * uint64(idx+1),
+		}
+	}
+
+	store.receipts[block.Hash()] = receipts
+	store.add(block)
+
+	return block
+}
+
+func TestGasPriceOracle_SuggestGasPrice(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		blocks     [][]int64
+		priceLimit uint64
+		avgPrice   int64
+		expected   int64
+	}{
+		{
+			name:     "average gas price without transactions",
+			blocks:   [][]int64{{}, {}},
+			avgPrice: 30,
+			expected: 30,
+		},
+		{
+			name:       "price limit without transactions",
+			blocks:     [][]int64{{}},
+			priceLimit: 50,
+			avgPrice:   30,
+			expected:   50,
+		},
+		{
+			name: "percentile of the lowest prices",
+			// sampled: 1, 2, 3, 10, 11, 12, 20
+			blocks:   [][]int64{{3, 1, 100, 2}, {}, {12, 11, 10}, {20}},
+			avgPrice: 1000,
+			expected: 10,
+		},
+		{
+			name:       "price limit above the sampled prices",
+			blocks:     [][]int64{{1, 2}, {3}},
+			priceLimit: 5,
+			expected:   5,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := newMockBlockStore()
+			store.averageGasPrice = tc.avgPrice
+
+			for number, gasPrices := range tc.blocks {
+				newFeesTestBlock(store, uint64(number), 21000, gasPrices...)
+			}
+
+			oracle := newGasPriceOracle(store, tc.priceLimit)
+
+			assert.Equal(t, big.NewInt(tc.expected), oracle.SuggestGasPrice())
+		})
+	}
+}
+
+func TestGasPriceOracle_SuggestGasPrice_Cached(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	newFeesTestBlock(store, 0, 21000, 10)
+
+	oracle := newGasPriceOracle(store, 0)
+	assert.Equal(t, big.NewInt(10), oracle.SuggestGasPrice())
+
+	// the suggestion is kept for the same head
+	store.blocks[0].Transactions[0].GasPrice = big.NewInt(20)
+	assert.Equal(t, big.NewInt(10), oracle.SuggestGasPrice())
+
+	// and computed again for a new head, the previous block fees coming from the cache
+	newFeesTestBlock(store, 1, 21000, 40)
+	assert.Equal(t, big.NewInt(10), oracle.SuggestGasPrice())
+
+	newFeesTestBlock(store, 2, 21000, 40)
+	assert.Equal(t, big.NewInt(40), oracle.SuggestGasPrice())
+}
+
+func TestGasPriceOracle_FeeHistory(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	newFeesTestBlock(store, 0, 21000)
+	newFeesTestBlock(store, 1, 21000, 5, 1, 3, 2)
+	newFeesTestBlock(store, 2, 21000, 7)
+
+	oracle := newGasPriceOracle(store, 0)
+
+	t.Run("gas used ratios and rewards", func(t *testing.T) {
+		t.Parallel()
+
+		history, err := oracle.FeeHistory(2, 2, []float64{0, 25, 50, 100})
+		assert.NoError(t, err)
+
+		assert.Equal(t, uint64(1), history.oldestBlock)
+		assert.Equal(t, []float64{0.2, 0.125}, history.gasUsedRatio)
+		assert.Equal(t, [][]*big.Int{
+			{big.NewInt(1), big.NewInt(1), big.NewInt(2), big.NewInt(5)},
+			{big.NewInt(7), big.NewInt(7), big.NewInt(7), big.NewInt(7)},
+		}, history.reward)
+	})
+
+	t.Run("empty blocks have zero rewards", func(t *testing.T) {
+		t.Parallel()
+
+		history, err := oracle.FeeHistory(1, 0, []float64{50})
+		assert.NoError(t, err)
+
+		assert.Equal(t, [][]*big.Int{{big.NewInt(0)}}, history.reward)
+	})
+
+	t.Run("range limited to the chain", func(t *testing.T) {
+		t.Parallel()
+
+		history, err := oracle.FeeHistory(10, 5, nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, uint64(0), history.oldestBlock)
+		assert.Len(t, history.gasUsedRatio, 3)
+		assert.Nil(t, history.reward)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := oracle.FeeHistory(0, 2, nil)
+		assert.ErrorIs(t, err, ErrInvalidBlockCount)
+
+		_, err = oracle.FeeHistory(1, 2, []float64{50, 10})
+		assert.ErrorIs(t, err, ErrInvalidRewardPercentile)
+
+		_, err = oracle.FeeHistory(1, 2, []float64{101})
+		assert.ErrorIs(t, err, ErrInvalidRewardPercentile)
+	})
+}
+
+func TestEth_FeeHistory(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	newFeesTestBlock(store, 0, 21000, 1)
+	newFeesTestBlock(store, 1, 21000, 2)
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.FeeHistory(argUint64(1), LatestBlockNumber, []float64{50})
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	history := res.(*feeHistoryResult)
+	assert.Equal(t, argUint64(1), history.OldestBlock)
+	assert.Equal(t, []argUint64{0, 0}, history.BaseFeePerGas)
+	assert.Equal(t, [][]*argBig{{argBigPtr(big.NewInt(2))}}, history.Reward)
+
+	// percentile of the lowest prices, 1 and 2
+	res, err = eth.MaxPriorityFeePerGas()
+	assert.NoError(t, err)
+	assert.Equal(t, argBigPtr(big.NewInt(1)), res)
+}
@@ -11,6 +11,7 @@ import (
 var (
 	ErrHeaderNotFound           = errors.New("header not found")
 	ErrLatestNotFound           = errors.New("latest header not found")
+	ErrFinalizedNotFound        = errors.New("finalized header not found")
 	ErrNegativeBlockNumber      = errors.New("invalid argument 0: block number must not be negative")
 	ErrFailedFetchGenesis       = errors.New("error fetching genesis block header")
 	ErrNoDataInContractCreation = errors.New("contract creation without data provided")
@@ -18,6 +19,7 @@ var (
 
 type latestHeaderGetter interface {
 	Header() *types.Header
+	GetFinalizedHeader() *types.Header
 }
 
 // GetNumericBlockNumber returns block number based on current state or specified number
@@ -31,6 +33,14 @@ func GetNumericBlockNumber(number BlockNumber, store latestHeaderGetter) (uint64
 
 		return latest.Number, nil
 
+	case SafeBlockNumber, FinalizedBlockNumber:
+		finalized := store.GetFinalizedHeader()
+		if finalized == nil {
+			return 0, ErrFinalizedNotFound
+		}
+
+		return finalized.Number, nil
+
 	case EarliestBlockNumber:
 		return 0, nil
 
@@ -45,6 +55,7 @@ func GetNumericBlockNumber(number BlockNumber, store latestHeaderGetter) (uint64
 
 type headerGetter interface {
 	Header() *types.Header
+	GetFinalizedHeader() *types.Header
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 }
 
@@ -54,6 +65,15 @@ func GetBlockHeader(number BlockNumber, store headerGetter) (*types.Header, erro
 	case PendingBlockNumber, LatestBlockNumber:
 		return store.Header(), nil
 
+	// the safe blocks are the finalized ones, IBFT having instant finality
+	case SafeBlockNumber, FinalizedBlockNumber:
+		header := store.GetFinalizedHeader()
+		if header == nil {
+			return nil, ErrFinalizedNotFound
+		}
+
+		return header, nil
+
 	case EarliestBlockNumber:
 		header, ok := store.GetHeaderByNumber(uint64(0))
 		if !ok {
@@ -101,6 +121,7 @@ func GetTxAndBlockByTxHash(txHash types.Hash, store txLookupAndBlockGetter) (*ty
 
 type blockGetter interface {
 	Header() *types.Header
+	GetFinalizedHeader() *types.Header
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
 }
@@ -132,6 +153,7 @@ func GetHeaderFromBlockNumberOrHash(bnh BlockNumberOrHash, store blockGetter) (*
 
 type nonceGetter interface {
 	Header() *types.Header
+	GetFinalizedHeader() *types.Header
 	GetHeaderByNumber(uint64) (*types.Header, bool)
 	GetNonce(types.Address) uint64
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
@@ -0,0 +1,145 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/SECRYPT-2022/SECRYPT/helper/ipc"
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/go-hclog"
+)
+
+// ipcWrapper is a wrapping object for the IPC connection,
+// writing the messages as newline delimited JSON
+type ipcWrapper struct {
+	sync.Mutex
+
+	conn     net.Conn     // the actual IPC connection
+	logger   hclog.Logger // module logger
+	filterID string       // filter ID
+}
+
+func (w *ipcWrapper) SetFilterID(filterID string) {
+	w.filterID = filterID
+}
+
+func (w *ipcWrapper) GetFilterID() string {
+	return w.filterID
+}
+
+// WriteMessage writes out the message to the IPC peer on a single line,
+// the message type is ignored
+func (w *ipcWrapper) WriteMessage(_ int, data []byte) error {
+	var buf bytes.Buffer
+
+	// the messages aren't all compact, the subscription notifications span multiple lines
+	if err := json.Compact(&buf, data); err != nil {
+		return err
+	}
+
+	buf.WriteByte('\n')
+
+	w.Lock()
+	defer w.Unlock()
+
+	if _, writeErr := w.conn.Write(buf.Bytes()); writeErr != nil {
+		w.logger.Error(
+			fmt.Sprintf("Unable to write IPC message, %s", writeErr.Error()),
+		)
+
+		return writeErr
+	}
+
+	return nil
+}
+
+func (j *JSONRPC) setupIPC() error {
+	lis := j.config.IPCListener
+	if lis == nil {
+		var err error
+
+		if lis, err = ipc.Listen(j.config.IPCPath); err != nil {
+			return err
+		}
+	}
+
+	j.logger.Info("ipc server started", "path", j.config.IPCPath)
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				j.logger.Error("closed ipc listener", "err", err)
+
+				return
+			}
+
+			go j.handleIPC(conn)
+		}
+	}()
+
+	return nil
+}
+
+// handleIPC serves the requests of the IPC connection, a stream of JSON requests
+// handled like the websocket ones, subscriptions included
+func (j *JSONRPC) handleIPC(conn net.Conn) {
+	defer func() {
+		if err := conn.Close(); err != nil {
+			j.logger.Error(
+				fmt.Sprintf("Unable to gracefully close IPC connection, %s", err.Error()),
+			)
+		}
+	}()
+
+	defer j.openConnection(serverIPC)()
+
+	wrapConn := &ipcWrapper{conn: conn, logger: j.logger}
+
+	j.logger.Debug("IPC connection established")
+
+	decoder := json.NewDecoder(conn)
+
+	for {
+		var message json.RawMessage
+		if err := decoder.Decode(&message); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+				j.logger.Debug("Closing IPC connection gracefully")
+			} else {
+				j.logger.Error(fmt.Sprintf("Unable to read IPC message, %s", err.Error()))
+			}
+
+			j.dispatcher.RemoveFilterByWs(wrapConn)
+
+			break
+		}
+
+		go func() {
+			var (
+				resp      []byte
+				handleErr error
+			)
+
+			// batches can't hold subscriptions, they're handled like the http ones.
+			// The IPC clients are trusted, they can call all the namespaces
+			if bytes.HasPrefix(message, []byte("[")) {
+				resp, handleErr = j.dispatcher.Handle(message, nil)
+			} else {
+				resp, handleErr = j.dispatcher.HandleWs(message, wrapConn, nil)
+			}
+
+			if handleErr != nil {
+				j.logger.Error(fmt.Sprintf("Unable to handle IPC request, %s", handleErr.Error()))
+
+				resp, _ = NewRPCResponse(nil, "2.0", nil, NewInternalError(handleErr.Error())).Bytes()
+			}
+
+			_ = wrapConn.WriteMessage(websocket.TextMessage, resp)
+		}()
+	}
+}
@@ -0,0 +1,156 @@
+//go:build !windows
+// +build !windows
+
+package jsonrpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/helper/ipc"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIPCServer(t *testing.T, store JSONRPCStore) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "secrypt.ipc")
+
+	j := &JSONRPC{
+		logger: hclog.NewNullLogger(),
+		config: &Config{IPCPath: path},
+		dispatcher: newDispatcher(
+			hclog.NewNullLogger(),
+			store,
+			&dispatcherParams{
+				chainID:                 100,
+				jsonRPCBatchLengthLimit: 20,
+			},
+		),
+	}
+
+	if err := j.setupIPC(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func dialTestIPC(t *testing.T, path string) (net.Conn, *bufio.Scanner) {
+	t.Helper()
+
+	conn, err := ipc.DialTimeout(path, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn, bufio.NewScanner(conn)
+}
+
+func readIPCMessage(t *testing.T, conn net.Conn, scanner *bufio.Scanner) []byte {
+	t.Helper()
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	if !scanner.Scan() {
+		t.Fatalf("Unable to read IPC message, %v", scanner.Err())
+	}
+
+	return scanner.Bytes()
+}
+
+func TestIPCServer_Requests(t *testing.T) {
+	t.Parallel()
+
+	path := newTestIPCServer(t, newMockStore())
+	conn, scanner := dialTestIPC(t, path)
+
+	// the requests aren't required to be newline delimited
+	_, err := conn.Write([]byte(
+		`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}` +
+			`[{"jsonrpc":"2.0","id":2,"method":"eth_chainId","params":[]}]`,
+	))
+	assert.NoError(t, err)
+
+	// the requests are handled concurrently, the responses can come in any order
+	var (
+		single SuccessResponse
+		batch  []SuccessResponse
+	)
+
+	for i := 0; i < 2; i++ {
+		msg := readIPCMessage(t, conn, scanner)
+
+		if msg[0] == '[' {
+			assert.NoError(t, json.Unmarshal(msg, &batch))
+		} else {
+			assert.NoError(t, json.Unmarshal(msg, &single))
+		}
+	}
+
+	assert.Equal(t, float64(1), single.ID)
+	assert.Equal(t, `"0x64"`, string(single.Result))
+
+	if assert.Len(t, batch, 1) {
+		assert.Equal(t, float64(2), batch[0].ID)
+		assert.Equal(t, `"0x64"`, string(batch[0].Result))
+	}
+}
+
+func TestIPCServer_Subscription(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+	path := newTestIPCServer(t, store)
+	conn, scanner := dialTestIPC(t, path)
+
+	_, err := conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["newHeads"]}` + "\n"))
+	assert.NoError(t, err)
+
+	var subscribeResp SuccessResponse
+
+	assert.NoError(t, json.Unmarshal(readIPCMessage(t, conn, scanner), &subscribeResp))
+
+	var subscriptionID string
+
+	assert.NoError(t, json.Unmarshal(subscribeResp.Result, &subscriptionID))
+	assert.NotEmpty(t, subscriptionID)
+
+	store.emitEvent(&mockEvent{
+		NewChain: []*mockHeader{
+			{
+				header: &types.Header{
+					Number: 1,
+					Hash:   types.StringToHash("0x1"),
+				},
+			},
+		},
+	})
+
+	var notification struct {
+		Method string `json:"method"`
+		Params struct {
+			Subscription string `json:"subscription"`
+			Result       struct {
+				Number string `json:"number"`
+				Hash   string `json:"hash"`
+			} `json:"result"`
+		} `json:"params"`
+	}
+
+	assert.NoError(t, json.Unmarshal(readIPCMessage(t, conn, scanner), &notification))
+	assert.Equal(t, "eth_subscription", notification.Method)
+	assert.Equal(t, subscriptionID, notification.Params.Subscription)
+	assert.Equal(t, "0x1", notification.Params.Result.Number)
+	assert.Equal(t, types.StringToHash("0x1").String(), notification.Params.Result.Hash)
+}
@@ -37,15 +37,23 @@ func (s serverType) String() string {
 
 // JSONRPC is an API consensus
 type JSONRPC struct {
+	// numbers of the open connections, first to be 64-bit aligned for the atomic operations
+	wsConnections  int64
+	ipcConnections int64
+
 	logger     hclog.Logger
 	config     *Config
 	dispatcher dispatcher
+
+	// authenticators of the transports, nil if not authenticated
+	httpAuth *authenticator
+	wsAuth   *authenticator
 }
 
 type dispatcher interface {
 	RemoveFilterByWs(conn wsConn)
-	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
-	Handle(reqBody []byte) ([]byte, error)
+	HandleWs(reqBody []byte, conn wsConn, c *client) ([]byte, error)
+	Handle(reqBody []byte, c *client) ([]byte, error)
 }
 
 // JSONRPCStore defines all the methods required
@@ -67,6 +75,27 @@ type Config struct {
 	PriceLimit               uint64
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+
+	// IPCPath is the path of the IPC endpoint socket, it's disabled if empty
+	IPCPath string
+
+	// IPCListener is the listener of the IPC endpoint, it's opened on IPCPath if nil.
+	// It's set when the socket is shared with other services
+	IPCListener net.Listener
+
+	// DisabledNamespaces are the namespaces that aren't served on any transport
+	DisabledNamespaces []string
+
+	// HTTPAuth and WSAuth are the authentication configs of the HTTP and WS transports,
+	// they serve all the namespaces to anyone if nil. The IPC transport is never authenticated
+	HTTPAuth *AuthConfig
+	WSAuth   *AuthConfig
+
+	// RateLimit is the per client rate limiting of the HTTP and WS requests, disabled if nil
+	RateLimit *RateLimitConfig
+
+	// Accounts manages the node accounts, they're disabled if nil
+	Accounts AccountManager
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -83,8 +112,13 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 				priceLimit:              config.PriceLimit,
 				jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 				blockRangeLimit:         config.BlockRangeLimit,
+				accounts:                config.Accounts,
+				disabledNamespaces:      config.DisabledNamespaces,
+				rateLimit:               config.RateLimit,
 			},
 		),
+		httpAuth: newAuthenticator(config.HTTPAuth),
+		wsAuth:   newAuthenticator(config.WSAuth),
 	}
 
 	// start http server
@@ -92,6 +126,13 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		return nil, err
 	}
 
+	// start ipc server
+	if config.IPCPath != "" {
+		if err := srv.setupIPC(); err != nil {
+			return nil, err
+		}
+	}
+
 	return srv, nil
 }
 
@@ -201,6 +242,15 @@ func isSupportedWSType(messageType int) bool {
 }
 
 func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
+	// the browsers can't set the handshake headers, the credentials can be in the query
+	c, err := j.wsAuth.authenticate(req, true)
+	if err != nil {
+		j.logger.Debug("Unable to authenticate WS connection", "err", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+
+		return
+	}
+
 	// CORS rule - Allow requests from anywhere
 	wsUpgrader.CheckOrigin = func(r *http.Request) bool { return true }
 
@@ -222,6 +272,8 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 		}
 	}(ws)
 
+	defer j.openConnection(serverWS)()
+
 	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
 
 	j.logger.Info("Websocket connection established")
@@ -249,7 +301,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 
 		if isSupportedWSType(msgType) {
 			go func() {
-				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
+				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn, c)
 				if handleErr != nil {
 					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
 
@@ -286,6 +338,14 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 }
 
 func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request) {
+	c, err := j.httpAuth.authenticate(req, false)
+	if err != nil {
+		j.logger.Debug("Unable to authenticate request", "err", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+
+		return
+	}
+
 	data, err := io.ReadAll(req.Body)
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
@@ -296,7 +356,7 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	// log request
 	j.logger.Debug("handle", "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := j.dispatcher.Handle(data, c)
 
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
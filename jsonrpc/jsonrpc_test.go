@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/SECRYPT-2022/SECRYPT/helper/tests"
@@ -66,3 +69,57 @@ func Test_handleGetRequest(t *testing.T) {
 		response,
 	)
 }
+
+func TestHTTPServer_Authentication(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		ChainID: 100,
+		HTTPAuth: &AuthConfig{
+			APIKeys: map[string][]string{"key": {"eth"}},
+		},
+	}
+
+	jsonRPC := &JSONRPC{
+		logger: hclog.NewNullLogger(),
+		config: config,
+		dispatcher: newDispatcher(
+			hclog.NewNullLogger(),
+			newMockStore(),
+			&dispatcherParams{chainID: config.ChainID},
+		),
+		httpAuth: newAuthenticator(config.HTTPAuth),
+	}
+
+	request := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(
+			http.MethodPost,
+			"/",
+			strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`),
+		)
+
+		if apiKey != "" {
+			req.Header.Set(apiKeyHeader, apiKey)
+		}
+
+		recorder := httptest.NewRecorder()
+		jsonRPC.handle(recorder, req)
+
+		return recorder
+	}
+
+	var chainID string
+
+	resp := request("key")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NoError(t, expectJSONResult(resp.Body.Bytes(), &chainID))
+	assert.Equal(t, "0x64", chainID)
+
+	// the public access list is empty
+	resp = request("")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	expectMethodNotFound(t, resp.Body.Bytes(), "eth_chainId")
+
+	resp = request("unknown")
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
@@ -0,0 +1,89 @@
+package jsonrpc
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// jsonRPCMetrics is a prefix used for json-rpc related metrics
+	jsonRPCMetrics = "jsonrpc"
+
+	// method label of the requests to the methods that aren't served
+	unknownMethodLabel = "unknown"
+)
+
+// requestDuration is the histogram of the request durations, registered with Prometheus
+// directly as go-metrics only exports the durations as summaries
+var requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "jsonrpc_request_duration_seconds",
+	Help:    "Duration of the handled JSON-RPC requests, by method",
+	Buckets: []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+}, []string{"method"})
+
+// RegisterMetrics registers the JSON-RPC metrics which don't go through go-metrics
+func RegisterMetrics(registerer prometheus.Registerer) error {
+	return registerer.Register(requestDuration)
+}
+
+// metricsMethod returns the method label of the request metrics, the methods that aren't served
+// sharing the same label so that the clients can't create new series at will
+func (d *Dispatcher) metricsMethod(method string) string {
+	if method == "eth_subscribe" || method == "eth_unsubscribe" {
+		return method
+	}
+
+	if _, _, err := d.getFnHandler(Request{Method: method}); err != nil {
+		return unknownMethodLabel
+	}
+
+	return method
+}
+
+// observeRequest records the count, the duration and the error code of a handled request
+func (d *Dispatcher) observeRequest(method string, start time.Time, err Error) {
+	methodLabel := metrics.Label{Name: "method", Value: d.metricsMethod(method)}
+
+	metrics.IncrCounterWithLabels([]string{jsonRPCMetrics, "requests"}, 1, []metrics.Label{methodLabel})
+	requestDuration.WithLabelValues(methodLabel.Value).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		metrics.IncrCounterWithLabels(
+			[]string{jsonRPCMetrics, "errors"},
+			1,
+			[]metrics.Label{methodLabel, {Name: "code", Value: strconv.Itoa(err.ErrorCode())}},
+		)
+	}
+}
+
+// observeBatch records the number of requests of a batch
+func observeBatch(size int) {
+	metrics.AddSample([]string{jsonRPCMetrics, "batch_size"}, float32(size))
+}
+
+// openConnection counts the new connection of the transport (ws, ipc),
+// returning the function to call once it's closed
+func (j *JSONRPC) openConnection(transport serverType) func() {
+	connections := &j.wsConnections
+	if transport == serverIPC {
+		connections = &j.ipcConnections
+	}
+
+	setConnectionsGauge(transport, atomic.AddInt64(connections, 1))
+
+	return func() {
+		setConnectionsGauge(transport, atomic.AddInt64(connections, -1))
+	}
+}
+
+func setConnectionsGauge(transport serverType, connections int64) {
+	metrics.SetGaugeWithLabels(
+		[]string{jsonRPCMetrics, "connections"},
+		float32(connections),
+		[]metrics.Label{{Name: "transport", Value: transport.String()}},
+	)
+}
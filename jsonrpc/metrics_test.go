@@ -0,0 +1,124 @@
+package jsonrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestMetricsSink replaces the global metrics with an in-memory sink, the tests using it can't be parallel
+func newTestMetricsSink(t *testing.T) *metrics.InmemSink {
+	t.Helper()
+
+	sink := metrics.NewInmemSink(time.Hour, time.Hour)
+
+	config := metrics.DefaultConfig("")
+	config.EnableHostname = false
+	config.EnableRuntimeMetrics = false
+
+	_, err := metrics.NewGlobal(config, sink)
+	assert.NoError(t, err)
+
+	return sink
+}
+
+// requestDurationCount returns the number of request durations observed for the method
+func requestDurationCount(t *testing.T, method string) uint64 {
+	t.Helper()
+
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, RegisterMetrics(registry))
+
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "method" && label.GetValue() == method {
+					return metric.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+
+	return 0
+}
+
+// currentMetrics returns the metrics recorded in the current interval of the sink
+func currentMetrics(sink *metrics.InmemSink) *metrics.IntervalMetrics {
+	data := sink.Data()
+
+	return data[len(data)-1]
+}
+
+func TestDispatcher_Metrics(t *testing.T) {
+	sink := newTestMetricsSink(t)
+	durations := requestDurationCount(t, "eth_chainId")
+
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), &dispatcherParams{
+		chainID:                 100,
+		jsonRPCBatchLengthLimit: 20,
+	})
+
+	_, err := dispatcher.Handle([]byte(`{"id":1,"method":"eth_chainId","params":[]}`), nil)
+	assert.NoError(t, err)
+
+	_, err = dispatcher.Handle([]byte(`[
+		{"id":2,"jsonrpc":"2.0","method":"eth_chainId","params":[]},
+		{"id":3,"jsonrpc":"2.0","method":"eth_getBalance","params":{}},
+		{"id":4,"jsonrpc":"2.0","method":"eth_notAMethod","params":[]}]`), nil)
+	assert.NoError(t, err)
+
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	_, err = dispatcher.HandleWs([]byte(`{"id":5,"method":"eth_subscribe","params":["newHeads"]}`), mockConnection, nil)
+	assert.NoError(t, err)
+
+	data := currentMetrics(sink)
+
+	assert.Equal(t, 2, data.Counters["jsonrpc.requests;method=eth_chainId"].Count)
+	assert.Equal(t, 1, data.Counters["jsonrpc.requests;method=eth_getBalance"].Count)
+	assert.Equal(t, 1, data.Counters["jsonrpc.requests;method=eth_subscribe"].Count)
+	assert.Equal(t, durations+2, requestDurationCount(t, "eth_chainId"))
+
+	// the methods that aren't served share a label
+	assert.Equal(t, 1, data.Counters["jsonrpc.requests;method=unknown"].Count)
+	assert.Equal(t, 1, data.Counters["jsonrpc.errors;method=unknown;code=-32601"].Count)
+
+	assert.Equal(t, 1, data.Counters["jsonrpc.errors;method=eth_getBalance;code=-32602"].Count)
+
+	assert.Equal(t, 1, data.Samples["jsonrpc.batch_size"].Count)
+	assert.Equal(t, float64(3), data.Samples["jsonrpc.batch_size"].Sum)
+
+	assert.Equal(t, float32(1), data.Gauges["jsonrpc.filters"].Value)
+
+	dispatcher.RemoveFilterByWs(mockConnection)
+
+	assert.Equal(t, float32(0), currentMetrics(sink).Gauges["jsonrpc.filters"].Value)
+}
+
+func TestJSONRPC_ConnectionsMetrics(t *testing.T) {
+	sink := newTestMetricsSink(t)
+
+	j := &JSONRPC{}
+
+	closeWs := j.openConnection(serverWS)
+	closeIPC := j.openConnection(serverIPC)
+	j.openConnection(serverWS)
+
+	data := currentMetrics(sink)
+	assert.Equal(t, float32(2), data.Gauges["jsonrpc.connections;transport=ws"].Value)
+	assert.Equal(t, float32(1), data.Gauges["jsonrpc.connections;transport=ipc"].Value)
+
+	closeWs()
+	closeIPC()
+
+	data = currentMetrics(sink)
+	assert.Equal(t, float32(1), data.Gauges["jsonrpc.connections;transport=ws"].Value)
+	assert.Equal(t, float32(0), data.Gauges["jsonrpc.connections;transport=ipc"].Value)
+}
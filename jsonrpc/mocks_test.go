@@ -3,8 +3,10 @@ package jsonrpc
 import (
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/SECRYPT-2022/SECRYPT/blockchain"
+	"github.com/SECRYPT-2022/SECRYPT/helper/progress"
 	"github.com/SECRYPT-2022/SECRYPT/types"
 )
 
@@ -37,6 +39,7 @@ type mockHeader struct {
 }
 
 type mockEvent struct {
+	Type     blockchain.EventType
 	OldChain []*mockHeader
 	NewChain []*mockHeader
 }
@@ -52,6 +55,13 @@ type mockStore struct {
 
 	// headers is the list of historical headers
 	historicalHeaders []*types.Header
+
+	pendingTxCh    chan types.Hash
+	pendingTxsLock sync.Mutex
+	pendingTxs     map[types.Hash]*types.Transaction
+
+	syncLock        sync.Mutex
+	syncProgression *progress.Progression
 }
 
 func newMockStore() *mockStore {
@@ -59,6 +69,8 @@ func newMockStore() *mockStore {
 		header:       &types.Header{Number: 0},
 		subscription: blockchain.NewMockSubscription(),
 		accounts:     map[types.Address]*Account{},
+		pendingTxCh:  make(chan types.Hash),
+		pendingTxs:   map[types.Hash]*types.Transaction{},
 	}
 	m.addHeader(m.header)
 
@@ -90,6 +102,7 @@ func (m *mockStore) emitEvent(evnt *mockEvent) {
 	}
 
 	bEvnt := &blockchain.Event{
+		Type:     evnt.Type,
 		NewChain: []*types.Header{},
 		OldChain: []*types.Header{},
 	}
@@ -124,6 +137,10 @@ func (m *mockStore) Header() *types.Header {
 	return m.header
 }
 
+func (m *mockStore) GetFinalizedHeader() *types.Header {
+	return m.header
+}
+
 func (m *mockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
 	m.receiptsLock.Lock()
 	defer m.receiptsLock.Unlock()
@@ -172,6 +189,46 @@ func (m *mockStore) GetCapacity() (uint64, uint64) {
 	return 0, 0
 }
 
+func (m *mockStore) GetTxTTL(txHash types.Hash) (time.Duration, bool) {
+	return 0, false
+}
+
+func (m *mockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
+	m.pendingTxsLock.Lock()
+	defer m.pendingTxsLock.Unlock()
+
+	tx, ok := m.pendingTxs[txHash]
+
+	return tx, ok
+}
+
+func (m *mockStore) SubscribePendingTxs() (<-chan types.Hash, func()) {
+	return m.pendingTxCh, func() {}
+}
+
+// emitPendingTx adds the transaction to the pending ones and notifies it
+func (m *mockStore) emitPendingTx(tx *types.Transaction) {
+	m.pendingTxsLock.Lock()
+	m.pendingTxs[tx.Hash] = tx
+	m.pendingTxsLock.Unlock()
+
+	m.pendingTxCh <- tx.Hash
+}
+
+func (m *mockStore) GetSyncProgression() *progress.Progression {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+
+	return m.syncProgression
+}
+
+func (m *mockStore) setSyncProgression(syncProgression *progress.Progression) {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+
+	m.syncProgression = syncProgression
+}
+
 func (m *mockStore) GetPeers() int {
 	return 20
 }
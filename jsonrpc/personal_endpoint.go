@@ -0,0 +1,90 @@
+package jsonrpc
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/types"
+)
+
+// default duration of personal_unlockAccount, without duration
+const defaultUnlockDuration = 300 * time.Second
+
+var (
+	ErrAccountsDisabled = errors.New("node accounts are disabled, enable them in the server config")
+)
+
+// AccountManager manages the accounts of the node keystore, signing with the unlocked ones
+type AccountManager interface {
+	// Accounts returns the addresses of the accounts
+	Accounts() []types.Address
+
+	// NewAccount generates a new key, stored encrypted with the password
+	NewAccount(password string) (types.Address, error)
+
+	// ImportRawKey stores the raw private key encrypted with the password
+	ImportRawKey(rawKey []byte, password string) (types.Address, error)
+
+	// Unlock decrypts the key of the account for the duration, until locked again if zero
+	Unlock(address types.Address, password string, duration time.Duration) error
+
+	// Lock removes the decrypted key of the account
+	Lock(address types.Address) error
+
+	// SignHash signs the hash with the key of the unlocked account, in the [R || S || V] format
+	SignHash(address types.Address, hash []byte) ([]byte, error)
+
+	// SignTx signs the transaction with the key of its unlocked sender
+	SignTx(tx *types.Transaction) (*types.Transaction, error)
+}
+
+// Personal is the personal jsonrpc endpoint, managing the node accounts
+type Personal struct {
+	accounts AccountManager
+}
+
+// NewAccount creates a new account, its key encrypted with the password
+func (p *Personal) NewAccount(password string) (interface{}, error) {
+	return p.accounts.NewAccount(password)
+}
+
+// ImportRawKey imports the hex encoded private key, encrypted with the password
+func (p *Personal) ImportRawKey(rawKey string, password string) (interface{}, error) {
+	key, err := hex.DecodeString(strings.TrimPrefix(rawKey, "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	return p.accounts.ImportRawKey(key, password)
+}
+
+// UnlockAccount unlocks the account for the duration in seconds, 300 by default,
+// or until it's locked again if zero
+func (p *Personal) UnlockAccount(address types.Address, password string, duration *uint64) (interface{}, error) {
+	unlockDuration := defaultUnlockDuration
+	if duration != nil {
+		unlockDuration = time.Duration(*duration) * time.Second
+	}
+
+	if err := p.accounts.Unlock(address, password, unlockDuration); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// LockAccount locks the account, its key can't be used until unlocked again
+func (p *Personal) LockAccount(address types.Address) (interface{}, error) {
+	if err := p.accounts.Lock(address); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ListAccounts returns the accounts of the node
+func (p *Personal) ListAccounts() (interface{}, error) {
+	return p.accounts.Accounts(), nil
+}
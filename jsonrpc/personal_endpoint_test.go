@@ -0,0 +1,304 @@
+package jsonrpc
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/accounts"
+	"github.com/SECRYPT-2022/SECRYPT/crypto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var errMockLocked = errors.New("locked")
+
+type mockAccountManager struct {
+	keys     map[types.Address]*ecdsa.PrivateKey
+	unlocked map[types.Address]time.Duration
+}
+
+func newMockAccountManager() *mockAccountManager {
+	return &mockAccountManager{
+		keys:     make(map[types.Address]*ecdsa.PrivateKey),
+		unlocked: make(map[types.Address]time.Duration),
+	}
+}
+
+func (m *mockAccountManager) Accounts() []types.Address {
+	addresses := make([]types.Address, 0, len(m.keys))
+	for address := range m.keys {
+		addresses = append(addresses, address)
+	}
+
+	return addresses
+}
+
+func (m *mockAccountManager) NewAccount(password string) (types.Address, error) {
+	key, err := crypto.GenerateECDSAKey()
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	rawKey, _ := crypto.MarshalECDSAPrivateKey(key)
+
+	return m.ImportRawKey(rawKey, password)
+}
+
+func (m *mockAccountManager) ImportRawKey(rawKey []byte, _ string) (types.Address, error) {
+	key, err := crypto.ParseECDSAPrivateKey(rawKey)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	address := crypto.PubKeyToAddress(&key.PublicKey)
+	m.keys[address] = key
+
+	return address, nil
+}
+
+func (m *mockAccountManager) Unlock(address types.Address, _ string, duration time.Duration) error {
+	m.unlocked[address] = duration
+
+	return nil
+}
+
+func (m *mockAccountManager) Lock(address types.Address) error {
+	delete(m.unlocked, address)
+
+	return nil
+}
+
+func (m *mockAccountManager) unlockedKey(address types.Address) (*ecdsa.PrivateKey, error) {
+	if _, ok := m.unlocked[address]; !ok {
+		return nil, errMockLocked
+	}
+
+	return m.keys[address], nil
+}
+
+func (m *mockAccountManager) SignHash(address types.Address, hash []byte) ([]byte, error) {
+	key, err := m.unlockedKey(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Sign(key, hash)
+}
+
+func (m *mockAccountManager) SignTx(tx *types.Transaction) (*types.Transaction, error) {
+	key, err := m.unlockedKey(tx.From)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := crypto.NewEIP155Signer(100).SignTx(tx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	signed.ComputeHash()
+
+	return signed, nil
+}
+
+// newTestAccount imports a new key in the manager, unlocked
+func newTestAccount(t *testing.T, manager *mockAccountManager) types.Address {
+	t.Helper()
+
+	address, err := manager.NewAccount("")
+	assert.NoError(t, err)
+	assert.NoError(t, manager.Unlock(address, "", 0))
+
+	return address
+}
+
+func TestPersonal_Accounts(t *testing.T) {
+	t.Parallel()
+
+	manager := newMockAccountManager()
+	personal := &Personal{manager}
+
+	key, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	rawKey, err := crypto.MarshalECDSAPrivateKey(key)
+	assert.NoError(t, err)
+
+	imported, err := personal.ImportRawKey(hex.EncodeToString(rawKey), "password")
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.PubKeyToAddress(&key.PublicKey), imported)
+
+	_, err = personal.ImportRawKey("not hex", "password")
+	assert.Error(t, err)
+
+	created, err := personal.NewAccount("password")
+	assert.NoError(t, err)
+
+	accounts, err := personal.ListAccounts()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []types.Address{imported.(types.Address), created.(types.Address)}, accounts)
+
+	// unlocked for 300s by default
+	address := created.(types.Address) //nolint:forcetypeassert
+
+	res, err := personal.UnlockAccount(address, "password", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, true, res)
+	assert.Equal(t, defaultUnlockDuration, manager.unlocked[address])
+
+	duration := uint64(0)
+
+	_, err = personal.UnlockAccount(address, "password", &duration)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), manager.unlocked[address])
+
+	_, err = personal.LockAccount(address)
+	assert.NoError(t, err)
+	assert.NotContains(t, manager.unlocked, address)
+}
+
+func TestEth_Sign(t *testing.T) {
+	t.Parallel()
+
+	manager := newMockAccountManager()
+	address := newTestAccount(t, manager)
+
+	eth := newTestEthEndpoint(&mockStoreTxn{})
+	eth.accounts = manager
+
+	recoverSigner := func(hash []byte, res interface{}) types.Address {
+		//nolint:forcetypeassert
+		sig := append([]byte{}, *res.(*argBytes)...)
+		assert.Contains(t, []byte{27, 28}, sig[64])
+
+		sig[64] -= 27
+
+		pub, err := crypto.SigToPub(hash, sig)
+		assert.NoError(t, err)
+
+		return crypto.PubKeyToAddress(pub)
+	}
+
+	t.Run("message", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.Sign(address, argBytes("hello"))
+		assert.NoError(t, err)
+		assert.Equal(t, address, recoverSigner(accounts.TextHash([]byte("hello")), res))
+	})
+
+	t.Run("typed data", func(t *testing.T) {
+		t.Parallel()
+
+		var typedData accounts.TypedData
+		assert.NoError(t, json.Unmarshal([]byte(`{
+			"types": {
+				"EIP712Domain": [{"name": "name", "type": "string"}],
+				"Message": [{"name": "value", "type": "uint256"}]
+			},
+			"primaryType": "Message",
+			"domain": {"name": "test"},
+			"message": {"value": "0x10"}
+		}`), &typedData))
+
+		hash, err := typedData.Hash()
+		assert.NoError(t, err)
+
+		res, err := eth.SignTypedData_v4(address, &typedData)
+		assert.NoError(t, err)
+		assert.Equal(t, address, recoverSigner(hash, res))
+	})
+
+	t.Run("locked account", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := eth.Sign(types.StringToAddress("1"), argBytes("hello"))
+		assert.ErrorIs(t, err, errMockLocked)
+	})
+}
+
+func TestEth_SignAndSendTransaction(t *testing.T) {
+	t.Parallel()
+
+	manager := newMockAccountManager()
+	address := newTestAccount(t, manager)
+
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+	eth.accounts = manager
+
+	newArgs := func() *txnArgs {
+		return &txnArgs{
+			From:     &address,
+			To:       &addr1,
+			Gas:      argUintPtr(21000),
+			GasPrice: argBytesPtr(big.NewInt(10).Bytes()),
+			Value:    argBytesPtr(big.NewInt(5).Bytes()),
+		}
+	}
+
+	res, err := eth.SignTransaction(newArgs())
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	signed := res.(*signTransactionResult)
+
+	tx := &types.Transaction{}
+	assert.NoError(t, tx.UnmarshalRLP(signed.Raw))
+
+	sender, err := crypto.NewEIP155Signer(100).Sender(tx)
+	assert.NoError(t, err)
+	assert.Equal(t, address, sender)
+
+	// the nonce is the next one of the pool
+	assert.Equal(t, uint64(1), tx.Nonce)
+	assert.Equal(t, argUint64(1), signed.Tx.Nonce)
+
+	hash, err := eth.SendTransaction(newArgs())
+	assert.NoError(t, err)
+	assert.Equal(t, store.txn.Hash.String(), hash)
+	assert.Equal(t, address, store.txn.From)
+
+	_, err = eth.SendTransaction(&txnArgs{To: &addr1})
+	assert.ErrorIs(t, err, ErrMissingSender)
+}
+
+func TestEth_AccountsDisabled(t *testing.T) {
+	t.Parallel()
+
+	eth := newTestEthEndpoint(&mockStoreTxn{})
+
+	res, err := eth.Accounts()
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Address{}, res)
+
+	_, err = eth.Sign(addr0, argBytes("hello"))
+	assert.ErrorIs(t, err, ErrAccountsDisabled)
+
+	_, err = eth.SignTransaction(&txnArgs{From: &addr0})
+	assert.ErrorIs(t, err, ErrAccountsDisabled)
+
+	_, err = eth.SendTransaction(&txnArgs{From: &addr0})
+	assert.Error(t, err)
+
+	// the personal namespace isn't registered
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), &dispatcherParams{})
+
+	resp, err := dispatcher.Handle([]byte(`{"method": "personal_listAccounts", "params": [], "id": 1}`), nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(resp), "does not exist")
+
+	dispatcher = newDispatcher(hclog.NewNullLogger(), newMockStore(), &dispatcherParams{
+		accounts: newMockAccountManager(),
+	})
+
+	resp, err = dispatcher.Handle([]byte(`{"method": "personal_listAccounts", "params": [], "id": 1}`), nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(resp), `"result":[]`)
+}
@@ -0,0 +1,150 @@
+package jsonrpc
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+)
+
+const (
+	// cost of the methods without configured cost
+	defaultMethodCost = 1
+
+	// interval between the removals of the buckets of the idle clients
+	bucketsPruneInterval = time.Minute
+)
+
+// RateLimitConfig is the per client rate limiting config of the JSON-RPC requests,
+// the clients being identified by their API key, JWT subject or IP
+type RateLimitConfig struct {
+	// Rate is the cost refilled per second in the bucket of each client, rate limiting is disabled if zero
+	Rate float64
+
+	// Burst is the capacity of the buckets, the cost a client can spend at once
+	Burst float64
+
+	// MethodCosts maps the methods ("eth_getLogs") or namespaces ("debug") to their cost,
+	// the other methods costing 1
+	MethodCosts map[string]float64
+}
+
+// bucket is the token bucket of a client
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter rate limits the requests of the clients, with a token bucket each
+type rateLimiter struct {
+	rate        float64
+	burst       float64
+	methodCosts map[string]float64
+
+	lock      sync.Mutex
+	buckets   map[string]*bucket
+	lastPrune time.Time
+
+	// returns the current time, replaced in tests
+	now func() time.Time
+}
+
+// newRateLimiter returns the rate limiter of the config, nil if rate limiting is disabled
+func newRateLimiter(config *RateLimitConfig) *rateLimiter {
+	if config == nil || config.Rate <= 0 {
+		return nil
+	}
+
+	burst := config.Burst
+	if burst < config.Rate {
+		burst = config.Rate
+	}
+
+	return &rateLimiter{
+		rate:        config.Rate,
+		burst:       burst,
+		methodCosts: config.MethodCosts,
+		buckets:     make(map[string]*bucket),
+		lastPrune:   time.Now(),
+		now:         time.Now,
+	}
+}
+
+// cost returns the cost of the method, from its own cost or the one of its namespace
+func (l *rateLimiter) cost(method string) float64 {
+	if cost, ok := l.methodCosts[method]; ok {
+		return cost
+	}
+
+	if cost, ok := l.methodCosts[strings.SplitN(method, "_", 2)[0]]; ok {
+		return cost
+	}
+
+	return defaultMethodCost
+}
+
+// take spends the cost of the method from the bucket of the client, returning the time
+// to wait before it can be called again if the bucket doesn't hold enough tokens.
+// The method labels the metrics, it must be one of the served methods
+func (l *rateLimiter) take(clientID, method string) (bool, time.Duration) {
+	// the methods costing more than the burst only need a full bucket, they'd never be allowed otherwise
+	cost := math.Min(l.cost(method), l.burst)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+
+	l.pruneBuckets(now)
+
+	b, ok := l.buckets[clientID]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[clientID] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < cost {
+		wait := time.Duration((cost - b.tokens) / l.rate * float64(time.Second))
+
+		metrics.IncrCounterWithLabels(
+			[]string{jsonRPCMetrics, "rate_limited_requests"},
+			1,
+			[]metrics.Label{{Name: "method", Value: method}},
+		)
+
+		return false, wait
+	}
+
+	b.tokens -= cost
+
+	metrics.IncrCounterWithLabels(
+		[]string{jsonRPCMetrics, "requests_cost"},
+		float32(cost),
+		[]metrics.Label{{Name: "method", Value: method}},
+	)
+
+	return true, 0
+}
+
+// pruneBuckets removes the buckets refilled since, the idle clients don't need to be tracked.
+// The lock must be held
+func (l *rateLimiter) pruneBuckets(now time.Time) {
+	if now.Sub(l.lastPrune) < bucketsPruneInterval {
+		return
+	}
+
+	l.lastPrune = now
+
+	for id, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, id)
+		}
+	}
+
+	metrics.SetGauge([]string{jsonRPCMetrics, "rate_limit_clients"}, float32(len(l.buckets)))
+}
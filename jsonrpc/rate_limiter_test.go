@@ -0,0 +1,197 @@
+package jsonrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRateLimiter returns a rate limiter whose time only moves with the returned function
+func newTestRateLimiter(config *RateLimitConfig) (*rateLimiter, func(time.Duration)) {
+	limiter := newRateLimiter(config)
+
+	now := time.Unix(1700000000, 0)
+	limiter.now = func() time.Time {
+		return now
+	}
+	limiter.lastPrune = now
+
+	return limiter, func(d time.Duration) {
+		now = now.Add(d)
+	}
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newRateLimiter(nil))
+	assert.Nil(t, newRateLimiter(&RateLimitConfig{Burst: 10}))
+}
+
+func TestRateLimiter_Cost(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRateLimiter(&RateLimitConfig{
+		Rate: 1,
+		MethodCosts: map[string]float64{
+			"debug":                  20,
+			"debug_getRawBlock":      2,
+			"eth_getLogs":            10,
+			"eth_sendRawTransaction": 0,
+		},
+	})
+
+	assert.Equal(t, float64(20), limiter.cost("debug_traceTransaction"))
+	assert.Equal(t, float64(2), limiter.cost("debug_getRawBlock"))
+	assert.Equal(t, float64(10), limiter.cost("eth_getLogs"))
+	assert.Equal(t, float64(0), limiter.cost("eth_sendRawTransaction"))
+	assert.Equal(t, float64(defaultMethodCost), limiter.cost("eth_blockNumber"))
+}
+
+func TestRateLimiter_Take(t *testing.T) {
+	t.Parallel()
+
+	limiter, sleep := newTestRateLimiter(&RateLimitConfig{
+		Rate:  2,
+		Burst: 10,
+		MethodCosts: map[string]float64{
+			"eth_getLogs": 4,
+			"debug":       100,
+		},
+	})
+
+	// the bucket starts full
+	for i := 0; i < 2; i++ {
+		ok, _ := limiter.take("a", "eth_getLogs")
+		assert.True(t, ok)
+	}
+
+	ok, wait := limiter.take("a", "eth_getLogs")
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, wait)
+
+	// the cheaper methods can still be called
+	ok, _ = limiter.take("a", "eth_blockNumber")
+	assert.True(t, ok)
+
+	// the other clients have their own bucket
+	ok, _ = limiter.take("b", "eth_getLogs")
+	assert.True(t, ok)
+
+	sleep(1500 * time.Millisecond)
+
+	ok, _ = limiter.take("a", "eth_getLogs")
+	assert.True(t, ok)
+
+	// the methods costing more than the burst need a full bucket
+	ok, wait = limiter.take("a", "debug_traceTransaction")
+	assert.False(t, ok)
+	assert.Equal(t, 5*time.Second, wait)
+
+	sleep(5 * time.Second)
+
+	ok, _ = limiter.take("a", "debug_traceTransaction")
+	assert.True(t, ok)
+}
+
+func TestRateLimiter_PruneBuckets(t *testing.T) {
+	t.Parallel()
+
+	limiter, sleep := newTestRateLimiter(&RateLimitConfig{
+		Rate:        1,
+		Burst:       100,
+		MethodCosts: map[string]float64{"eth_getLogs": 50},
+	})
+
+	limiter.take("a", "eth_getLogs")
+
+	sleep(bucketsPruneInterval / 2)
+	limiter.take("b", "eth_getLogs")
+
+	// the bucket of a is full again, b used it since
+	sleep(bucketsPruneInterval / 2)
+	limiter.take("c", "eth_blockNumber")
+
+	_, hasA := limiter.buckets["a"]
+	_, hasB := limiter.buckets["b"]
+
+	assert.False(t, hasA)
+	assert.True(t, hasB)
+}
+
+func TestDispatcher_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newDispatcher(
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:                 100,
+			jsonRPCBatchLengthLimit: 20,
+			rateLimit: &RateLimitConfig{
+				Rate:  1,
+				Burst: 2,
+			},
+		},
+	)
+
+	c := &client{id: "ip:10.0.0.1"}
+
+	// the methods that aren't served don't use the bucket
+	for i := 0; i < 3; i++ {
+		var unknownResp SuccessResponse
+
+		resp, err := dispatcher.Handle([]byte(`{"id":1,"jsonrpc":"2.0","method":"eth_notServed","params":[]}`), c)
+		assert.NoError(t, err)
+		assert.NoError(t, expectBatchJSONResult(resp, &unknownResp))
+
+		if assert.NotNil(t, unknownResp.Error) {
+			assert.Equal(t, -32601, unknownResp.Error.Code)
+		}
+	}
+
+	// the limit applies to each request of the batches
+	var batch []*SuccessResponse
+
+	resp, err := dispatcher.Handle([]byte(`[
+		{"id":1,"jsonrpc":"2.0","method":"eth_chainId","params":[]},
+		{"id":2,"jsonrpc":"2.0","method":"eth_chainId","params":[]},
+		{"id":3,"jsonrpc":"2.0","method":"eth_chainId","params":[]}]`), c)
+	assert.NoError(t, err)
+	assert.NoError(t, expectBatchJSONResult(resp, &batch))
+
+	if assert.Len(t, batch, 3) {
+		assert.Nil(t, batch[0].Error)
+		assert.Nil(t, batch[1].Error)
+
+		if assert.NotNil(t, batch[2].Error) {
+			assert.Equal(t, -32005, batch[2].Error.Code)
+			assert.Equal(t, map[string]interface{}{"retryAfter": float64(1)}, batch[2].Error.Data)
+		}
+	}
+
+	// and to the websocket messages, subscriptions included
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	resp, err = dispatcher.HandleWs([]byte(`{"id":4,"method":"eth_subscribe","params":["newHeads"]}`), mockConnection, c)
+	assert.NoError(t, err)
+
+	var wsResp SuccessResponse
+
+	assert.NoError(t, expectBatchJSONResult(resp, &wsResp))
+
+	if assert.NotNil(t, wsResp.Error) {
+		assert.Equal(t, -32005, wsResp.Error.Code)
+	}
+
+	// the trusted clients aren't rate limited
+	resp, err = dispatcher.Handle([]byte(`{"id":5,"method":"eth_chainId","params":[]}`), nil)
+	assert.NoError(t, err)
+
+	var chainID string
+
+	assert.NoError(t, expectJSONResult(resp, &chainID))
+	assert.Equal(t, "0x64", chainID)
+}
@@ -0,0 +1,395 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer/paritytracer"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+)
+
+const (
+	traceTypeTrace     = "trace"
+	traceTypeVMTrace   = "vmTrace"
+	traceTypeStateDiff = "stateDiff"
+)
+
+var (
+	// ErrUnknownTraceType is an error returned when an unsupported trace type is requested
+	ErrUnknownTraceType = errors.New("unknown trace type")
+	// ErrUnexpectedTraceResult is an error returned when the tracer returns an unexpected result
+	ErrUnexpectedTraceResult = errors.New("unexpected trace result")
+)
+
+type traceStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetFinalizedHeader returns the last finalized header
+	GetFinalizedHeader() *types.Header
+
+	// GetHeaderByNumber gets a header using the provided number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+
+	// ReadTxLookup returns a block hash in which a given txn was mined
+	ReadTxLookup(txnHash types.Hash) (types.Hash, bool)
+
+	// GetBlockByHash gets a block using the provided hash
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+
+	// GetBlockByNumber gets a block using the provided height
+	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// TraceBlock traces all transactions in the given block
+	TraceBlock(*types.Block, tracer.Tracer) ([]interface{}, error)
+
+	// TraceTxn traces a transaction in the block, associated with the given hash
+	TraceTxn(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
+}
+
+// Trace is the trace jsonrpc endpoint, compatible with the OpenEthereum trace module
+type Trace struct {
+	store           traceStore
+	blockRangeLimit uint64
+}
+
+// traceFilter is the filter of trace_filter
+type traceFilter struct {
+	FromBlock   *BlockNumber    `json:"fromBlock"`
+	ToBlock     *BlockNumber    `json:"toBlock"`
+	FromAddress []types.Address `json:"fromAddress"`
+	ToAddress   []types.Address `json:"toAddress"`
+	After       *uint64         `json:"after"`
+	Count       *uint64         `json:"count"`
+}
+
+// replayResult is the result of replaying a transaction
+type replayResult struct {
+	*paritytracer.TxTrace
+
+	TransactionHash types.Hash `json:"transactionHash"`
+}
+
+// Block returns the traces of all transactions in the block
+func (t *Trace) Block(number BlockNumber) (interface{}, error) {
+	num, err := GetNumericBlockNumber(number, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := t.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+
+	txTraces, err := t.traceBlock(block, paritytracer.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	traces := []*paritytracer.Trace{}
+	for _, txTrace := range txTraces {
+		traces = append(traces, txTrace.Trace...)
+	}
+
+	return traces, nil
+}
+
+// Transaction returns the traces of the transaction
+func (t *Trace) Transaction(txHash types.Hash) (interface{}, error) {
+	tx, block := GetTxAndBlockByTxHash(txHash, t.store)
+	if tx == nil {
+		return nil, fmt.Errorf("tx %s not found", txHash.String())
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	tracer, cancel := newParityTracer(paritytracer.Config{})
+	defer cancel()
+
+	res, err := t.store.TraceTxn(block, tx.Hash, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	txTrace, ok := res.(*paritytracer.TxTrace)
+	if !ok {
+		return nil, ErrUnexpectedTraceResult
+	}
+
+	for idx, blockTx := range block.Transactions {
+		if blockTx.Hash == tx.Hash {
+			fillTraces(txTrace.Trace, block, uint64(idx))
+
+			break
+		}
+	}
+
+	return txTrace.Trace, nil
+}
+
+// ReplayBlockTransactions replays all transactions in the block,
+// returning the requested trace types for each of them
+func (t *Trace) ReplayBlockTransactions(number BlockNumber, traceTypes []string) (interface{}, error) {
+	config, withTrace, err := parseTraceTypes(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	num, err := GetNumericBlockNumber(number, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := t.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+
+	txTraces, err := t.traceBlock(block, config)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*replayResult, len(txTraces))
+
+	for idx, txTrace := range txTraces {
+		// replayed traces don't hold the block information
+		for _, trace := range txTrace.Trace {
+			trace.BlockHash, trace.BlockNumber = nil, nil
+			trace.TransactionHash, trace.TransactionPosition = nil, nil
+		}
+
+		if !withTrace {
+			txTrace.Trace = []*paritytracer.Trace{}
+		}
+
+		results[idx] = &replayResult{
+			TxTrace:         txTrace,
+			TransactionHash: block.Transactions[idx].Hash,
+		}
+	}
+
+	return results, nil
+}
+
+// Filter returns the traces in the block range matching the given addresses
+func (t *Trace) Filter(filter traceFilter) (interface{}, error) {
+	from, to, err := t.filterRange(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		traces  = []*paritytracer.Trace{}
+		skipped uint64
+	)
+
+	for num := from; num <= to; num++ {
+		block, ok := t.store.GetBlockByNumber(num, true)
+		if !ok {
+			return nil, fmt.Errorf("block %d not found", num)
+		}
+
+		if len(block.Transactions) == 0 {
+			continue
+		}
+
+		txTraces, err := t.traceBlock(block, paritytracer.Config{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, txTrace := range txTraces {
+			for _, trace := range txTrace.Trace {
+				if !filter.matches(trace) {
+					continue
+				}
+
+				if filter.After != nil && skipped < *filter.After {
+					skipped++
+
+					continue
+				}
+
+				traces = append(traces, trace)
+
+				if filter.Count != nil && uint64(len(traces)) >= *filter.Count {
+					return traces, nil
+				}
+			}
+		}
+	}
+
+	return traces, nil
+}
+
+// filterRange returns the block range of the filter
+func (t *Trace) filterRange(filter traceFilter) (uint64, uint64, error) {
+	fromBlock, toBlock := EarliestBlockNumber, LatestBlockNumber
+
+	if filter.FromBlock != nil {
+		fromBlock = *filter.FromBlock
+	}
+
+	if filter.ToBlock != nil {
+		toBlock = *filter.ToBlock
+	}
+
+	from, err := GetNumericBlockNumber(fromBlock, t.store)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err := GetNumericBlockNumber(toBlock, t.store)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if to < from {
+		return 0, 0, ErrIncorrectBlockRange
+	}
+
+	if t.blockRangeLimit != 0 && to-from > t.blockRangeLimit {
+		return 0, 0, ErrBlockRangeTooHigh
+	}
+
+	// genesis is not traceable
+	if from == 0 {
+		from = 1
+	}
+
+	return from, to, nil
+}
+
+// traceBlock traces the transactions in the block, filling the block information
+func (t *Trace) traceBlock(block *types.Block, config paritytracer.Config) ([]*paritytracer.TxTrace, error) {
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	tracer, cancel := newParityTracer(config)
+	defer cancel()
+
+	results, err := t.store.TraceBlock(block, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	txTraces := make([]*paritytracer.TxTrace, len(results))
+
+	for idx, res := range results {
+		txTrace, ok := res.(*paritytracer.TxTrace)
+		if !ok {
+			return nil, ErrUnexpectedTraceResult
+		}
+
+		fillTraces(txTrace.Trace, block, uint64(idx))
+
+		txTraces[idx] = txTrace
+	}
+
+	return txTraces, nil
+}
+
+// matches returns true if the trace matches the addresses of the filter
+func (f *traceFilter) matches(trace *paritytracer.Trace) bool {
+	var from, to *types.Address
+
+	switch {
+	case trace.Action.Address != nil:
+		// self destruct
+		from, to = trace.Action.Address, trace.Action.RefundAddress
+	case trace.Action.To != nil:
+		from, to = trace.Action.From, trace.Action.To
+	default:
+		// contract creation
+		from = trace.Action.From
+
+		if trace.Result != nil {
+			to = trace.Result.Address
+		}
+	}
+
+	return containsAddress(f.FromAddress, from) && containsAddress(f.ToAddress, to)
+}
+
+// containsAddress returns true if the list is empty or contains the address
+func containsAddress(list []types.Address, addr *types.Address) bool {
+	if len(list) == 0 {
+		return true
+	}
+
+	if addr == nil {
+		return false
+	}
+
+	for _, item := range list {
+		if item == *addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fillTraces sets the block and transaction information of the traces
+func fillTraces(traces []*paritytracer.Trace, block *types.Block, txIndex uint64) {
+	var (
+		blockHash   = block.Hash()
+		blockNumber = block.Number()
+		txHash      = block.Transactions[txIndex].Hash
+	)
+
+	for _, trace := range traces {
+		trace.BlockHash = &blockHash
+		trace.BlockNumber = &blockNumber
+		trace.TransactionHash = &txHash
+		trace.TransactionPosition = &txIndex
+	}
+}
+
+// parseTraceTypes returns the tracer config for the requested trace types
+func parseTraceTypes(traceTypes []string) (paritytracer.Config, bool, error) {
+	var (
+		config    paritytracer.Config
+		withTrace bool
+	)
+
+	for _, traceType := range traceTypes {
+		switch traceType {
+		case traceTypeTrace:
+			withTrace = true
+		case traceTypeVMTrace:
+			config.VMTrace = true
+		case traceTypeStateDiff:
+			config.StateDiff = true
+		default:
+			return config, false, fmt.Errorf("%w: %s", ErrUnknownTraceType, traceType)
+		}
+	}
+
+	return config, withTrace, nil
+}
+
+// newParityTracer creates a new parity tracer, cancelled after the default trace timeout
+func newParityTracer(config paritytracer.Config) (tracer.Tracer, context.CancelFunc) {
+	tracer := paritytracer.NewParityTracer(config)
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTraceTimeout)
+
+	go func() {
+		<-timeoutCtx.Done()
+
+		if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+			tracer.Cancel(ErrExecutionTimeout)
+		}
+	}()
+
+	// cancellation of context is done by caller
+	return tracer, cancel
+}
@@ -0,0 +1,308 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer/paritytracer"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	traceAddr1 = types.StringToAddress("1")
+	traceAddr2 = types.StringToAddress("2")
+	traceAddr3 = types.StringToAddress("3")
+)
+
+// newTraceTestBlock returns a block with a transaction from addr1 to each of the given addresses
+func newTraceTestBlock(num uint64, to ...types.Address) *types.Block {
+	block := &types.Block{
+		Header: &types.Header{
+			Number: num,
+		},
+	}
+
+	for idx := range to {
+		block.Transactions = append(block.Transactions, &types.Transaction{
+			Nonce: uint64(idx),
+			From:  traceAddr1,
+			To:    &to[idx],
+			Hash:  types.BytesToHash([]byte{byte(num), byte(idx)}),
+		})
+	}
+
+	block.Header.ComputeHash()
+
+	return block
+}
+
+// traceTestBlock runs the transactions of the block through the tracer hooks
+func traceTestBlock(block *types.Block, tr tracer.Tracer) ([]interface{}, error) {
+	results := make([]interface{}, len(block.Transactions))
+
+	for idx, tx := range block.Transactions {
+		tr.Clear()
+
+		tr.CallStart(1, tx.From, *tx.To, int(runtime.Call), 21000, big.NewInt(0), nil)
+		tr.CallEnd(1, nil, 0, nil)
+
+		res, err := tr.GetResult()
+		if err != nil {
+			return nil, err
+		}
+
+		results[idx] = res
+	}
+
+	return results, nil
+}
+
+func newTraceTestStore(blocks ...*types.Block) *debugEndpointMockStore {
+	return &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return blocks[len(blocks)-1].Header
+		},
+		getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+			for _, block := range blocks {
+				if block.Number() == num {
+					return block, true
+				}
+			}
+
+			return nil, false
+		},
+		getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+			for _, block := range blocks {
+				if block.Hash() == hash {
+					return block, true
+				}
+			}
+
+			return nil, false
+		},
+		readTxLookupFn: func(txHash types.Hash) (types.Hash, bool) {
+			for _, block := range blocks {
+				for _, tx := range block.Transactions {
+					if tx.Hash == txHash {
+						return block.Hash(), true
+					}
+				}
+			}
+
+			return types.ZeroHash, false
+		},
+		traceBlockFn: traceTestBlock,
+		traceTxnFn: func(block *types.Block, txHash types.Hash, tr tracer.Tracer) (interface{}, error) {
+			results, err := traceTestBlock(block, tr)
+			if err != nil {
+				return nil, err
+			}
+
+			for idx, tx := range block.Transactions {
+				if tx.Hash == txHash {
+					return results[idx], nil
+				}
+			}
+
+			return nil, nil
+		},
+	}
+}
+
+func TestTrace_Block(t *testing.T) {
+	t.Parallel()
+
+	block := newTraceTestBlock(1, traceAddr2, traceAddr3)
+	endpoint := &Trace{store: newTraceTestStore(newTraceTestBlock(0), block)}
+
+	res, err := endpoint.Block(BlockNumber(1))
+	assert.NoError(t, err)
+
+	traces, ok := res.([]*paritytracer.Trace)
+	assert.True(t, ok)
+	assert.Len(t, traces, 2)
+
+	for idx, trace := range traces {
+		assert.Equal(t, block.Hash(), *trace.BlockHash)
+		assert.Equal(t, uint64(1), *trace.BlockNumber)
+		assert.Equal(t, block.Transactions[idx].Hash, *trace.TransactionHash)
+		assert.Equal(t, uint64(idx), *trace.TransactionPosition)
+	}
+
+	// genesis can't be traced
+	_, err = endpoint.Block(BlockNumber(0))
+	assert.ErrorIs(t, err, ErrTraceGenesisBlock)
+}
+
+func TestTrace_Transaction(t *testing.T) {
+	t.Parallel()
+
+	block := newTraceTestBlock(1, traceAddr2, traceAddr3)
+	endpoint := &Trace{store: newTraceTestStore(newTraceTestBlock(0), block)}
+
+	res, err := endpoint.Transaction(block.Transactions[1].Hash)
+	assert.NoError(t, err)
+
+	traces, ok := res.([]*paritytracer.Trace)
+	assert.True(t, ok)
+	assert.Len(t, traces, 1)
+	assert.Equal(t, &traceAddr3, traces[0].Action.To)
+	assert.Equal(t, uint64(1), *traces[0].TransactionPosition)
+
+	_, err = endpoint.Transaction(types.StringToHash("ff"))
+	assert.Error(t, err)
+}
+
+func TestTrace_ReplayBlockTransactions(t *testing.T) {
+	t.Parallel()
+
+	block := newTraceTestBlock(1, traceAddr2)
+	endpoint := &Trace{store: newTraceTestStore(newTraceTestBlock(0), block)}
+
+	res, err := endpoint.ReplayBlockTransactions(BlockNumber(1), []string{traceTypeVMTrace})
+	assert.NoError(t, err)
+
+	results, ok := res.([]*replayResult)
+	assert.True(t, ok)
+	assert.Len(t, results, 1)
+	assert.Equal(t, block.Transactions[0].Hash, results[0].TransactionHash)
+	assert.Empty(t, results[0].Trace)
+	assert.NotNil(t, results[0].VMTrace)
+	assert.Nil(t, results[0].StateDiff)
+
+	res, err = endpoint.ReplayBlockTransactions(BlockNumber(1), []string{traceTypeTrace})
+	assert.NoError(t, err)
+
+	results, ok = res.([]*replayResult)
+	assert.True(t, ok)
+	assert.Len(t, results[0].Trace, 1)
+	assert.Nil(t, results[0].Trace[0].BlockHash)
+	assert.Nil(t, results[0].VMTrace)
+
+	_, err = endpoint.ReplayBlockTransactions(BlockNumber(1), []string{"foo"})
+	assert.ErrorIs(t, err, ErrUnknownTraceType)
+}
+
+func TestTrace_Filter(t *testing.T) {
+	t.Parallel()
+
+	blocks := []*types.Block{
+		newTraceTestBlock(0),
+		newTraceTestBlock(1, traceAddr2, traceAddr3),
+		newTraceTestBlock(2),
+		newTraceTestBlock(3, traceAddr3, traceAddr2, traceAddr3),
+	}
+
+	blockNumber := func(num int64) *BlockNumber {
+		bn := BlockNumber(num)
+
+		return &bn
+	}
+
+	count := func(num uint64) *uint64 {
+		return &num
+	}
+
+	testTable := []struct {
+		name     string
+		limit    uint64
+		filter   traceFilter
+		expected []types.Hash
+		err      error
+	}{
+		{
+			name:   "all traces",
+			filter: traceFilter{},
+			expected: []types.Hash{
+				blocks[1].Transactions[0].Hash,
+				blocks[1].Transactions[1].Hash,
+				blocks[3].Transactions[0].Hash,
+				blocks[3].Transactions[1].Hash,
+				blocks[3].Transactions[2].Hash,
+			},
+		},
+		{
+			name: "to address in range",
+			filter: traceFilter{
+				FromBlock: blockNumber(2),
+				ToBlock:   blockNumber(3),
+				ToAddress: []types.Address{traceAddr3},
+			},
+			expected: []types.Hash{
+				blocks[3].Transactions[0].Hash,
+				blocks[3].Transactions[2].Hash,
+			},
+		},
+		{
+			name: "unknown from address",
+			filter: traceFilter{
+				FromAddress: []types.Address{traceAddr2},
+			},
+			expected: []types.Hash{},
+		},
+		{
+			name: "after and count",
+			filter: traceFilter{
+				FromAddress: []types.Address{traceAddr1},
+				After:       count(1),
+				Count:       count(2),
+			},
+			expected: []types.Hash{
+				blocks[1].Transactions[1].Hash,
+				blocks[3].Transactions[0].Hash,
+			},
+		},
+		{
+			name:  "range too high",
+			limit: 2,
+			filter: traceFilter{
+				FromBlock: blockNumber(0),
+				ToBlock:   blockNumber(3),
+			},
+			err: ErrBlockRangeTooHigh,
+		},
+		{
+			name: "incorrect range",
+			filter: traceFilter{
+				FromBlock: blockNumber(3),
+				ToBlock:   blockNumber(1),
+			},
+			err: ErrIncorrectBlockRange,
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint := &Trace{
+				store:           newTraceTestStore(blocks...),
+				blockRangeLimit: tt.limit,
+			}
+
+			res, err := endpoint.Filter(tt.filter)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			traces, ok := res.([]*paritytracer.Trace)
+			assert.True(t, ok)
+
+			hashes := make([]types.Hash, len(traces))
+			for idx, trace := range traces {
+				hashes[idx] = *trace.TransactionHash
+			}
+
+			assert.Equal(t, tt.expected, hashes)
+		})
+	}
+}
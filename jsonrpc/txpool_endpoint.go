@@ -3,6 +3,7 @@ package jsonrpc
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/SECRYPT-2022/SECRYPT/types"
 )
@@ -14,6 +15,10 @@ type txPoolStore interface {
 
 	// GetCapacity returns the current and max capacity of the pool in slots
 	GetCapacity() (uint64, uint64)
+
+	// GetTxTTL returns the remaining lifetime of the pool transaction,
+	// or false if the transactions don't expire
+	GetTxTTL(txHash types.Hash) (time.Duration, bool)
 }
 
 // TxPool is the txpool jsonrpc endpoint
@@ -50,6 +55,7 @@ type txpoolTransaction struct {
 	BlockHash   types.Hash     `json:"blockHash"`
 	BlockNumber interface{}    `json:"blockNumber"`
 	TxIndex     interface{}    `json:"transactionIndex"`
+	TTL         *argUint64     `json:"ttl,omitempty"`
 }
 
 func toTxPoolTransaction(t *types.Transaction) *txpoolTransaction {
@@ -68,6 +74,19 @@ func toTxPoolTransaction(t *types.Transaction) *txpoolTransaction {
 	}
 }
 
+// toContentTransaction converts the transaction, along with
+// its remaining lifetime in seconds if the transactions expire
+func (t *TxPool) toContentTransaction(tx *types.Transaction) *txpoolTransaction {
+	rpcTx := toTxPoolTransaction(tx)
+
+	if ttl, ok := t.store.GetTxTTL(tx.Hash); ok {
+		seconds := argUint64(ttl / time.Second)
+		rpcTx.TTL = &seconds
+	}
+
+	return rpcTx
+}
+
 // Create response for txpool_content request.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_content.
 func (t *TxPool) Content() (interface{}, error) {
@@ -80,7 +99,7 @@ func (t *TxPool) Content() (interface{}, error) {
 
 		for _, tx := range txs {
 			nonce := tx.Nonce
-			rpcTx := toTxPoolTransaction(tx)
+			rpcTx := t.toContentTransaction(tx)
 
 			pendingRPCTxs[addr][nonce] = rpcTx
 		}
@@ -93,7 +112,7 @@ func (t *TxPool) Content() (interface{}, error) {
 
 		for _, tx := range txs {
 			nonce := tx.Nonce
-			rpcTx := toTxPoolTransaction(tx)
+			rpcTx := t.toContentTransaction(tx)
 
 			queuedRPCTxs[addr][nonce] = rpcTx
 		}
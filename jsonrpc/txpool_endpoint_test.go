@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/SECRYPT-2022/SECRYPT/types"
 
@@ -88,6 +89,32 @@ func TestContentEndpoint(t *testing.T) {
 		assert.Equal(t, nil, txData.TxIndex)
 	})
 
+	t.Run("returns ttl of expiring transactions", func(t *testing.T) {
+		t.Parallel()
+
+		mockStore := newMockTxPoolStore()
+		address1 := types.Address{0x1}
+		expiringTx := newTestTransaction(2, address1)
+		expiringTx.Hash = types.StringToHash("0x1")
+		testTx := newTestTransaction(3, address1)
+		testTx.Hash = types.StringToHash("0x2")
+		mockStore.pending[address1] = []*types.Transaction{expiringTx, testTx}
+		mockStore.ttls = map[types.Hash]time.Duration{
+			expiringTx.Hash: 90 * time.Second,
+		}
+		txPoolEndpoint := &TxPool{mockStore}
+
+		result, _ := txPoolEndpoint.Content()
+		//nolint:forcetypeassert
+		response := result.(ContentResponse)
+
+		txData := response.Pending[address1][expiringTx.Nonce]
+		assert.NotNil(t, txData.TTL)
+		assert.Equal(t, argUint64(90), *txData.TTL)
+
+		assert.Nil(t, response.Pending[address1][testTx.Nonce].TTL)
+	})
+
 	t.Run("returns correct ContentResponse data for multiple transactions", func(t *testing.T) {
 		t.Parallel()
 
@@ -234,6 +261,7 @@ type mockTxPoolStore struct {
 	capacity      uint64
 	maxSlots      uint64
 	includeQueued bool
+	ttls          map[types.Hash]time.Duration
 }
 
 func newMockTxPoolStore() *mockTxPoolStore {
@@ -253,6 +281,12 @@ func (s *mockTxPoolStore) GetCapacity() (uint64, uint64) {
 	return s.capacity, s.maxSlots
 }
 
+func (s *mockTxPoolStore) GetTxTTL(txHash types.Hash) (time.Duration, bool) {
+	ttl, ok := s.ttls[txHash]
+
+	return ttl, ok
+}
+
 func newTestTransaction(nonce uint64, from types.Address) *types.Transaction {
 	txn := &types.Transaction{
 		Nonce:    nonce,
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/SECRYPT-2022/SECRYPT/helper/hex"
+	"github.com/SECRYPT-2022/SECRYPT/helper/progress"
 	"github.com/SECRYPT-2022/SECRYPT/types"
 )
 
@@ -29,6 +30,10 @@ type transaction struct {
 	BlockHash   *types.Hash    `json:"blockHash"`
 	BlockNumber *argUint64     `json:"blockNumber"`
 	TxIndex     *argUint64     `json:"transactionIndex"`
+	FeePayer    *types.Address `json:"feePayer,omitempty"`
+	FeePayerV   *argBig        `json:"feePayerV,omitempty"`
+	FeePayerR   *argBig        `json:"feePayerR,omitempty"`
+	FeePayerS   *argBig        `json:"feePayerS,omitempty"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -78,6 +83,16 @@ func toTransaction(
 		res.TxIndex = argUintPtr(uint64(*txIndex))
 	}
 
+	if t.IsFeeDelegated() {
+		res.FeePayer = t.FeePayer
+
+		if t.FeePayerV != nil && t.FeePayerR != nil && t.FeePayerS != nil {
+			res.FeePayerV = argBigPtr(t.FeePayerV)
+			res.FeePayerR = argBigPtr(t.FeePayerR)
+			res.FeePayerS = argBigPtr(t.FeePayerS)
+		}
+	}
+
 	return res
 }
 
@@ -182,6 +197,65 @@ type receipt struct {
 	ContractAddress   *types.Address `json:"contractAddress"`
 	FromAddr          types.Address  `json:"from"`
 	ToAddr            *types.Address `json:"to"`
+	FeePayer          *types.Address `json:"feePayer,omitempty"`
+}
+
+func toReceipt(raw *types.Receipt, txn *types.Transaction, txIndex uint64, b *types.Block) *receipt {
+	logs := make([]*Log, len(raw.Logs))
+	for idx, elem := range raw.Logs {
+		logs[idx] = &Log{
+			Address:     elem.Address,
+			Topics:      elem.Topics,
+			Data:        argBytes(elem.Data),
+			BlockHash:   b.Hash(),
+			BlockNumber: argUint64(b.Number()),
+			TxHash:      txn.Hash,
+			TxIndex:     argUint64(txIndex),
+			LogIndex:    argUint64(idx),
+			Removed:     false,
+		}
+	}
+
+	res := &receipt{
+		Root:              raw.Root,
+		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
+		LogsBloom:         raw.LogsBloom,
+		TxHash:            txn.Hash,
+		TxIndex:           argUint64(txIndex),
+		BlockHash:         b.Hash(),
+		BlockNumber:       argUint64(b.Number()),
+		GasUsed:           argUint64(raw.GasUsed),
+		ContractAddress:   raw.ContractAddress,
+		FromAddr:          txn.From,
+		ToAddr:            txn.To,
+		Logs:              logs,
+	}
+
+	if raw.Status != nil {
+		res.Status = argUint64(*raw.Status)
+	}
+
+	if txn.IsFeeDelegated() {
+		res.FeePayer = txn.FeePayer
+	}
+
+	return res
+}
+
+// toTransactionAt returns the transaction at the index of the block, or nil if out of range
+func toTransactionAt(b *types.Block, index argUint64) *transaction {
+	if uint64(index) >= uint64(len(b.Transactions)) {
+		return nil
+	}
+
+	idx := int(index)
+
+	return toTransaction(
+		b.Transactions[idx],
+		argUintPtr(b.Number()),
+		argHashPtr(b.Hash()),
+		&idx,
+	)
 }
 
 type Log struct {
@@ -317,9 +391,137 @@ type txnArgs struct {
 	Nonce    *argUint64
 }
 
+// privateTxArgs are the arguments of eth_sendPrivateTransaction
+type privateTxArgs struct {
+	Tx             *argBytes  `json:"tx"`
+	MaxBlockNumber *argUint64 `json:"maxBlockNumber"`
+}
+
+// bundleArgs are the arguments of eth_sendBundle
+type bundleArgs struct {
+	Txs               []argBytes   `json:"txs"`
+	BlockNumber       *argUint64   `json:"blockNumber"`
+	RevertingTxHashes []types.Hash `json:"revertingTxHashes"`
+}
+
+// signTransactionResult is the result of eth_signTransaction
+type signTransactionResult struct {
+	Raw argBytes     `json:"raw"`
+	Tx  *transaction `json:"tx"`
+}
+
+type bundleResult struct {
+	BundleHash types.Hash `json:"bundleHash"`
+}
+
 type progression struct {
 	Type          string    `json:"type"`
 	StartingBlock argUint64 `json:"startingBlock"`
 	CurrentBlock  argUint64 `json:"currentBlock"`
 	HighestBlock  argUint64 `json:"highestBlock"`
 }
+
+func toProgression(p *progress.Progression) progression {
+	return progression{
+		Type:          string(p.SyncType),
+		StartingBlock: argUint64(p.StartingBlock),
+		CurrentBlock:  argUint64(p.CurrentBlock),
+		HighestBlock:  argUint64(p.HighestBlock),
+	}
+}
+
+// syncStatus is the notification of the syncing subscription while syncing,
+// false being notified once the sync is over
+type syncStatus struct {
+	Syncing bool        `json:"syncing"`
+	Status  progression `json:"status"`
+}
+
+// status of the transactions included in a block, the others being reported by the pool
+const txStatusIncluded = "included"
+
+type txEventResult struct {
+	Type   string    `json:"type"`
+	Reason string    `json:"reason,omitempty"`
+	Time   argUint64 `json:"time"`
+}
+
+type txStatusResult struct {
+	Status           string           `json:"status"`
+	BlockHash        *types.Hash      `json:"blockHash,omitempty"`
+	BlockNumber      *argUint64       `json:"blockNumber,omitempty"`
+	From             *types.Address   `json:"from,omitempty"`
+	Nonce            *argUint64       `json:"nonce,omitempty"`
+	NextNonce        *argUint64       `json:"nextNonce,omitempty"`
+	MissingNonces    []argUint64      `json:"missingNonces,omitempty"`
+	GasPrice         *argBig          `json:"gasPrice,omitempty"`
+	RequiredGasPrice *argBig          `json:"requiredGasPrice,omitempty"`
+	LastEvent        *txEventResult   `json:"lastEvent"`
+	History          []*txEventResult `json:"history"`
+}
+
+func toTxStatusResult(status *TxStatus) *txStatusResult {
+	res := &txStatusResult{
+		Status:  status.State,
+		History: make([]*txEventResult, len(status.History)),
+	}
+
+	if status.RequiredGasPrice != nil {
+		res.RequiredGasPrice = argBigPtr(status.RequiredGasPrice)
+	}
+
+	if status.From != nil {
+		res.From = status.From
+		res.Nonce = argUintPtr(status.Nonce)
+		res.NextNonce = argUintPtr(status.NextNonce)
+		res.GasPrice = argBigPtr(status.GasPrice)
+	}
+
+	for _, nonce := range status.MissingNonces {
+		res.MissingNonces = append(res.MissingNonces, argUint64(nonce))
+	}
+
+	for idx, event := range status.History {
+		res.History[idx] = &txEventResult{
+			Type:   event.Type,
+			Reason: event.Reason,
+			Time:   argUint64(event.Time.UnixMilli()),
+		}
+	}
+
+	if len(res.History) > 0 {
+		res.LastEvent = res.History[len(res.History)-1]
+	}
+
+	return res
+}
+
+type feeHistoryResult struct {
+	OldestBlock   argUint64   `json:"oldestBlock"`
+	BaseFeePerGas []argUint64 `json:"baseFeePerGas"`
+	GasUsedRatio  []float64   `json:"gasUsedRatio"`
+	Reward        [][]*argBig `json:"reward,omitempty"`
+}
+
+func toFeeHistoryResult(history *feeHistory) *feeHistoryResult {
+	res := &feeHistoryResult{
+		OldestBlock: argUint64(history.oldestBlock),
+		// the chain has no base fee, reported as zero for the blocks and the next one
+		BaseFeePerGas: make([]argUint64, len(history.gasUsedRatio)+1),
+		GasUsedRatio:  history.gasUsedRatio,
+	}
+
+	if history.reward != nil {
+		res.Reward = make([][]*argBig, len(history.reward))
+
+		for idx, blockReward := range history.reward {
+			res.Reward[idx] = make([]*argBig, len(blockReward))
+
+			for i, reward := range blockReward {
+				res.Reward[idx][i] = argBigPtr(reward)
+			}
+		}
+	}
+
+	return res
+}
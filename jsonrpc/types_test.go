@@ -242,6 +242,22 @@ func TestTransaction_Encoding(t *testing.T) {
 		testTransaction("testsuite/transaction-sealed.json")
 	})
 
+	t.Run("fee delegated", func(t *testing.T) {
+		feePayer := types.Address{0x4}
+
+		tt.FeePayer = &feePayer
+		tt.FeePayerV = argBigPtr(big.NewInt(4))
+		tt.FeePayerR = argBigPtr(big.NewInt(5))
+		tt.FeePayerS = argBigPtr(big.NewInt(6))
+
+		testTransaction("testsuite/transaction-fee-delegated.json")
+
+		tt.FeePayer = nil
+		tt.FeePayerV = nil
+		tt.FeePayerR = nil
+		tt.FeePayerS = nil
+	})
+
 	t.Run("pending", func(t *testing.T) {
 		tt.BlockHash = nil
 		tt.BlockNumber = nil
@@ -250,3 +266,60 @@ func TestTransaction_Encoding(t *testing.T) {
 		testTransaction("testsuite/transaction-pending.json")
 	})
 }
+
+func TestReceipt_Encoding(t *testing.T) {
+	status := types.ReceiptSuccess
+	to := types.Address{0x5}
+
+	raw := &types.Receipt{
+		CumulativeGasUsed: 42000,
+		LogsBloom:         types.Bloom{0x1},
+		Status:            &status,
+		GasUsed:           21000,
+		Logs: []*types.Log{
+			{
+				Address: types.Address{0x6},
+				Topics:  []types.Hash{{0x7}},
+				Data:    []byte{0x1, 0x2},
+			},
+		},
+	}
+
+	txn := &types.Transaction{
+		Hash: types.Hash{0x2},
+		From: types.Address{0x3},
+		To:   &to,
+	}
+
+	b := &types.Block{
+		Header: &types.Header{
+			Number: 1,
+			Hash:   types.Hash{0x8},
+		},
+	}
+
+	testReceipt := func(name string, rec *receipt) {
+		res, err := json.Marshal(rec)
+		require.NoError(t, err)
+
+		data, err := testsuite.ReadFile(name)
+		require.NoError(t, err)
+
+		data = removeWhiteSpace(data)
+		require.Equal(t, data, res)
+	}
+
+	t.Run("transaction receipt", func(t *testing.T) {
+		testReceipt("testsuite/receipt.json", toReceipt(raw, txn, 1, b))
+	})
+
+	t.Run("contract creation receipt", func(t *testing.T) {
+		contract := types.Address{0x9}
+
+		raw.ContractAddress = &contract
+		raw.Logs = nil
+		txn.To = nil
+
+		testReceipt("testsuite/receipt-contract-creation.json", toReceipt(raw, txn, 0, b))
+	})
+}
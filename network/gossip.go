@@ -43,18 +43,38 @@ func (t *Topic) Publish(obj proto.Message) error {
 	return t.topic.Publish(context.Background(), data)
 }
 
+// Subscribe subscribes to the topic, passing the publisher of each message to the handler
 func (t *Topic) Subscribe(handler func(obj interface{}, from peer.ID)) error {
+	return t.subscribe(handler, (*pubsub.Message).GetFrom)
+}
+
+// SubscribeForwarded subscribes to the topic, passing to the handler the connected peer
+// each message was received from, which isn't its publisher if the message was relayed
+func (t *Topic) SubscribeForwarded(handler func(obj interface{}, from peer.ID)) error {
+	return t.subscribe(handler, func(msg *pubsub.Message) peer.ID {
+		return msg.ReceivedFrom
+	})
+}
+
+func (t *Topic) subscribe(
+	handler func(obj interface{}, from peer.ID),
+	sender func(*pubsub.Message) peer.ID,
+) error {
 	sub, err := t.topic.Subscribe(pubsub.WithBufferSize(subscribeOutputBufferSize))
 	if err != nil {
 		return err
 	}
 
-	go t.readLoop(sub, handler)
+	go t.readLoop(sub, handler, sender)
 
 	return nil
 }
 
-func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{}, from peer.ID)) {
+func (t *Topic) readLoop(
+	sub *pubsub.Subscription,
+	handler func(obj interface{}, from peer.ID),
+	sender func(*pubsub.Message) peer.ID,
+) {
 	ctx, cancelFn := context.WithCancel(context.Background())
 
 	go func() {
@@ -78,7 +98,7 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 				return
 			}
 
-			handler(obj, msg.GetFrom())
+			handler(obj, sender(msg))
 		}()
 	}
 }
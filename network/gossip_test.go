@@ -107,3 +107,95 @@ func TestSimpleGossip(t *testing.T) {
 		}
 	}
 }
+
+func TestSubscribeForwarded(t *testing.T) {
+	noDiscover := &CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = true
+		},
+	}
+
+	// the servers are chained, the relayer being the only peer of the receiver
+	servers, createErr := createServers(3, map[int]*CreateServerParams{
+		0: noDiscover,
+		1: noDiscover,
+		2: noDiscover,
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	publisher, relayer, receiver := servers[0], servers[1], servers[2]
+
+	for _, pair := range [][2]*Server{{publisher, relayer}, {relayer, receiver}} {
+		if joinErr := JoinAndWait(pair[0], pair[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+			t.Fatalf("Unable to join servers, %v", joinErr)
+		}
+	}
+
+	topicName := "msg-pub-sub"
+	topics := make([]*Topic, len(servers))
+
+	for i, server := range servers {
+		topic, topicErr := server.NewTopic(topicName, &testproto.GenericMessage{})
+		if topicErr != nil {
+			t.Fatalf("Unable to create topic, %v", topicErr)
+		}
+
+		topics[i] = topic
+	}
+
+	// the relayer has to subscribe to the topic to forward the messages
+	if subscribeErr := topics[1].Subscribe(func(interface{}, peer.ID) {}); subscribeErr != nil {
+		t.Fatalf("Unable to subscribe to topic, %v", subscribeErr)
+	}
+
+	fromCh := make(chan peer.ID, 16)
+
+	if subscribeErr := topics[2].SubscribeForwarded(func(_ interface{}, from peer.ID) {
+		select {
+		case fromCh <- from:
+		default:
+		}
+	}); subscribeErr != nil {
+		t.Fatalf("Unable to subscribe to topic, %v", subscribeErr)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if waitErr := WaitForSubscribers(ctx, publisher, topicName, 1); waitErr != nil {
+		t.Fatalf("Unable to wait for subscribers, %v", waitErr)
+	}
+
+	if waitErr := WaitForSubscribers(ctx, relayer, topicName, 1); waitErr != nil {
+		t.Fatalf("Unable to wait for subscribers, %v", waitErr)
+	}
+
+	timeoutCh := time.After(15 * time.Second)
+
+	// the message is published again until the relayer has the receiver in its mesh
+	for i := 0; ; i++ {
+		if publishErr := topics[0].Publish(
+			&testproto.GenericMessage{Message: fmt.Sprintf("relayed %d", i)},
+		); publishErr != nil {
+			t.Fatalf("Unable to publish message, %v", publishErr)
+		}
+
+		select {
+		case <-timeoutCh:
+			t.Fatalf("Relayed message not received before timeout")
+		case from := <-fromCh:
+			if from != relayer.AddrInfo().ID {
+				t.Fatalf("Expected the relayer %s, got %s", relayer.AddrInfo().ID, from)
+			}
+
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -19,6 +19,7 @@ const PeerID = "peerID"
 var (
 	ErrInvalidChainID   = errors.New("invalid chain ID")
 	ErrNoAvailableSlots = errors.New("no available Slots")
+	ErrBannedPeer       = errors.New("peer is banned")
 )
 
 // networkingServer defines the base communication interface between
@@ -43,6 +44,9 @@ type networkingServer interface {
 	// EmitEvent emits the specified peer event on the base networking server
 	EmitEvent(event *event.PeerEvent)
 
+	// IsBanned checks if the peer is currently banned [Thread safe]
+	IsBanned(peerID peer.ID) bool
+
 	// TEMPORARY DIALING //
 
 	// IsTemporaryDial checks if the peer connection is a temporary dial [Thread safe]
@@ -93,6 +97,12 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 				return
 			}
 
+			if i.baseServer.IsBanned(peerID) {
+				i.disconnectFromPeer(peerID, ErrBannedPeer.Error())
+
+				return
+			}
+
 			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
 				i.disconnectFromPeer(peerID, ErrNoAvailableSlots.Error())
 
@@ -87,6 +87,9 @@ type Server struct {
 
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
+	bannedPeers     map[peer.ID]time.Time // map of the banned peers; peerID -> ban expiry
+	bannedPeersLock sync.Mutex            // lock for the banned peers map
+
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
 }
 
@@ -144,6 +147,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		closeCh:          make(chan struct{}),
 		emitterPeerEvent: emitter,
 		protocols:        map[string]Protocol{},
+		bannedPeers:      make(map[peer.ID]time.Time),
 		secretsManager:   config.SecretsManager,
 		bootnodes: &bootnodesWrapper{
 			bootnodeArr:       make([]*peer.AddrInfo, 0),
@@ -407,7 +411,7 @@ func (s *Server) runDial() {
 
 			s.logger.Debug(fmt.Sprintf("Dialing peer [%s] as local [%s]", peerInfo.String(), s.host.ID()))
 
-			if !s.IsConnected(peerInfo.ID) {
+			if !s.IsConnected(peerInfo.ID) && !s.IsBanned(peerInfo.ID) {
 				// the connection process is async because it involves connection (here) +
 				// the handshake done in the identity service.
 				if err := s.host.Connect(context.Background(), *peerInfo); err != nil {
@@ -546,6 +550,38 @@ func (s *Server) DisconnectFromPeer(peer peer.ID, reason string) {
 	}
 }
 
+// BanPeer disconnects from the specified peer, and refuses its connections for the given duration
+func (s *Server) BanPeer(peerID peer.ID, duration time.Duration, reason string) {
+	s.bannedPeersLock.Lock()
+	s.bannedPeers[peerID] = time.Now().Add(duration)
+	s.bannedPeersLock.Unlock()
+
+	metrics.IncrCounter([]string{networkMetrics, "banned_peers"}, 1)
+
+	s.logger.Info("Banning peer", "id", peerID, "duration", duration, "reason", reason)
+
+	s.DisconnectFromPeer(peerID, reason)
+}
+
+// IsBanned checks if the specified peer is currently banned [Thread safe]
+func (s *Server) IsBanned(peerID peer.ID) bool {
+	s.bannedPeersLock.Lock()
+	defer s.bannedPeersLock.Unlock()
+
+	expiry, ok := s.bannedPeers[peerID]
+	if !ok {
+		return false
+	}
+
+	if time.Now().After(expiry) {
+		delete(s.bannedPeers, peerID)
+
+		return false
+	}
+
+	return true
+}
+
 var (
 	// Anything below 35s is prone to false timeouts, as seen from empirical test data
 	DefaultJoinTimeout   = 100 * time.Second
@@ -29,6 +29,7 @@ type MockNetworkingServer struct {
 	updatePendingConnCountFn updatePendingConnCountDelegate
 	emitEventFn              emitEventDelegate
 	isTemporaryDialFn        isTemporaryDialDelegate
+	isBannedFn               isBannedDelegate
 	hasFreeConnectionSlotFn  hasFreeConnectionSlotDelegate
 
 	// Discovery Hooks
@@ -73,6 +74,7 @@ type addPeerDelegate func(peer.ID, network.Direction)
 type updatePendingConnCountDelegate func(int64, network.Direction)
 type emitEventDelegate func(*event.PeerEvent)
 type isTemporaryDialDelegate func(peer.ID) bool
+type isBannedDelegate func(peer.ID) bool
 type hasFreeConnectionSlotDelegate func(network.Direction) bool
 
 // Required for Discovery
@@ -162,6 +164,18 @@ func (m *MockNetworkingServer) HookIsTemporaryDial(fn isTemporaryDialDelegate) {
 	m.isTemporaryDialFn = fn
 }
 
+func (m *MockNetworkingServer) IsBanned(peerID peer.ID) bool {
+	if m.isBannedFn != nil {
+		return m.isBannedFn(peerID)
+	}
+
+	return false
+}
+
+func (m *MockNetworkingServer) HookIsBanned(fn isBannedDelegate) {
+	m.isBannedFn = fn
+}
+
 func (m *MockNetworkingServer) HasFreeConnectionSlot(direction network.Direction) bool {
 	if m.hasFreeConnectionSlotFn != nil {
 		return m.hasFreeConnectionSlotFn(direction)
@@ -63,7 +63,11 @@ func (l *LocalSecretsManager) Setup() error {
 	l.secretPathMapLock.Lock()
 	defer l.secretPathMapLock.Unlock()
 
-	subDirectories := []string{secrets.ConsensusFolderLocal, secrets.NetworkFolderLocal}
+	subDirectories := []string{
+		secrets.ConsensusFolderLocal,
+		secrets.NetworkFolderLocal,
+		secrets.JSONRPCFolderLocal,
+	}
 
 	// Set up the local directories
 	if err := common.SetupDataDir(l.path, subDirectories); err != nil {
@@ -91,6 +95,13 @@ func (l *LocalSecretsManager) Setup() error {
 		secrets.NetworkKeyLocal,
 	)
 
+	// baseDir/jsonrpc/jwt.secret
+	l.secretPathMap[secrets.JSONRPCJWTSecret] = filepath.Join(
+		l.path,
+		secrets.JSONRPCFolderLocal,
+		secrets.JSONRPCJWTSecretLocal,
+	)
+
 	return nil
 }
 
@@ -31,19 +31,24 @@ const (
 
 	// NetworkKey is the libp2p private key secret used for networking
 	NetworkKey = "network-key"
+
+	// JSONRPCJWTSecret is the HS256 key of the JSON-RPC bearer tokens
+	JSONRPCJWTSecret = "jsonrpc-jwt-secret"
 )
 
 // Define constant file names for the local StorageManager
 const (
-	ValidatorKeyLocal    = "validator.key"
-	ValidatorBLSKeyLocal = "validator-bls.key"
-	NetworkKeyLocal      = "libp2p.key"
+	ValidatorKeyLocal     = "validator.key"
+	ValidatorBLSKeyLocal  = "validator-bls.key"
+	NetworkKeyLocal       = "libp2p.key"
+	JSONRPCJWTSecretLocal = "jwt.secret"
 )
 
 // Define constant folder names for the local StorageManager
 const (
 	ConsensusFolderLocal = "consensus"
 	NetworkFolderLocal   = "libp2p"
+	JSONRPCFolderLocal   = "jsonrpc"
 )
 
 var (
@@ -2,10 +2,13 @@ package server
 
 import (
 	"net"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/SECRYPT-2022/SECRYPT/chain"
+	"github.com/SECRYPT-2022/SECRYPT/jsonrpc"
 	"github.com/SECRYPT-2022/SECRYPT/network"
 	"github.com/SECRYPT-2022/SECRYPT/secrets"
 )
@@ -22,8 +25,14 @@ type Config struct {
 	LibP2PAddr *net.TCPAddr
 
 	PriceLimit         uint64
+	PriceBump          uint64
 	MaxAccountEnqueued uint64
 	MaxSlots           uint64
+	Journal            bool
+	JournalRemotes     bool
+	RejournalInterval  time.Duration
+	TxLifetime         time.Duration
+	PrivateTxPeers     []peer.ID
 	BlockTime          uint64
 
 	Telemetry *Telemetry
@@ -41,6 +50,8 @@ type Config struct {
 	JSONLogFormat bool
 
 	LogFilePath string
+
+	Accounts *Accounts
 }
 
 // Telemetry holds the config details for metric services
@@ -48,10 +59,45 @@ type Telemetry struct {
 	PrometheusAddr *net.TCPAddr
 }
 
+// Accounts holds the config details for the node accounts, used through the JSON-RPC server
+type Accounts struct {
+	Enabled bool
+
+	// KeystoreDir is the directory of the key files, <data-dir>/keystore if empty
+	KeystoreDir string
+}
+
 // JSONRPC holds the config details for the JSON-RPC server
 type JSONRPC struct {
 	JSONRPCAddr              *net.TCPAddr
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+
+	// IPCPath is the path of the IPC endpoint socket, it's disabled if empty
+	IPCPath string
+
+	// DisabledNamespaces are the namespaces that aren't served
+	DisabledNamespaces []string
+
+	// HTTPAuth and WSAuth are the authentication configs of the transports,
+	// they aren't authenticated if nil
+	HTTPAuth *JSONRPCAuth
+	WSAuth   *JSONRPCAuth
+
+	// RateLimit is the per client rate limiting of the requests, disabled if nil
+	RateLimit *jsonrpc.RateLimitConfig
+}
+
+// JSONRPCAuth holds the access lists of a JSON-RPC transport,
+// the JWT tokens being signed with the secret of the secrets manager
+type JSONRPCAuth struct {
+	JWTSubjects map[string][]string
+	APIKeys     map[string][]string
+	Public      []string
+}
+
+// acceptsJWT returns true if the transport is authenticated with JWT tokens
+func (a *JSONRPCAuth) acceptsJWT() bool {
+	return a != nil && len(a.JWTSubjects) > 0
 }
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"math/big"
@@ -9,8 +10,10 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/SECRYPT-2022/SECRYPT/accounts"
 	"github.com/SECRYPT-2022/SECRYPT/archive"
 	"github.com/SECRYPT-2022/SECRYPT/blockchain"
 	"github.com/SECRYPT-2022/SECRYPT/chain"
@@ -18,6 +21,8 @@ import (
 	"github.com/SECRYPT-2022/SECRYPT/crypto"
 	"github.com/SECRYPT-2022/SECRYPT/helper/common"
 	configHelper "github.com/SECRYPT-2022/SECRYPT/helper/config"
+	"github.com/SECRYPT-2022/SECRYPT/helper/hex"
+	"github.com/SECRYPT-2022/SECRYPT/helper/ipc"
 	"github.com/SECRYPT-2022/SECRYPT/helper/progress"
 	"github.com/SECRYPT-2022/SECRYPT/jsonrpc"
 	"github.com/SECRYPT-2022/SECRYPT/network"
@@ -26,6 +31,7 @@ import (
 	"github.com/SECRYPT-2022/SECRYPT/state"
 	itrie "github.com/SECRYPT-2022/SECRYPT/state/immutable-trie"
 	"github.com/SECRYPT-2022/SECRYPT/state/runtime"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/allowlist"
 	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer"
 	"github.com/SECRYPT-2022/SECRYPT/txpool"
 	"github.com/SECRYPT-2022/SECRYPT/types"
@@ -54,9 +60,15 @@ type Server struct {
 	// jsonrpc stack
 	jsonrpcServer *jsonrpc.JSONRPC
 
+	// node accounts, nil if they're disabled
+	accountManager *accounts.Manager
+
 	// system grpc server
 	grpcServer *grpc.Server
 
+	// IPC endpoint shared by the grpc and jsonrpc servers, nil if it's disabled
+	ipcMux *ipc.Mux
+
 	// libp2p network
 	network *network.Server
 
@@ -72,6 +84,12 @@ type Server struct {
 	restoreProgression *progress.ProgressionWrapper
 }
 
+// txPoolJournalFile is the file in the data dir keeping the pool transactions
+const txPoolJournalFile = "transactions.rlp"
+
+// jwtSecretLength is the length of the generated JSON-RPC JWT secret
+const jwtSecretLength = 32
+
 var dirPaths = []string{
 	"blockchain",
 	"trie",
@@ -212,6 +230,11 @@ func NewServer(config *Config) (*Server, error) {
 			return nil, err
 		}
 
+		// the allow lists can't be managed without bootstrap admins
+		if config.Chain.Params.AllowLists != nil && len(deploymentWhitelist) == 0 {
+			return nil, allowlist.ErrMissingBootstrap
+		}
+
 		// start transaction pool
 		m.txpool, err = txpool.NewTxPool(
 			logger,
@@ -222,8 +245,16 @@ func NewServer(config *Config) (*Server, error) {
 			&txpool.Config{
 				MaxSlots:            m.config.MaxSlots,
 				PriceLimit:          m.config.PriceLimit,
+				PriceBump:           m.config.PriceBump,
 				MaxAccountEnqueued:  m.config.MaxAccountEnqueued,
 				DeploymentWhitelist: deploymentWhitelist,
+				AllowLists:          config.Chain.Params.AllowLists,
+				FeeDelegation:       m.chain.Params.Forks.FeeDelegation,
+				JournalPath:         m.journalPath(),
+				JournalRemotes:      m.config.JournalRemotes,
+				RejournalInterval:   m.config.RejournalInterval,
+				Lifetime:            m.config.TxLifetime,
+				PrivateTxPeers:      m.config.PrivateTxPeers,
 			},
 		)
 		if err != nil {
@@ -279,9 +310,26 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.txpool.Start()
 
+	// sync the pool with the head before replaying the journal,
+	// so the already mined transactions are dropped
+	m.txpool.ResetWithHeaders(m.blockchain.Header())
+
+	if err := m.txpool.LoadJournal(); err != nil {
+		m.logger.Error("failed to load the txpool journal", "err", err)
+	}
+
 	return m, nil
 }
 
+// journalPath returns the txpool journal file, or an empty path if the journal is disabled
+func (s *Server) journalPath() string {
+	if !s.config.Journal {
+		return ""
+	}
+
+	return filepath.Join(s.config.DataDir, txPoolJournalFile)
+}
+
 func (s *Server) restoreChain() error {
 	if s.config.RestoreFile == nil {
 		return nil
@@ -328,6 +376,20 @@ func (t *txpoolHub) GetNonce(root types.Hash, addr types.Address) uint64 {
 	return account.Nonce
 }
 
+func (t *txpoolHub) GetStorage(root types.Hash, addr types.Address, slot types.Hash) types.Hash {
+	account, err := getAccountImpl(t.state, root, addr)
+	if err != nil {
+		return types.ZeroHash
+	}
+
+	snap, err := t.state.NewSnapshotAt(root)
+	if err != nil {
+		return types.ZeroHash
+	}
+
+	return snap.GetStorage(addr, account.Root, slot)
+}
+
 func (t *txpoolHub) GetBalance(root types.Hash, addr types.Address) (*big.Int, error) {
 	account, err := getAccountImpl(t.state, root, addr)
 
@@ -445,6 +507,37 @@ func (j *jsonRPCHub) GetPeers() int {
 	return len(j.Server.Peers())
 }
 
+func (j *jsonRPCHub) GetTxStatus(txHash types.Hash) *jsonrpc.TxStatus {
+	status := j.TxPool.GetTxStatus(txHash)
+
+	res := &jsonrpc.TxStatus{
+		State:            status.State,
+		MissingNonces:    status.MissingNonces,
+		RequiredGasPrice: status.RequiredGasPrice,
+		History:          make([]*jsonrpc.TxEvent, len(status.History)),
+	}
+
+	// only known while the transaction is in the pool
+	if status.GasPrice != nil {
+		from := status.From
+
+		res.From = &from
+		res.Nonce = status.Nonce
+		res.NextNonce = status.NextNonce
+		res.GasPrice = status.GasPrice
+	}
+
+	for idx, event := range status.History {
+		res.History[idx] = &jsonrpc.TxEvent{
+			Type:   event.Type,
+			Reason: event.Reason,
+			Time:   event.Time,
+		}
+	}
+
+	return res
+}
+
 func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*jsonrpc.Account, error) {
 	acct, err := getAccountImpl(j.state, root, addr)
 	if err != nil {
@@ -670,6 +763,36 @@ func (s *Server) setupJSONRPC() error {
 		PriceLimit:               s.config.PriceLimit,
 		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
+		IPCPath:                  s.config.JSONRPC.IPCPath,
+		DisabledNamespaces:       s.config.JSONRPC.DisabledNamespaces,
+		RateLimit:                s.config.JSONRPC.RateLimit,
+	}
+
+	if s.ipcMux != nil {
+		conf.IPCListener = s.ipcMux.Other()
+	}
+
+	if err := s.setupJSONRPCAuth(conf); err != nil {
+		return err
+	}
+
+	if s.config.Accounts != nil && s.config.Accounts.Enabled {
+		keystoreDir := s.config.Accounts.KeystoreDir
+		if keystoreDir == "" {
+			keystoreDir = filepath.Join(s.config.DataDir, "keystore")
+		}
+
+		manager, err := accounts.NewManager(
+			s.logger,
+			keystoreDir,
+			crypto.NewEIP155Signer(uint64(s.config.Chain.Params.ChainID)),
+		)
+		if err != nil {
+			return err
+		}
+
+		s.accountManager = manager
+		conf.Accounts = manager
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -682,7 +805,73 @@ func (s *Server) setupJSONRPC() error {
 	return nil
 }
 
-// setupGRPC sets up the grpc server and listens on tcp
+// setupJSONRPCAuth sets the authentication configs of the JSON-RPC transports,
+// reading the JWT secret from the secrets manager if the tokens are accepted
+func (s *Server) setupJSONRPCAuth(conf *jsonrpc.Config) error {
+	httpAuth, wsAuth := s.config.JSONRPC.HTTPAuth, s.config.JSONRPC.WSAuth
+
+	var jwtSecret []byte
+
+	if httpAuth.acceptsJWT() || wsAuth.acceptsJWT() {
+		secret, err := s.loadJSONRPCJWTSecret()
+		if err != nil {
+			return err
+		}
+
+		jwtSecret = secret
+	}
+
+	toAuthConfig := func(auth *JSONRPCAuth) *jsonrpc.AuthConfig {
+		if auth == nil {
+			return nil
+		}
+
+		return &jsonrpc.AuthConfig{
+			JWTSecret:   jwtSecret,
+			JWTSubjects: auth.JWTSubjects,
+			APIKeys:     auth.APIKeys,
+			Public:      auth.Public,
+		}
+	}
+
+	conf.HTTPAuth = toAuthConfig(httpAuth)
+	conf.WSAuth = toAuthConfig(wsAuth)
+
+	return nil
+}
+
+// loadJSONRPCJWTSecret reads the JWT secret from the secrets manager, generating it if not present
+func (s *Server) loadJSONRPCJWTSecret() ([]byte, error) {
+	if s.secretsManager.HasSecret(secrets.JSONRPCJWTSecret) {
+		encoded, err := s.secretsManager.GetSecret(secrets.JSONRPCJWTSecret)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read JSON-RPC JWT secret from Secrets Manager, %w", err)
+		}
+
+		secret, err := hex.DecodeHex(strings.TrimSpace(string(encoded)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON-RPC JWT secret, %w", err)
+		}
+
+		return secret, nil
+	}
+
+	secret := make([]byte, jwtSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("unable to generate JSON-RPC JWT secret, %w", err)
+	}
+
+	if err := s.secretsManager.SetSecret(secrets.JSONRPCJWTSecret, []byte(hex.EncodeToHex(secret))); err != nil {
+		return nil, fmt.Errorf("unable to store JSON-RPC JWT secret to Secrets Manager, %w", err)
+	}
+
+	s.logger.Info("generated JSON-RPC JWT secret")
+
+	return secret, nil
+}
+
+// setupGRPC sets up the grpc server and listens on tcp,
+// and on the IPC endpoint if it's enabled
 func (s *Server) setupGRPC() error {
 	proto.RegisterSystemServer(s.grpcServer, &systemService{server: s})
 
@@ -699,6 +888,39 @@ func (s *Server) setupGRPC() error {
 
 	s.logger.Info("GRPC server running", "addr", s.config.GRPCAddr.String())
 
+	if s.config.JSONRPC.IPCPath != "" {
+		if err := s.setupIPCMux(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setupIPCMux opens the IPC endpoint, the grpc connections are served by the grpc server
+// and the others are handed to the jsonrpc server
+func (s *Server) setupIPCMux() error {
+	lis, err := ipc.Listen(s.config.JSONRPC.IPCPath)
+	if err != nil {
+		return err
+	}
+
+	s.ipcMux = ipc.NewMux(lis)
+
+	go func() {
+		if err := s.ipcMux.Serve(); err != nil && !errors.Is(err, net.ErrClosed) {
+			s.logger.Error("closed IPC listener", "err", err)
+		}
+	}()
+
+	go func() {
+		if err := s.grpcServer.Serve(s.ipcMux.GRPC()); err != nil {
+			s.logger.Error(err.Error())
+		}
+	}()
+
+	s.logger.Info("GRPC server running", "ipc", s.config.JSONRPC.IPCPath)
+
 	return nil
 }
 
@@ -743,6 +965,18 @@ func (s *Server) Close() {
 	// close the txpool's main loop
 	s.txpool.Close()
 
+	// close the IPC endpoint
+	if s.ipcMux != nil {
+		if err := s.ipcMux.Close(); err != nil {
+			s.logger.Error("failed to close IPC endpoint", "err", err.Error())
+		}
+	}
+
+	// forget the decrypted keys of the unlocked accounts
+	if s.accountManager != nil {
+		s.accountManager.Close()
+	}
+
 	// close DataDog profiler
 	s.closeDataDogProfiler()
 }
@@ -5,8 +5,10 @@ import (
 	"os"
 	"time"
 
+	"github.com/SECRYPT-2022/SECRYPT/jsonrpc"
 	"github.com/armon/go-metrics"
 	"github.com/armon/go-metrics/prometheus"
+	promclient "github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 	"gopkg.in/DataDog/dd-trace-go.v1/profiler"
 )
@@ -26,6 +28,11 @@ func (s *Server) setupTelemetry() error {
 		inm, promSink,
 	})
 
+	// the histograms are registered directly, go-metrics only exporting summaries
+	if err := jsonrpc.RegisterMetrics(promclient.DefaultRegisterer); err != nil {
+		return fmt.Errorf("unable to register the json-rpc metrics, %w", err)
+	}
+
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/SECRYPT-2022/SECRYPT/chain"
 	"github.com/SECRYPT-2022/SECRYPT/crypto"
 	"github.com/SECRYPT-2022/SECRYPT/state/runtime"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/allowlist"
 	"github.com/SECRYPT-2022/SECRYPT/state/runtime/evm"
 	"github.com/SECRYPT-2022/SECRYPT/state/runtime/precompiled"
 	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer"
@@ -38,14 +39,26 @@ type Executor struct {
 	GetHash GetHashByNumberHelper
 
 	PostHook func(txn *Transition)
+
+	// contract-managed allow lists
+	deploymentAllowList  *allowlist.AllowList
+	transactionAllowList *allowlist.AllowList
 }
 
 // NewExecutor creates a new executor
 func NewExecutor(config *chain.Params, s State, logger hclog.Logger) *Executor {
+	// the genesis deployment whitelist is the bootstrap admin set of the allow lists
+	var bootstrapAdmins []types.Address
+	if config.Whitelists != nil {
+		bootstrapAdmins = config.Whitelists.Deployment
+	}
+
 	return &Executor{
-		logger: logger,
-		config: config,
-		state:  s,
+		logger:               logger,
+		config:               config,
+		state:                s,
+		deploymentAllowList:  allowlist.NewAllowList(allowlist.DeploymentAllowListAddr, bootstrapAdmins),
+		transactionAllowList: allowlist.NewAllowList(allowlist.TransactionAllowListAddr, bootstrapAdmins),
 	}
 }
 
@@ -167,6 +180,23 @@ func (e *Executor) BeginTxn(
 		PostHook:    e.PostHook,
 	}
 
+	if e.config.AllowLists.IsDeploymentActive(header.Number) {
+		txn.deploymentAllowList = e.deploymentAllowList
+	}
+
+	if e.config.AllowLists.IsTransactionActive(header.Number) {
+		txn.transactionAllowList = e.transactionAllowList
+	}
+
+	// make sure the accounts of the enforced allow lists exist,
+	// so their storage isn't pruned as part of an empty account
+	for _, list := range txn.allowLists() {
+		if !newTxn.Exist(list.Address()) {
+			newTxn.SetCode(list.Address(), allowlist.SystemContractCode)
+			newTxn.SetNonce(list.Address(), 1)
+		}
+	}
+
 	return txn, nil
 }
 
@@ -187,11 +217,18 @@ type Transition struct {
 	receipts []*types.Receipt
 	totalGas uint64
 
+	// snapshots of the results, to roll back written transactions
+	snapshots []transitionSnapshot
+
 	PostHook func(t *Transition)
 
 	// runtimes
 	evm         *evm.EVM
 	precompiles *precompiled.Precompiled
+
+	// contract-managed allow lists, nil if not enforced at this block
+	deploymentAllowList  *allowlist.AllowList
+	transactionAllowList *allowlist.AllowList
 }
 
 func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transition {
@@ -212,6 +249,38 @@ func (t *Transition) Receipts() []*types.Receipt {
 	return t.receipts
 }
 
+// transitionSnapshot is the state of the transition at some point in time
+type transitionSnapshot struct {
+	state    int
+	receipts int
+	totalGas uint64
+	gasPool  uint64
+}
+
+// Snapshot takes a snapshot of the state and the results written so far
+func (t *Transition) Snapshot() int {
+	id := len(t.snapshots)
+	t.snapshots = append(t.snapshots, transitionSnapshot{
+		state:    t.state.Snapshot(),
+		receipts: len(t.receipts),
+		totalGas: t.totalGas,
+		gasPool:  t.gasPool,
+	})
+
+	return id
+}
+
+// RevertToSnapshot rolls back the transactions written after the given snapshot
+func (t *Transition) RevertToSnapshot(id int) {
+	snapshot := t.snapshots[id]
+
+	t.state.RevertToSnapshot(snapshot.state)
+	t.receipts = t.receipts[:snapshot.receipts]
+	t.totalGas = snapshot.totalGas
+	t.gasPool = snapshot.gasPool
+	t.snapshots = t.snapshots[:id]
+}
+
 var emptyFrom = types.Address{}
 
 func (t *Transition) WriteFailedReceipt(txn *types.Transaction) error {
@@ -257,6 +326,17 @@ func (t *Transition) Write(txn *types.Transaction) error {
 		}
 	}
 
+	// Make sure the fee payer agreed to pay for the transaction
+	if txn.IsFeeDelegated() {
+		if err := t.txTypeCheck(txn); err != nil {
+			return NewTransitionApplicationError(err, false)
+		}
+
+		if _, err := signer.FeePayer(txn); err != nil {
+			return NewTransitionApplicationError(err, false)
+		}
+	}
+
 	// Make a local copy and apply the transaction
 	msg := txn.Copy()
 
@@ -327,11 +407,20 @@ func (t *Transition) Txn() *Txn {
 
 // Apply applies a new transaction
 func (t *Transition) Apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
+	var preState *Txn
+
+	stateDiffTracer, captureStateDiff := t.ctx.Tracer.(tracer.StateDiffTracer)
+	if captureStateDiff {
+		preState = t.state.Copy()
+	}
+
 	s := t.state.Snapshot()
 
 	result, err := t.apply(msg)
 	if err != nil {
 		t.state.RevertToSnapshot(s)
+	} else if captureStateDiff {
+		stateDiffTracer.CaptureStateDiff(t.state.Diff(preState))
 	}
 
 	if t.PostHook != nil {
@@ -348,11 +437,12 @@ func (t *Transition) ContextPtr() *runtime.TxContext {
 }
 
 func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
-	// deduct the upfront max gas cost
+	// deduct the upfront max gas cost from the payer,
+	// which is the fee payer for fee delegated transactions
 	upfrontGasCost := new(big.Int).Set(msg.GasPrice)
 	upfrontGasCost.Mul(upfrontGasCost, new(big.Int).SetUint64(msg.Gas))
 
-	if err := t.state.SubBalance(msg.From, upfrontGasCost); err != nil {
+	if err := t.state.SubBalance(msg.Payer(), upfrontGasCost); err != nil {
 		if errors.Is(err, runtime.ErrNotEnoughFunds) {
 			return ErrNotEnoughFundsForGas
 		}
@@ -363,6 +453,69 @@ func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 	return nil
 }
 
+// allowLists returns the allow lists enforced at this block
+func (t *Transition) allowLists() []*allowlist.AllowList {
+	lists := make([]*allowlist.AllowList, 0, 2)
+
+	if t.deploymentAllowList != nil {
+		lists = append(lists, t.deploymentAllowList)
+	}
+
+	if t.transactionAllowList != nil {
+		lists = append(lists, t.transactionAllowList)
+	}
+
+	return lists
+}
+
+// parentStorageReader reads the storage of the state the block is built upon.
+// Allow list checks are done against it, so role changes take effect from the next block
+type parentStorageReader struct {
+	snap readSnapshot
+}
+
+func (r *parentStorageReader) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	account, err := r.snap.GetAccount(addr)
+	if err != nil || account == nil {
+		return types.Hash{}
+	}
+
+	return r.snap.GetStorage(addr, account.Root, key)
+}
+
+// canDeploy checks if the address is allowed to deploy contracts
+func (t *Transition) canDeploy(addr types.Address) bool {
+	if t.deploymentAllowList == nil {
+		return true
+	}
+
+	return t.deploymentAllowList.GetRole(&parentStorageReader{t.snap}, addr).Enabled()
+}
+
+// allowListCheck checks if the sender of the message
+// is allowed to send it by the enforced allow lists
+func (t *Transition) allowListCheck(msg *types.Transaction) error {
+	if t.transactionAllowList != nil &&
+		!t.transactionAllowList.GetRole(&parentStorageReader{t.snap}, msg.From).Enabled() {
+		return ErrSenderNotAllowed
+	}
+
+	if msg.IsContractCreation() && !t.canDeploy(msg.From) {
+		return ErrDeploymentNotAllowed
+	}
+
+	return nil
+}
+
+// txTypeCheck checks if the type of the message is enabled at this block
+func (t *Transition) txTypeCheck(msg *types.Transaction) error {
+	if msg.IsFeeDelegated() && !t.config.FeeDelegation {
+		return ErrTxTypeNotSupported
+	}
+
+	return nil
+}
+
 func (t *Transition) nonceCheck(msg *types.Transaction) error {
 	nonce := t.state.GetNonce(msg.From)
 
@@ -383,6 +536,9 @@ var (
 	ErrIntrinsicGasOverflow  = fmt.Errorf("overflow in intrinsic gas calculation")
 	ErrNotEnoughIntrinsicGas = fmt.Errorf("not enough gas supplied for intrinsic gas costs")
 	ErrNotEnoughFunds        = fmt.Errorf("not enough funds for transfer with given value")
+	ErrSenderNotAllowed      = fmt.Errorf("sender is not allowed to send transactions")
+	ErrDeploymentNotAllowed  = fmt.Errorf("sender is not allowed to deploy contracts")
+	ErrTxTypeNotSupported    = fmt.Errorf("transaction type not supported")
 )
 
 type TransitionApplicationError struct {
@@ -416,19 +572,31 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	// applying the message. The rules include these clauses
 	//
 	// 1. the nonce of the message caller is correct
-	// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
+	// 2. payer has enough balance to cover transaction fee(gaslimit * gasprice)
 	// 3. the amount of gas required is available in the block
 	// 4. there is no overflow when calculating intrinsic gas
 	// 5. the purchased gas is enough to cover intrinsic usage
 	// 6. caller has enough balance to cover asset transfer for **topmost** call
+	// 7. caller is allowed to send the message by the enforced allow lists
+	// 8. the transaction type is enabled at this block
 	txn := t.state
 
+	// 8. the transaction type is enabled at this block
+	if err := t.txTypeCheck(msg); err != nil {
+		return nil, NewTransitionApplicationError(err, false)
+	}
+
 	// 1. the nonce of the message caller is correct
 	if err := t.nonceCheck(msg); err != nil {
 		return nil, NewTransitionApplicationError(err, true)
 	}
 
-	// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
+	// 7. caller is allowed to send the message by the enforced allow lists
+	if err := t.allowListCheck(msg); err != nil {
+		return nil, NewTransitionApplicationError(err, false)
+	}
+
+	// 2. payer has enough balance to cover transaction fee(gaslimit * gasprice)
 	if err := t.subGasLimitPrice(msg); err != nil {
 		return nil, NewTransitionApplicationError(err, true)
 	}
@@ -482,9 +650,9 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		t.ctx.Tracer.TxEnd(result.GasLeft)
 	}
 
-	// refund the sender
+	// refund the payer
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), gasPrice)
-	txn.AddBalance(msg.From, remaining)
+	txn.AddBalance(msg.Payer(), remaining)
 
 	// pay the coinbase
 	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), gasPrice)
@@ -521,6 +689,13 @@ func (t *Transition) Call2(
 }
 
 func (t *Transition) run(contract *runtime.Contract, host runtime.Host) *runtime.ExecutionResult {
+	// check the allow list system contracts
+	for _, list := range t.allowLists() {
+		if list.CanRun(contract, host, &t.config) {
+			return list.Run(contract, host, &t.config)
+		}
+	}
+
 	// check the precompiles
 	if t.precompiles.CanRun(contract, host, &t.config) {
 		return t.precompiles.Run(contract, host, &t.config)
@@ -619,6 +794,14 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		}
 	}
 
+	// Contracts deploying other contracts are restricted by the transaction origin
+	if !t.canDeploy(t.ctx.Origin) {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     ErrDeploymentNotAllowed,
+		}
+	}
+
 	// Increment the nonce of the caller
 	t.state.IncrNonce(c.Caller)
 
@@ -876,9 +1059,15 @@ func (t *Transition) captureCallEnd(c *runtime.Contract, result *runtime.Executi
 		return
 	}
 
+	var gasUsed uint64
+	if c.Gas > result.GasLeft {
+		gasUsed = c.Gas - result.GasLeft
+	}
+
 	t.ctx.Tracer.CallEnd(
 		c.Depth,
 		result.ReturnValue,
+		gasUsed,
 		result.Err,
 	)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/SECRYPT-2022/SECRYPT/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/umbracle/fastrlp"
 )
 
@@ -94,6 +95,16 @@ func NewLevelDBStorage(path string, logger hclog.Logger) (Storage, error) {
 	return &KVStorage{db}, nil
 }
 
+// NewReadOnlyLevelDBStorage opens the leveldb trie storage in read-only mode, its writes being dropped
+func NewReadOnlyLevelDBStorage(path string, logger hclog.Logger) (Storage, error) {
+	db, err := leveldb.OpenFile(path, &opt.Options{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &KVStorage{db}, nil
+}
+
 type memStorage struct {
 	db   map[string][]byte
 	code map[string][]byte
@@ -0,0 +1,277 @@
+package allowlist
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/SECRYPT-2022/SECRYPT/chain"
+	"github.com/SECRYPT-2022/SECRYPT/crypto"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+)
+
+var _ runtime.Runtime = &AllowList{}
+
+// Role is the permission level an address has in an allow list
+type Role uint64
+
+const (
+	// NoRole addresses are not allowed to perform the restricted action
+	NoRole Role = iota
+	// EnabledRole addresses are allowed to perform the restricted action
+	EnabledRole
+	// ManagerRole addresses can perform the restricted action,
+	// and can enable or disable addresses that aren't managers or admins
+	ManagerRole
+	// AdminRole addresses can perform the restricted action,
+	// and can assign any role to any address
+	AdminRole
+)
+
+// Enabled returns true if the role allows performing the restricted action
+func (r Role) Enabled() bool {
+	return r != NoRole
+}
+
+func (r Role) String() string {
+	switch r {
+	case NoRole:
+		return "none"
+	case EnabledRole:
+		return "enabled"
+	case ManagerRole:
+		return "manager"
+	case AdminRole:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
+
+var (
+	// DeploymentAllowListAddr is the address of the system contract
+	// managing which accounts can deploy smart contracts
+	DeploymentAllowListAddr = types.StringToAddress("0x0200000000000000000000000000000000000000")
+
+	// TransactionAllowListAddr is the address of the system contract
+	// managing which accounts can send transactions
+	TransactionAllowListAddr = types.StringToAddress("0x0200000000000000000000000000000000000002")
+
+	// SystemContractCode is the placeholder code set on the allow list accounts,
+	// so they are not treated as empty accounts and pruned from the state
+	SystemContractCode = []byte{0x01}
+)
+
+const (
+	readAllowListGas   uint64 = 2600
+	modifyAllowListGas uint64 = 20000
+)
+
+var (
+	readAllowListMethod = methodID("readAllowList(address)")
+	setAdminMethod      = methodID("setAdmin(address)")
+	setManagerMethod    = methodID("setManager(address)")
+	setEnabledMethod    = methodID("setEnabled(address)")
+	setNoneMethod       = methodID("setNone(address)")
+
+	// RoleSetEvent is the topic of the event emitted on each role change:
+	// RoleSet(uint256 indexed role, address indexed account, address indexed sender)
+	RoleSetEvent = types.BytesToHash(crypto.Keccak256([]byte("RoleSet(uint256,address,address)")))
+)
+
+var (
+	ErrInvalidInput     = errors.New("invalid allow list input")
+	ErrUnknownMethod    = errors.New("unknown allow list method")
+	ErrNotAuthorized    = errors.New("caller is not authorized to modify the allow list")
+	ErrWriteProtection  = errors.New("write protection")
+	ErrValueTransfer    = errors.New("allow list does not accept value transfers")
+	ErrMissingBootstrap = errors.New("allow list is enabled, but no bootstrap admin is set")
+)
+
+// StateReader provides read access to the storage of the allow list contract
+type StateReader interface {
+	GetStorage(addr types.Address, key types.Hash) types.Hash
+}
+
+// AllowList is a stateful system contract which keeps the role of each account in its storage.
+//
+// Each role is stored in the slot keyed by the (left padded) account address,
+// offset by one so that an explicit NoRole can be told apart from an unset slot.
+// Accounts with an unset slot fall back to the bootstrap admin set, which
+// is the deployment whitelist from the genesis configuration
+type AllowList struct {
+	addr      types.Address
+	bootstrap map[types.Address]struct{}
+}
+
+// NewAllowList creates the allow list contract living at the given address
+func NewAllowList(addr types.Address, bootstrapAdmins []types.Address) *AllowList {
+	bootstrap := make(map[types.Address]struct{}, len(bootstrapAdmins))
+	for _, admin := range bootstrapAdmins {
+		bootstrap[admin] = struct{}{}
+	}
+
+	return &AllowList{
+		addr:      addr,
+		bootstrap: bootstrap,
+	}
+}
+
+// Address returns the address of the allow list contract
+func (a *AllowList) Address() types.Address {
+	return a.addr
+}
+
+// GetRole returns the role of the account, as found in the given state
+func (a *AllowList) GetRole(state StateReader, account types.Address) Role {
+	stored := state.GetStorage(a.addr, roleKey(account))
+	if stored == types.ZeroHash {
+		if _, ok := a.bootstrap[account]; ok {
+			return AdminRole
+		}
+
+		return NoRole
+	}
+
+	return Role(new(big.Int).SetBytes(stored.Bytes()).Uint64() - 1)
+}
+
+// setRole stores the role of the account
+func (a *AllowList) setRole(host runtime.Host, account types.Address, role Role, config *chain.ForksInTime) {
+	host.SetStorage(
+		a.addr,
+		roleKey(account),
+		encodeRole(role+1),
+		config,
+	)
+}
+
+// CanRun implements the runtime interface
+func (a *AllowList) CanRun(c *runtime.Contract, _ runtime.Host, _ *chain.ForksInTime) bool {
+	return c.CodeAddress == a.addr
+}
+
+// Name implements the runtime interface
+func (a *AllowList) Name() string {
+	return "allowlist"
+}
+
+// Run implements the runtime interface
+func (a *AllowList) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) *runtime.ExecutionResult {
+	if len(c.Input) != 4+32 {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     ErrInvalidInput,
+		}
+	}
+
+	method, account := string(c.Input[:4]), types.BytesToAddress(c.Input[4:])
+
+	gasCost := modifyAllowListGas
+	if method == readAllowListMethod {
+		gasCost = readAllowListGas
+	}
+
+	// In the case of not enough gas for the execution we return ErrOutOfGas
+	if c.Gas < gasCost {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrOutOfGas,
+		}
+	}
+
+	returnValue, err := a.run(method, account, c, host, config)
+	if err != nil {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     err,
+		}
+	}
+
+	return &runtime.ExecutionResult{
+		ReturnValue: returnValue,
+		GasLeft:     c.Gas - gasCost,
+	}
+}
+
+func (a *AllowList) run(
+	method string,
+	account types.Address,
+	c *runtime.Contract,
+	host runtime.Host,
+	config *chain.ForksInTime,
+) ([]byte, error) {
+	var newRole Role
+
+	switch method {
+	case readAllowListMethod:
+		return encodeRole(a.GetRole(host, account)).Bytes(), nil
+	case setAdminMethod:
+		newRole = AdminRole
+	case setManagerMethod:
+		newRole = ManagerRole
+	case setEnabledMethod:
+		newRole = EnabledRole
+	case setNoneMethod:
+		newRole = NoRole
+	default:
+		return nil, ErrUnknownMethod
+	}
+
+	if c.Static {
+		return nil, ErrWriteProtection
+	}
+
+	if c.Value != nil && c.Value.Sign() != 0 {
+		return nil, ErrValueTransfer
+	}
+
+	if !canModify(a.GetRole(host, c.Caller), a.GetRole(host, account), newRole) {
+		return nil, ErrNotAuthorized
+	}
+
+	a.setRole(host, account, newRole, config)
+
+	host.EmitLog(
+		a.addr,
+		[]types.Hash{
+			RoleSetEvent,
+			encodeRole(newRole),
+			types.BytesToHash(account.Bytes()),
+			types.BytesToHash(c.Caller.Bytes()),
+		},
+		nil,
+	)
+
+	return nil, nil
+}
+
+// canModify checks if an account with the caller role is allowed to
+// change the role of an account from the current role to the new one.
+// Admins can assign any role, while managers can only toggle
+// accounts that are neither managers nor admins between enabled and none
+func canModify(caller, current, newRole Role) bool {
+	switch caller {
+	case AdminRole:
+		return true
+	case ManagerRole:
+		return current <= EnabledRole && newRole <= EnabledRole
+	default:
+		return false
+	}
+}
+
+// roleKey returns the storage slot in which the role of the account is kept
+func roleKey(account types.Address) types.Hash {
+	return types.BytesToHash(account.Bytes())
+}
+
+// encodeRole returns the role as an ABI encoded uint256
+func encodeRole(role Role) types.Hash {
+	return types.BytesToHash(new(big.Int).SetUint64(uint64(role)).Bytes())
+}
+
+// methodID returns the ABI selector of the method with the given signature
+func methodID(signature string) string {
+	return string(crypto.Keccak256([]byte(signature))[:4])
+}
@@ -0,0 +1,171 @@
+package allowlist
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/SECRYPT-2022/SECRYPT/chain"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addrAdmin   = types.StringToAddress("1")
+	addrManager = types.StringToAddress("2")
+	addrEnabled = types.StringToAddress("3")
+	addrOther   = types.StringToAddress("4")
+)
+
+type mockHost struct {
+	runtime.Host
+
+	storage map[types.Address]map[types.Hash]types.Hash
+	logs    [][]types.Hash
+}
+
+func newMockHost() *mockHost {
+	return &mockHost{
+		storage: map[types.Address]map[types.Hash]types.Hash{},
+	}
+}
+
+func (m *mockHost) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.storage[addr][key]
+}
+
+func (m *mockHost) SetStorage(
+	addr types.Address,
+	key types.Hash,
+	value types.Hash,
+	_ *chain.ForksInTime,
+) runtime.StorageStatus {
+	if _, ok := m.storage[addr]; !ok {
+		m.storage[addr] = map[types.Hash]types.Hash{}
+	}
+
+	m.storage[addr][key] = value
+
+	return runtime.StorageModified
+}
+
+func (m *mockHost) EmitLog(_ types.Address, topics []types.Hash, _ []byte) {
+	m.logs = append(m.logs, topics)
+}
+
+func input(method string, account types.Address) []byte {
+	return append([]byte(method), types.BytesToHash(account.Bytes()).Bytes()...)
+}
+
+func call(list *AllowList, host runtime.Host, caller types.Address, in []byte) *runtime.ExecutionResult {
+	contract := runtime.NewContractCall(1, caller, caller, list.Address(), big.NewInt(0), 100000, nil, in)
+
+	return list.Run(contract, host, &chain.ForksInTime{})
+}
+
+func TestAllowList_BootstrapAdmins(t *testing.T) {
+	t.Parallel()
+
+	list := NewAllowList(DeploymentAllowListAddr, []types.Address{addrAdmin})
+	host := newMockHost()
+
+	assert.Equal(t, AdminRole, list.GetRole(host, addrAdmin))
+	assert.Equal(t, NoRole, list.GetRole(host, addrOther))
+
+	// an explicitly removed bootstrap admin loses its role
+	assert.NoError(t, call(list, host, addrAdmin, input(setNoneMethod, addrAdmin)).Err)
+	assert.Equal(t, NoRole, list.GetRole(host, addrAdmin))
+}
+
+func TestAllowList_SetRoles(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name   string
+		caller types.Address
+		method string
+		target types.Address
+		err    error
+		role   Role
+	}{
+		{"admin sets admin", addrAdmin, setAdminMethod, addrOther, nil, AdminRole},
+		{"admin sets manager", addrAdmin, setManagerMethod, addrOther, nil, ManagerRole},
+		{"admin removes manager", addrAdmin, setNoneMethod, addrManager, nil, NoRole},
+		{"manager enables", addrManager, setEnabledMethod, addrOther, nil, EnabledRole},
+		{"manager disables", addrManager, setNoneMethod, addrEnabled, nil, NoRole},
+		{"manager can't set admin", addrManager, setAdminMethod, addrOther, ErrNotAuthorized, NoRole},
+		{"manager can't remove admin", addrManager, setNoneMethod, addrAdmin, ErrNotAuthorized, AdminRole},
+		{"enabled can't modify", addrEnabled, setEnabledMethod, addrOther, ErrNotAuthorized, NoRole},
+		{"none can't modify", addrOther, setEnabledMethod, addrOther, ErrNotAuthorized, NoRole},
+		{"unknown method", addrAdmin, methodID("foo(address)"), addrOther, ErrUnknownMethod, NoRole},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			list := NewAllowList(DeploymentAllowListAddr, []types.Address{addrAdmin})
+			host := newMockHost()
+
+			list.setRole(host, addrManager, ManagerRole, &chain.ForksInTime{})
+			list.setRole(host, addrEnabled, EnabledRole, &chain.ForksInTime{})
+
+			res := call(list, host, tt.caller, input(tt.method, tt.target))
+
+			assert.ErrorIs(t, res.Err, tt.err)
+			assert.Equal(t, tt.role, list.GetRole(host, tt.target))
+
+			if tt.err == nil {
+				assert.Equal(t, uint64(100000)-modifyAllowListGas, res.GasLeft)
+				assert.Len(t, host.logs, 1)
+				assert.Equal(t, RoleSetEvent, host.logs[0][0])
+			} else {
+				assert.Equal(t, uint64(0), res.GasLeft)
+				assert.Len(t, host.logs, 0)
+			}
+		})
+	}
+}
+
+func TestAllowList_ReadAllowList(t *testing.T) {
+	t.Parallel()
+
+	list := NewAllowList(TransactionAllowListAddr, []types.Address{addrAdmin})
+	host := newMockHost()
+
+	list.setRole(host, addrManager, ManagerRole, &chain.ForksInTime{})
+
+	res := call(list, host, addrOther, input(readAllowListMethod, addrManager))
+	assert.NoError(t, res.Err)
+	assert.Equal(t, encodeRole(ManagerRole).Bytes(), res.ReturnValue)
+	assert.Equal(t, uint64(100000)-readAllowListGas, res.GasLeft)
+}
+
+func TestAllowList_InvalidCalls(t *testing.T) {
+	t.Parallel()
+
+	list := NewAllowList(DeploymentAllowListAddr, []types.Address{addrAdmin})
+
+	// malformed input
+	res := call(list, newMockHost(), addrAdmin, []byte{0x1, 0x2})
+	assert.ErrorIs(t, res.Err, ErrInvalidInput)
+
+	// static calls can't modify the list
+	contract := runtime.NewContractCall(
+		1, addrAdmin, addrAdmin, list.Address(), big.NewInt(0), 100000, nil, input(setEnabledMethod, addrOther),
+	)
+	contract.Static = true
+
+	res = list.Run(contract, newMockHost(), &chain.ForksInTime{})
+	assert.ErrorIs(t, res.Err, ErrWriteProtection)
+
+	// not enough gas
+	contract = runtime.NewContractCall(
+		1, addrAdmin, addrAdmin, list.Address(), big.NewInt(0), 100, nil, input(setEnabledMethod, addrOther),
+	)
+
+	res = list.Run(contract, newMockHost(), &chain.ForksInTime{})
+	assert.ErrorIs(t, res.Err, runtime.ErrOutOfGas)
+}
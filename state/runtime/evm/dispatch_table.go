@@ -131,3 +131,10 @@ func init() {
 	register(JUMPI, handler{opJumpi, 2, 10})
 	register(JUMPDEST, handler{opJumpDest, 0, 1})
 }
+
+// StackInputs returns the number of stack items the instruction pops.
+// DUP and SWAP instructions check their stack requirements on their own
+// and return 0
+func StackInputs(op OpCode) int {
+	return dispatchTable[op].stack
+}
@@ -0,0 +1,106 @@
+package paritytracer
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/SECRYPT-2022/SECRYPT/helper/hex"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+)
+
+const unchanged = "="
+
+// AccountStateDiff is the change of an account in OpenEthereum format,
+// each field is either "=" (unchanged), {"+": value} (created),
+// {"-": value} (deleted) or {"*": {"from": value, "to": value}} (modified)
+type AccountStateDiff struct {
+	Balance interface{}                `json:"balance"`
+	Code    interface{}                `json:"code"`
+	Nonce   interface{}                `json:"nonce"`
+	Storage map[types.Hash]interface{} `json:"storage"`
+}
+
+type fromTo struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// formatStateDiff converts the account diffs into OpenEthereum format
+func formatStateDiff(diff map[types.Address]*tracer.AccountDiff) map[types.Address]*AccountStateDiff {
+	res := make(map[types.Address]*AccountStateDiff, len(diff))
+
+	for addr, accountDiff := range diff {
+		res[addr] = formatAccountDiff(accountDiff)
+	}
+
+	return res
+}
+
+func formatAccountDiff(diff *tracer.AccountDiff) *AccountStateDiff {
+	pre, post := diff.Pre, diff.Post
+
+	res := &AccountStateDiff{
+		Storage: map[types.Hash]interface{}{},
+	}
+
+	switch {
+	case pre == nil:
+		res.Balance = created(hex.EncodeBig(balance(post)))
+		res.Code = created(hex.EncodeToHex(post.Code))
+		res.Nonce = created(hex.EncodeUint64(post.Nonce))
+
+		for key, val := range post.Storage {
+			res.Storage[key] = created(val.String())
+		}
+	case post == nil:
+		res.Balance = deleted(hex.EncodeBig(balance(pre)))
+		res.Code = deleted(hex.EncodeToHex(pre.Code))
+		res.Nonce = deleted(hex.EncodeUint64(pre.Nonce))
+
+		for key, val := range pre.Storage {
+			res.Storage[key] = deleted(val.String())
+		}
+	default:
+		res.Balance = unchanged
+		if balance(pre).Cmp(balance(post)) != 0 {
+			res.Balance = modified(hex.EncodeBig(balance(pre)), hex.EncodeBig(balance(post)))
+		}
+
+		res.Code = unchanged
+		if !bytes.Equal(pre.Code, post.Code) {
+			res.Code = modified(hex.EncodeToHex(pre.Code), hex.EncodeToHex(post.Code))
+		}
+
+		res.Nonce = unchanged
+		if pre.Nonce != post.Nonce {
+			res.Nonce = modified(hex.EncodeUint64(pre.Nonce), hex.EncodeUint64(post.Nonce))
+		}
+
+		for key, val := range post.Storage {
+			res.Storage[key] = modified(pre.Storage[key].String(), val.String())
+		}
+	}
+
+	return res
+}
+
+func balance(account *tracer.AccountState) *big.Int {
+	if account.Balance == nil {
+		return big.NewInt(0)
+	}
+
+	return account.Balance
+}
+
+func created(val string) map[string]string {
+	return map[string]string{"+": val}
+}
+
+func deleted(val string) map[string]string {
+	return map[string]string{"-": val}
+}
+
+func modified(from, to string) map[string]fromTo {
+	return map[string]fromTo{"*": {From: from, To: to}}
+}
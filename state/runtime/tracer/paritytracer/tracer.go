@@ -0,0 +1,535 @@
+package paritytracer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/SECRYPT-2022/SECRYPT/helper/hex"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/evm"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+)
+
+var _ tracer.StateDiffTracer = &ParityTracer{}
+
+const (
+	callTraceType    = "call"
+	createTraceType  = "create"
+	suicideTraceType = "suicide"
+)
+
+type Config struct {
+	VMTrace   bool // enable vm trace capture
+	StateDiff bool // enable state diff capture
+}
+
+// Action is the action performed by a trace
+type Action struct {
+	CallType      string         `json:"callType,omitempty"`
+	From          *types.Address `json:"from,omitempty"`
+	To            *types.Address `json:"to,omitempty"`
+	Gas           string         `json:"gas,omitempty"`
+	Input         string         `json:"input,omitempty"`
+	Init          string         `json:"init,omitempty"`
+	Value         string         `json:"value,omitempty"`
+	Address       *types.Address `json:"address,omitempty"`
+	RefundAddress *types.Address `json:"refundAddress,omitempty"`
+	Balance       string         `json:"balance,omitempty"`
+}
+
+// Result is the result of a successful trace
+type Result struct {
+	GasUsed string         `json:"gasUsed"`
+	Output  string         `json:"output,omitempty"`
+	Address *types.Address `json:"address,omitempty"`
+	Code    string         `json:"code,omitempty"`
+}
+
+// Trace is a flat trace of a call, contract creation or self destruct,
+// positioned in the call tree by its trace address
+type Trace struct {
+	Action              *Action     `json:"action"`
+	BlockHash           *types.Hash `json:"blockHash,omitempty"`
+	BlockNumber         *uint64     `json:"blockNumber,omitempty"`
+	Error               string      `json:"error,omitempty"`
+	Result              *Result     `json:"result"`
+	Subtraces           int         `json:"subtraces"`
+	TraceAddress        []int       `json:"traceAddress"`
+	TransactionHash     *types.Hash `json:"transactionHash,omitempty"`
+	TransactionPosition *uint64     `json:"transactionPosition,omitempty"`
+	Type                string      `json:"type"`
+}
+
+// VMTrace is the trace of the instructions executed by a call
+type VMTrace struct {
+	Code string         `json:"code"`
+	Ops  []*VMOperation `json:"ops"`
+}
+
+// VMOperation is an executed instruction
+type VMOperation struct {
+	Cost uint64      `json:"cost"`
+	Ex   *VMExecuted `json:"ex"`
+	Pc   uint64      `json:"pc"`
+	Sub  *VMTrace    `json:"sub"`
+}
+
+// VMExecuted holds the effects of an executed instruction
+type VMExecuted struct {
+	Mem   *VMMemory  `json:"mem"`
+	Push  []string   `json:"push"`
+	Store *VMStorage `json:"store"`
+	Used  uint64     `json:"used"`
+}
+
+// VMMemory is a memory write
+type VMMemory struct {
+	Data string `json:"data"`
+	Off  uint64 `json:"off"`
+}
+
+// VMStorage is a storage write
+type VMStorage struct {
+	Key string `json:"key"`
+	Val string `json:"val"`
+}
+
+// TxTrace is the result of tracing a transaction
+type TxTrace struct {
+	Output    string                              `json:"output"`
+	StateDiff map[types.Address]*AccountStateDiff `json:"stateDiff"`
+	Trace     []*Trace                            `json:"trace"`
+	VMTrace   *VMTrace                            `json:"vmTrace"`
+}
+
+// frame is a call in progress
+type frame struct {
+	trace   *Trace
+	address types.Address
+	vmTrace *VMTrace
+
+	// instruction being executed
+	current *VMOperation
+
+	// last executed instruction, waiting for its effects
+	// to be captured at the next instruction
+	pending *pendingOperation
+}
+
+type pendingOperation struct {
+	op      *VMOperation
+	opCode  int
+	sp      int
+	memOff  uint64
+	memSize uint64
+}
+
+// ParityTracer builds OpenEthereum style flat call traces,
+// and optionally vm traces and state diffs
+type ParityTracer struct {
+	Config Config
+
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	traces    []*Trace
+	frames    []*frame
+	vmTrace   *VMTrace
+	output    []byte
+	stateDiff map[types.Address]*tracer.AccountDiff
+}
+
+func NewParityTracer(config Config) *ParityTracer {
+	return &ParityTracer{
+		Config:     config,
+		cancelLock: sync.RWMutex{},
+	}
+}
+
+func (t *ParityTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *ParityTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *ParityTracer) Clear() {
+	t.traces = nil
+	t.frames = nil
+	t.vmTrace = nil
+	t.output = nil
+	t.stateDiff = nil
+}
+
+func (t *ParityTracer) TxStart(gasLimit uint64) {
+}
+
+func (t *ParityTracer) TxEnd(gasLeft uint64) {
+}
+
+// currentFrame returns the call in progress, if any
+func (t *ParityTracer) currentFrame() *frame {
+	if len(t.frames) == 0 {
+		return nil
+	}
+
+	return t.frames[len(t.frames)-1]
+}
+
+// addTrace appends a trace as a child of the call in progress
+func (t *ParityTracer) addTrace(trace *Trace) {
+	trace.TraceAddress = []int{}
+
+	if parent := t.currentFrame(); parent != nil {
+		trace.TraceAddress = make([]int, len(parent.trace.TraceAddress), len(parent.trace.TraceAddress)+1)
+		copy(trace.TraceAddress, parent.trace.TraceAddress)
+		trace.TraceAddress = append(trace.TraceAddress, parent.trace.Subtraces)
+
+		parent.trace.Subtraces++
+	}
+
+	t.traces = append(t.traces, trace)
+}
+
+func (t *ParityTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	trace := &Trace{
+		Action: &Action{
+			From:  &from,
+			Gas:   hex.EncodeUint64(gas),
+			Value: hex.EncodeBig(value),
+		},
+	}
+
+	isCreate := callType == evm.CREATE || callType == evm.CREATE2 ||
+		callType == int(runtime.Create) || callType == int(runtime.Create2)
+
+	if isCreate {
+		trace.Type = createTraceType
+		trace.Action.Init = hex.EncodeToHex(input)
+	} else {
+		trace.Type = callTraceType
+		trace.Action.CallType = callTypeName(callType)
+		trace.Action.To = &to
+		trace.Action.Input = hex.EncodeToHex(input)
+	}
+
+	t.addTrace(trace)
+
+	f := &frame{
+		trace:   trace,
+		address: to,
+	}
+
+	if t.Config.VMTrace {
+		f.vmTrace = &VMTrace{
+			Ops: []*VMOperation{},
+		}
+
+		if isCreate {
+			f.vmTrace.Code = hex.EncodeToHex(input)
+		}
+
+		// the call is a sub trace of the instruction which triggered it
+		if parent := t.currentFrame(); parent != nil && parent.current != nil {
+			parent.current.Sub = f.vmTrace
+		} else if t.vmTrace == nil {
+			t.vmTrace = f.vmTrace
+		}
+	}
+
+	t.frames = append(t.frames, f)
+}
+
+func (t *ParityTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasUsed uint64,
+	err error,
+) {
+	f := t.currentFrame()
+	if f == nil {
+		return
+	}
+
+	t.frames = t.frames[:len(t.frames)-1]
+
+	if depth == 1 {
+		t.output = output
+	}
+
+	if err != nil {
+		f.trace.Error = errorString(err)
+
+		return
+	}
+
+	f.trace.Result = &Result{
+		GasUsed: hex.EncodeUint64(gasUsed),
+	}
+
+	if f.trace.Type == createTraceType {
+		f.trace.Result.Address = &f.address
+		f.trace.Result.Code = hex.EncodeToHex(output)
+	} else {
+		f.trace.Result.Output = hex.EncodeToHex(output)
+	}
+}
+
+func (t *ParityTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	f := t.currentFrame()
+	if f == nil {
+		return
+	}
+
+	if opCode == evm.SELFDESTRUCT && sp >= 1 {
+		address, refundAddress := contractAddress, types.BytesToAddress(stack[sp-1].Bytes())
+
+		t.addTrace(&Trace{
+			Type: suicideTraceType,
+			Action: &Action{
+				Address:       &address,
+				RefundAddress: &refundAddress,
+				Balance:       hex.EncodeBig(host.GetBalance(contractAddress)),
+			},
+		})
+	}
+
+	if !t.Config.VMTrace {
+		return
+	}
+
+	if f.vmTrace.Code == "" {
+		f.vmTrace.Code = hex.EncodeToHex(host.GetCode(contractAddress))
+	}
+
+	// the effects of the previous instruction are visible now
+	if f.pending != nil {
+		f.pending.capture(memory, stack, sp)
+		f.pending = nil
+	}
+
+	f.current = &VMOperation{
+		Ex: &VMExecuted{
+			Push: []string{},
+		},
+	}
+
+	if opCode == evm.SSTORE && sp >= 2 {
+		f.current.Ex.Store = &VMStorage{
+			Key: hex.EncodeBig(stack[sp-1]),
+			Val: hex.EncodeBig(stack[sp-2]),
+		}
+	}
+
+	f.pending = &pendingOperation{
+		op:     f.current,
+		opCode: opCode,
+		sp:     sp,
+	}
+
+	f.pending.memOff, f.pending.memSize = memoryWrite(opCode, stack, sp)
+}
+
+func (t *ParityTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+	if !t.Config.VMTrace {
+		return
+	}
+
+	f := t.currentFrame()
+	if f == nil || f.current == nil {
+		return
+	}
+
+	f.current.Pc = ip
+	f.current.Cost = cost
+
+	if availableGas > cost {
+		f.current.Ex.Used = availableGas - cost
+	}
+
+	f.vmTrace.Ops = append(f.vmTrace.Ops, f.current)
+	f.current = nil
+}
+
+// CaptureStateDiff implements the StateDiffTracer interface
+func (t *ParityTracer) CaptureStateDiff(diff map[types.Address]*tracer.AccountDiff) {
+	if t.Config.StateDiff {
+		t.stateDiff = diff
+	}
+}
+
+func (t *ParityTracer) GetResult() (interface{}, error) {
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	traces := t.traces
+	if traces == nil {
+		traces = []*Trace{}
+	}
+
+	result := &TxTrace{
+		Output: hex.EncodeToHex(t.output),
+		Trace:  traces,
+	}
+
+	if t.Config.VMTrace {
+		result.VMTrace = t.vmTrace
+	}
+
+	if t.Config.StateDiff {
+		result.StateDiff = formatStateDiff(t.stateDiff)
+	}
+
+	return result, nil
+}
+
+// capture sets the stack items pushed and the memory written by the instruction
+func (p *pendingOperation) capture(memory []byte, stack []*big.Int, sp int) {
+	var pushed int
+
+	switch {
+	case p.opCode >= evm.DUP1 && p.opCode <= evm.DUP16:
+		pushed = p.opCode - evm.DUP1 + 2
+	case p.opCode >= evm.SWAP1 && p.opCode <= evm.SWAP16:
+		pushed = p.opCode - evm.SWAP1 + 2
+	default:
+		pushed = sp - (p.sp - evm.StackInputs(evm.OpCode(p.opCode)))
+	}
+
+	if pushed > sp {
+		pushed = sp
+	}
+
+	for i := sp - pushed; i < sp; i++ {
+		p.op.Ex.Push = append(p.op.Ex.Push, hex.EncodeBig(stack[i]))
+	}
+
+	if p.memSize == 0 {
+		return
+	}
+
+	end := p.memOff + p.memSize
+	if end > uint64(len(memory)) || end < p.memOff {
+		return
+	}
+
+	p.op.Ex.Mem = &VMMemory{
+		Off:  p.memOff,
+		Data: hex.EncodeToHex(memory[p.memOff:end]),
+	}
+}
+
+// memoryWrite returns the memory range the instruction writes to
+func memoryWrite(opCode int, stack []*big.Int, sp int) (uint64, uint64) {
+	var offPos, sizePos int
+
+	size := uint64(0)
+
+	switch opCode {
+	case evm.MSTORE:
+		offPos, size = 1, 32
+	case evm.MSTORE8:
+		offPos, size = 1, 1
+	case evm.CALLDATACOPY, evm.CODECOPY, evm.RETURNDATACOPY:
+		offPos, sizePos = 1, 3
+	case evm.EXTCODECOPY:
+		offPos, sizePos = 2, 4
+	case evm.CALL, evm.CALLCODE:
+		offPos, sizePos = 6, 7
+	case evm.DELEGATECALL, evm.STATICCALL:
+		offPos, sizePos = 5, 6
+	default:
+		return 0, 0
+	}
+
+	if sp < offPos || sp < sizePos {
+		return 0, 0
+	}
+
+	off := stack[sp-offPos]
+	if !off.IsUint64() {
+		return 0, 0
+	}
+
+	if sizePos != 0 {
+		if !stack[sp-sizePos].IsUint64() {
+			return 0, 0
+		}
+
+		size = stack[sp-sizePos].Uint64()
+	}
+
+	return off.Uint64(), size
+}
+
+func callTypeName(callType int) string {
+	switch runtime.CallType(callType) {
+	case runtime.CallCode:
+		return "callcode"
+	case runtime.DelegateCall:
+		return "delegatecall"
+	case runtime.StaticCall:
+		return "staticcall"
+	default:
+		return "call"
+	}
+}
+
+// errorString returns the error message in the format used by OpenEthereum
+func errorString(err error) string {
+	switch {
+	case errors.Is(err, runtime.ErrExecutionReverted):
+		return "Reverted"
+	case errors.Is(err, runtime.ErrOutOfGas):
+		return "Out of gas"
+	default:
+		return err.Error()
+	}
+}
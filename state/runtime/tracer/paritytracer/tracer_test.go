@@ -0,0 +1,224 @@
+package paritytracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/evm"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+)
+
+type mockHost struct {
+	tracer.RuntimeHost
+
+	balances map[types.Address]*big.Int
+	code     map[types.Address][]byte
+}
+
+func (m *mockHost) GetBalance(addr types.Address) *big.Int {
+	if balance, ok := m.balances[addr]; ok {
+		return balance
+	}
+
+	return big.NewInt(0)
+}
+
+func (m *mockHost) GetCode(addr types.Address) []byte {
+	return m.code[addr]
+}
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+func getTrace(t *testing.T, tr *ParityTracer) *TxTrace {
+	t.Helper()
+
+	res, err := tr.GetResult()
+	assert.NoError(t, err)
+
+	txTrace, ok := res.(*TxTrace)
+	assert.True(t, ok)
+
+	return txTrace
+}
+
+func TestParityTracer_CallTraces(t *testing.T) {
+	t.Parallel()
+
+	tr := NewParityTracer(Config{})
+	host := &mockHost{
+		balances: map[types.Address]*big.Int{addr2: big.NewInt(10)},
+	}
+
+	tr.CallStart(1, addr1, addr2, int(runtime.Call), 1000, big.NewInt(1), []byte{0x1})
+
+	// inner create
+	tr.CallStart(2, addr2, addr3, evm.CREATE, 500, big.NewInt(0), []byte{0x2})
+	tr.CallEnd(2, []byte{0x3}, 100, nil)
+
+	// inner reverted delegate call
+	tr.CallStart(2, addr2, addr3, int(runtime.DelegateCall), 300, nil, nil)
+	tr.CallEnd(2, nil, 300, runtime.ErrExecutionReverted)
+
+	// self destruct
+	tr.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(addr1.Bytes())}, evm.SELFDESTRUCT, addr2, 1, host, &mockState{})
+
+	tr.CallEnd(1, []byte{0x4}, 900, nil)
+
+	res := getTrace(t, tr)
+
+	assert.Equal(t, "0x04", res.Output)
+	assert.Nil(t, res.VMTrace)
+	assert.Nil(t, res.StateDiff)
+	assert.Len(t, res.Trace, 4)
+
+	call := res.Trace[0]
+	assert.Equal(t, callTraceType, call.Type)
+	assert.Equal(t, "call", call.Action.CallType)
+	assert.Equal(t, &addr2, call.Action.To)
+	assert.Equal(t, "0x3e8", call.Action.Gas)
+	assert.Equal(t, 3, call.Subtraces)
+	assert.Equal(t, []int{}, call.TraceAddress)
+	assert.Equal(t, &Result{GasUsed: "0x384", Output: "0x04"}, call.Result)
+
+	create := res.Trace[1]
+	assert.Equal(t, createTraceType, create.Type)
+	assert.Equal(t, "0x02", create.Action.Init)
+	assert.Nil(t, create.Action.To)
+	assert.Equal(t, []int{0}, create.TraceAddress)
+	assert.Equal(t, &Result{GasUsed: "0x64", Address: &addr3, Code: "0x03"}, create.Result)
+
+	reverted := res.Trace[2]
+	assert.Equal(t, "delegatecall", reverted.Action.CallType)
+	assert.Equal(t, "0x0", reverted.Action.Value)
+	assert.Equal(t, []int{1}, reverted.TraceAddress)
+	assert.Equal(t, "Reverted", reverted.Error)
+	assert.Nil(t, reverted.Result)
+
+	suicide := res.Trace[3]
+	assert.Equal(t, suicideTraceType, suicide.Type)
+	assert.Equal(t, &addr2, suicide.Action.Address)
+	assert.Equal(t, &addr1, suicide.Action.RefundAddress)
+	assert.Equal(t, "0xa", suicide.Action.Balance)
+	assert.Equal(t, []int{2}, suicide.TraceAddress)
+}
+
+func TestParityTracer_VMTrace(t *testing.T) {
+	t.Parallel()
+
+	tr := NewParityTracer(Config{VMTrace: true})
+	host := &mockHost{
+		code: map[types.Address][]byte{addr2: {0x60, 0x1}},
+	}
+	state := &mockState{}
+
+	tr.CallStart(1, addr1, addr2, int(runtime.Call), 1000, big.NewInt(0), nil)
+
+	steps := []struct {
+		op     int
+		name   string
+		memory []byte
+		stack  []*big.Int
+	}{
+		{evm.PUSH1, "PUSH1", nil, []*big.Int{}},
+		{evm.PUSH1, "PUSH1", nil, []*big.Int{big.NewInt(1)}},
+		{evm.MSTORE8, "MSTORE8", nil, []*big.Int{big.NewInt(1), big.NewInt(0)}},
+		{evm.PUSH1, "PUSH1", []byte{0x1}, []*big.Int{}},
+		{evm.PUSH1, "PUSH1", []byte{0x1}, []*big.Int{big.NewInt(2)}},
+		{evm.SSTORE, "SSTORE", []byte{0x1}, []*big.Int{big.NewInt(2), big.NewInt(1)}},
+	}
+
+	gas := uint64(1000)
+
+	for pc, step := range steps {
+		tr.CaptureState(step.memory, step.stack, step.op, addr2, len(step.stack), host, state)
+		tr.ExecuteState(addr2, uint64(pc), step.name, gas, 3, nil, 1, nil, host)
+
+		gas -= 3
+	}
+
+	tr.CallEnd(1, nil, 1000, errors.New("out of gas"))
+
+	res := getTrace(t, tr)
+
+	assert.Equal(t, "0x6001", res.VMTrace.Code)
+	assert.Len(t, res.VMTrace.Ops, 6)
+
+	push := res.VMTrace.Ops[0]
+	assert.Equal(t, uint64(0), push.Pc)
+	assert.Equal(t, uint64(3), push.Cost)
+	assert.Equal(t, uint64(997), push.Ex.Used)
+	assert.Equal(t, []string{"0x1"}, push.Ex.Push)
+	assert.Nil(t, push.Ex.Mem)
+
+	mstore := res.VMTrace.Ops[2]
+	assert.Equal(t, uint64(2), mstore.Pc)
+	assert.Equal(t, []string{}, mstore.Ex.Push)
+	assert.Equal(t, &VMMemory{Off: 0, Data: "0x01"}, mstore.Ex.Mem)
+
+	sstore := res.VMTrace.Ops[5]
+	assert.Equal(t, &VMStorage{Key: "0x1", Val: "0x2"}, sstore.Ex.Store)
+
+	assert.Equal(t, "out of gas", res.Trace[0].Error)
+}
+
+func TestParityTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+
+	tr := NewParityTracer(Config{})
+	tr.CallStart(1, addr1, addr2, int(runtime.Call), 1000, big.NewInt(0), nil)
+	tr.Cancel(err)
+
+	state := &mockState{}
+	tr.CaptureState(nil, nil, int(evm.STOP), addr2, 0, &mockHost{}, state)
+
+	assert.True(t, state.halted)
+
+	res, resErr := tr.GetResult()
+	assert.Nil(t, res)
+	assert.Equal(t, err, resErr)
+}
+
+func TestFormatStateDiff(t *testing.T) {
+	t.Parallel()
+
+	slot := types.StringToHash("1")
+
+	diff := map[types.Address]*tracer.AccountDiff{
+		addr1: {
+			Pre:  &tracer.AccountState{Balance: big.NewInt(10), Nonce: 1, Storage: map[types.Hash]types.Hash{}},
+			Post: &tracer.AccountState{Balance: big.NewInt(5), Nonce: 1, Storage: map[types.Hash]types.Hash{slot: slot}},
+		},
+		addr2: {
+			Post: &tracer.AccountState{Balance: big.NewInt(5), Code: []byte{0x1}},
+		},
+	}
+
+	res := formatStateDiff(diff)
+
+	assert.Equal(t, modified("0xa", "0x5"), res[addr1].Balance)
+	assert.Equal(t, unchanged, res[addr1].Nonce)
+	assert.Equal(t, unchanged, res[addr1].Code)
+	assert.Equal(t, modified(types.ZeroHash.String(), slot.String()), res[addr1].Storage[slot])
+
+	assert.Equal(t, created("0x5"), res[addr2].Balance)
+	assert.Equal(t, created("0x01"), res[addr2].Code)
+	assert.Equal(t, created("0x0"), res[addr2].Nonce)
+}
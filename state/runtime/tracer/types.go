@@ -1,6 +1,7 @@
 package tracer
 
 import (
+	"bytes"
 	"math/big"
 
 	"github.com/SECRYPT-2022/SECRYPT/types"
@@ -12,6 +13,10 @@ type RuntimeHost interface {
 	GetRefund() uint64
 	// GetStorage access the storage slot at the given address and slot hash
 	GetStorage(types.Address, types.Hash) types.Hash
+	// GetBalance returns the balance of the given address
+	GetBalance(types.Address) *big.Int
+	// GetCode returns the code of the given address
+	GetCode(types.Address) []byte
 }
 
 type VMState interface {
@@ -43,6 +48,7 @@ type Tracer interface {
 	CallEnd(
 		depth int, // begins from 1
 		output []byte,
+		gasUsed uint64,
 		err error,
 	)
 
@@ -68,3 +74,71 @@ type Tracer interface {
 		host RuntimeHost,
 	)
 }
+
+// AccountState is the state of an account at some point of a transaction
+type AccountState struct {
+	Balance *big.Int
+	Nonce   uint64
+	Code    []byte
+	Storage map[types.Hash]types.Hash
+}
+
+// AccountDiff holds the changes a transaction made to an account.
+// Pre is nil if the account was created by the transaction,
+// while Post is nil if the account was deleted by it.
+// Only the storage slots changed by the transaction are included
+type AccountDiff struct {
+	Pre  *AccountState
+	Post *AccountState
+}
+
+// NewAccountDiff returns the changes between the given account states,
+// keeping only the changed storage slots. It returns nil if nothing changed
+func NewAccountDiff(pre, post *AccountState) *AccountDiff {
+	if pre == nil && post == nil {
+		return nil
+	}
+
+	if pre != nil && post != nil {
+		for slot, value := range post.Storage {
+			if pre.Storage[slot] == value {
+				delete(pre.Storage, slot)
+				delete(post.Storage, slot)
+			}
+		}
+
+		if pre.Balance.Cmp(post.Balance) == 0 &&
+			pre.Nonce == post.Nonce &&
+			bytes.Equal(pre.Code, post.Code) &&
+			len(post.Storage) == 0 {
+			return nil
+		}
+	}
+
+	// created or deleted accounts have no storage on the other side
+	for _, state := range []*AccountState{pre, post} {
+		if state == nil {
+			continue
+		}
+
+		for slot, value := range state.Storage {
+			if value == types.ZeroHash && (pre == nil || post == nil) {
+				delete(state.Storage, slot)
+			}
+		}
+	}
+
+	return &AccountDiff{
+		Pre:  pre,
+		Post: post,
+	}
+}
+
+// StateDiffTracer is implemented by tracers which capture
+// the changes each transaction makes to the state
+type StateDiffTracer interface {
+	Tracer
+
+	// CaptureStateDiff is called with the changes of a transaction, after it's applied
+	CaptureStateDiff(diff map[types.Address]*AccountDiff)
+}
@@ -4,7 +4,9 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/SECRYPT-2022/SECRYPT/chain"
 	"github.com/SECRYPT-2022/SECRYPT/state/runtime"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/allowlist"
 	"github.com/SECRYPT-2022/SECRYPT/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -159,3 +161,161 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestAllowListCheck(t *testing.T) {
+	t.Parallel()
+
+	addr3 := types.StringToAddress("3")
+
+	// addr2 is enabled in the parent state of both allow lists
+	enabled := map[types.Hash]types.Hash{
+		types.BytesToHash(addr2.Bytes()): types.BytesToHash([]byte{byte(allowlist.EnabledRole) + 1}),
+	}
+	preState := map[types.Address]*PreState{
+		allowlist.DeploymentAllowListAddr:  {State: enabled},
+		allowlist.TransactionAllowListAddr: {State: enabled},
+	}
+
+	tests := []struct {
+		name        string
+		from        types.Address
+		create      bool
+		deployment  bool
+		transaction bool
+		expectedErr error
+	}{
+		{"allow lists not enforced", addr3, true, false, false, nil},
+		{"bootstrap admin can deploy", addr1, true, true, true, nil},
+		{"enabled account can deploy", addr2, true, true, true, nil},
+		{"account without role can't deploy", addr3, true, true, false, ErrDeploymentNotAllowed},
+		{"account without role can call", addr3, false, true, false, nil},
+		{"account without role can't transact", addr3, false, false, true, ErrSenderNotAllowed},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			transition := newTestTransition(preState)
+			transition.snap = &mockSnapshot{state: preState}
+
+			if tt.deployment {
+				transition.deploymentAllowList = allowlist.NewAllowList(
+					allowlist.DeploymentAllowListAddr,
+					[]types.Address{addr1},
+				)
+			}
+
+			if tt.transaction {
+				transition.transactionAllowList = allowlist.NewAllowList(
+					allowlist.TransactionAllowListAddr,
+					[]types.Address{addr1},
+				)
+			}
+
+			msg := &types.Transaction{
+				From: tt.from,
+			}
+			if !tt.create {
+				msg.To = &addr1
+			}
+
+			assert.Equal(t, tt.expectedErr, transition.allowListCheck(msg))
+		})
+	}
+}
+
+func TestSubGasLimitPrice_FeeDelegated(t *testing.T) {
+	t.Parallel()
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {
+			Nonce:   0,
+			Balance: 10,
+		},
+		addr2: {
+			Nonce:   0,
+			Balance: 1000,
+		},
+	})
+
+	feePayer := addr2
+	msg := &types.Transaction{
+		From:     addr1,
+		Gas:      10,
+		GasPrice: big.NewInt(10),
+		Type:     types.FeeDelegatedTx,
+		FeePayer: &feePayer,
+	}
+
+	assert.NoError(t, transition.subGasLimitPrice(msg))
+
+	// the gas is charged to the fee payer, leaving the sender balance untouched
+	assert.Equal(t, big.NewInt(10), transition.state.GetBalance(addr1))
+	assert.Equal(t, big.NewInt(900), transition.state.GetBalance(addr2))
+}
+
+func TestTransitionSnapshot(t *testing.T) {
+	t.Parallel()
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {
+			Nonce:   0,
+			Balance: 1000,
+		},
+	})
+	transition.gasPool = 100
+	transition.totalGas = 10
+	transition.receipts = []*types.Receipt{{CumulativeGasUsed: 10}}
+
+	snapshot := transition.Snapshot()
+
+	assert.NoError(t, transition.state.SubBalance(addr1, big.NewInt(500)))
+	transition.gasPool = 50
+	transition.totalGas = 60
+	transition.receipts = append(transition.receipts, &types.Receipt{CumulativeGasUsed: 60})
+
+	transition.RevertToSnapshot(snapshot)
+
+	assert.Equal(t, big.NewInt(1000), transition.state.GetBalance(addr1))
+	assert.Equal(t, uint64(100), transition.gasPool)
+	assert.Equal(t, uint64(10), transition.totalGas)
+	assert.Len(t, transition.receipts, 1)
+	assert.Len(t, transition.snapshots, 0)
+}
+
+func TestTxTypeCheck_FeeDelegation(t *testing.T) {
+	t.Parallel()
+
+	feePayer := addr2
+	msg := &types.Transaction{
+		From:     addr1,
+		Gas:      10,
+		GasPrice: big.NewInt(10),
+		Type:     types.FeeDelegatedTx,
+		FeePayer: &feePayer,
+	}
+
+	forks := &chain.Forks{FeeDelegation: chain.NewFork(5)}
+
+	// the same transaction is rejected before the fork and accepted from it
+	transition := newTestTransition(nil)
+	transition.config = forks.At(4)
+
+	_, err := transition.Apply(msg)
+
+	var appErr *TransitionApplicationError
+	if assert.ErrorAs(t, err, &appErr) {
+		assert.Equal(t, ErrTxTypeNotSupported, appErr.Err)
+		assert.False(t, appErr.IsRecoverable)
+	}
+
+	transition = newTestTransition(nil)
+	transition.config = forks.At(5)
+
+	assert.NoError(t, transition.txTypeCheck(msg))
+
+	// the legacy transactions don't depend on the fork
+	assert.NoError(t, newTestTransition(nil).txTypeCheck(&types.Transaction{From: addr1}))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/SECRYPT-2022/SECRYPT/chain"
 	"github.com/SECRYPT-2022/SECRYPT/crypto"
 	"github.com/SECRYPT-2022/SECRYPT/state/runtime"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/tracer"
 	"github.com/SECRYPT-2022/SECRYPT/types"
 )
 
@@ -57,6 +58,80 @@ func newTxn(snapshot readSnapshot) *Txn {
 	}
 }
 
+// Copy returns a copy of the txn at this point in time,
+// which isn't affected by further changes to the txn
+func (txn *Txn) Copy() *Txn {
+	return &Txn{
+		snapshot:  txn.snapshot,
+		snapshots: []*iradix.Tree{},
+		txn:       txn.txn.CommitOnly().Txn(),
+		codeCache: txn.codeCache,
+	}
+}
+
+// Diff returns the changes made to the accounts since the given copy of the txn was taken
+func (txn *Txn) Diff(pre *Txn) map[types.Address]*tracer.AccountDiff {
+	diff := map[types.Address]*tracer.AccountDiff{}
+
+	txn.txn.Root().Walk(func(k []byte, v interface{}) bool {
+		post, ok := v.(*StateObject)
+		if !ok {
+			// logs and refunds are kept in the radix as well
+			return false
+		}
+
+		// objects are replaced on each change, so an unchanged object is still the same one
+		if prev, exists := pre.txn.Get(k); exists && prev == v {
+			return false
+		}
+
+		addr := types.BytesToAddress(k)
+
+		// storage slots changed so far in the block
+		var slots []types.Hash
+
+		if post.Txn != nil {
+			post.Txn.Root().Walk(func(key []byte, _ interface{}) bool {
+				slots = append(slots, types.BytesToHash(key))
+
+				return false
+			})
+		}
+
+		if accountDiff := tracer.NewAccountDiff(
+			pre.accountState(addr, slots),
+			txn.accountState(addr, slots),
+		); accountDiff != nil {
+			diff[addr] = accountDiff
+		}
+
+		return false
+	})
+
+	return diff
+}
+
+// accountState returns the state of the account, with the given storage slots
+func (txn *Txn) accountState(addr types.Address, slots []types.Hash) *tracer.AccountState {
+	object, exists := txn.getStateObject(addr)
+	if !exists || object.Suicide {
+		return nil
+	}
+
+	state := &tracer.AccountState{
+		Balance: new(big.Int).Set(object.Account.Balance),
+		Nonce:   object.Account.Nonce,
+		Code:    txn.GetCode(addr),
+		Storage: make(map[types.Hash]types.Hash, len(slots)),
+	}
+
+	for _, slot := range slots {
+		state.Storage[slot] = txn.GetState(addr, slot)
+	}
+
+	return state
+}
+
 // Snapshot takes a snapshot at this point in time
 func (txn *Txn) Snapshot() int {
 	t := txn.txn.CommitOnly()
@@ -45,6 +45,10 @@ func (m *mockSnapshot) GetCode(hash types.Hash) ([]byte, bool) {
 	return nil, false
 }
 
+func (m *mockSnapshot) Commit(objs []*Object) (Snapshot, []byte) {
+	return m, nil
+}
+
 func newStateWithPreState(preState map[types.Address]*PreState) readSnapshot {
 	return &mockSnapshot{state: preState}
 }
@@ -66,3 +70,32 @@ func TestSnapshotUpdateData(t *testing.T) {
 	txn.RevertToSnapshot(ss)
 	assert.Equal(t, hash1, txn.GetState(addr1, hash1))
 }
+
+func TestTxn_Diff(t *testing.T) {
+	txn := newTestTxn(defaultPreState)
+
+	// changes before the copy are not part of the diff
+	txn.AddBalance(addr1, big.NewInt(1))
+
+	pre := txn.Copy()
+
+	txn.SetState(addr1, hash1, hash2)
+	txn.SetState(addr1, hash2, hash2)
+	txn.AddBalance(addr2, big.NewInt(10))
+
+	diff := txn.Diff(pre)
+	assert.Len(t, diff, 2)
+
+	// modified account
+	assert.Equal(t, big.NewInt(1), diff[addr1].Pre.Balance)
+	assert.Equal(t, big.NewInt(1), diff[addr1].Post.Balance)
+	assert.Equal(t, map[types.Hash]types.Hash{hash1: hash1, hash2: {}}, diff[addr1].Pre.Storage)
+	assert.Equal(t, map[types.Hash]types.Hash{hash1: hash2, hash2: hash2}, diff[addr1].Post.Storage)
+
+	// created account
+	assert.Nil(t, diff[addr2].Pre)
+	assert.Equal(t, big.NewInt(10), diff[addr2].Post.Balance)
+
+	// the copy is not affected by the changes
+	assert.Equal(t, hash1, pre.GetState(addr1, hash1))
+}
@@ -0,0 +1,501 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SECRYPT-2022/SECRYPT/blockchain"
+	"github.com/SECRYPT-2022/SECRYPT/chain"
+	"github.com/SECRYPT-2022/SECRYPT/crypto"
+	"github.com/SECRYPT-2022/SECRYPT/helper/hex"
+	"github.com/SECRYPT-2022/SECRYPT/state"
+	itrie "github.com/SECRYPT-2022/SECRYPT/state/immutable-trie"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const blockchainTests = "BlockchainTests"
+
+const (
+	// maxExtraDataSize is the maximum size of the header extra data on Ethereum
+	maxExtraDataSize = 32
+
+	// minGasLimit is the minimum gas limit of a block on Ethereum
+	minGasLimit = 5000
+
+	// gasLimitBoundDivisor bounds the change of the gas limit from the parent block
+	gasLimitBoundDivisor = 1024
+)
+
+// blockchainForks are the networks of the blockchain tests whose
+// mapping differs from the one of the state tests, which only enable
+// the forks used by their transitions
+var blockchainForks = map[string]*chain.Forks{
+	"ByzantiumToConstantinopleAt5": {
+		Homestead:      chain.NewFork(0),
+		EIP150:         chain.NewFork(0),
+		EIP155:         chain.NewFork(0),
+		EIP158:         chain.NewFork(0),
+		Byzantium:      chain.NewFork(0),
+		Constantinople: chain.NewFork(5),
+	},
+}
+
+// getBlockchainForks returns the forks of the network of a blockchain test
+func getBlockchainForks(network string) (*chain.Forks, bool) {
+	if forks, ok := blockchainForks[network]; ok {
+		return forks, true
+	}
+
+	forks, ok := Forks[network]
+
+	return forks, ok
+}
+
+var (
+	frontierBlockReward       = big.NewInt(5e+18)
+	byzantiumBlockReward      = big.NewInt(3e+18)
+	constantinopleBlockReward = big.NewInt(2e+18)
+)
+
+type blockchainCase struct {
+	Network    string                                  `json:"network"`
+	GenesisRLP string                                  `json:"genesisRLP"`
+	Pre        map[types.Address]*chain.GenesisAccount `json:"pre"`
+	Post       map[types.Address]*chain.GenesisAccount `json:"postState"`
+	Blocks     []*btBlock                              `json:"blocks"`
+	LastBlock  types.Hash                              `json:"lastblockhash"`
+}
+
+// btBlock is a block of the fixture, the block is expected
+// to be rejected if any of the expectException fields is set
+type btBlock struct {
+	RLP       string
+	Exception string
+}
+
+func (b *btBlock) UnmarshalJSON(input []byte) error {
+	var dec map[string]json.RawMessage
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	if raw, ok := dec["rlp"]; ok {
+		if err := json.Unmarshal(raw, &b.RLP); err != nil {
+			return err
+		}
+	}
+
+	for key, raw := range dec {
+		if !strings.HasPrefix(key, "expectException") {
+			continue
+		}
+
+		if err := json.Unmarshal(raw, &b.Exception); err != nil {
+			return err
+		}
+
+		if b.Exception == "" {
+			b.Exception = key
+		}
+	}
+
+	return nil
+}
+
+// testConsensus is a consensus verifier following the Ethereum mainnet rules,
+// except for the proof of work seal and the difficulty which are not verified
+type testConsensus struct {
+	forks      *chain.Forks
+	blockchain *blockchain.Blockchain
+
+	lock     sync.Mutex
+	uncles   map[types.Hash][]*types.Header
+	receipts map[types.Hash][]*types.Receipt
+}
+
+func newTestConsensus(forks *chain.Forks) *testConsensus {
+	return &testConsensus{
+		forks:    forks,
+		uncles:   map[types.Hash][]*types.Header{},
+		receipts: map[types.Hash][]*types.Receipt{},
+	}
+}
+
+func (c *testConsensus) VerifyHeader(header *types.Header) error {
+	parent, ok := c.blockchain.GetHeaderByHash(header.ParentHash)
+	if !ok {
+		return blockchain.ErrParentNotFound
+	}
+
+	if header.Timestamp <= parent.Timestamp {
+		return fmt.Errorf("timestamp %d is not after parent timestamp %d", header.Timestamp, parent.Timestamp)
+	}
+
+	if len(header.ExtraData) > maxExtraDataSize {
+		return fmt.Errorf("extra data too long: %d", len(header.ExtraData))
+	}
+
+	if header.GasLimit < minGasLimit {
+		return fmt.Errorf("gas limit %d below minimum %d", header.GasLimit, minGasLimit)
+	}
+
+	// the gas limit can change by less than parent / 1024
+	diff := int64(header.GasLimit) - int64(parent.GasLimit)
+	if diff < 0 {
+		diff *= -1
+	}
+
+	if limit := parent.GasLimit / gasLimitBoundDivisor; uint64(diff) >= limit {
+		return fmt.Errorf("gas limit %d differs from parent gas limit %d by %d or more",
+			header.GasLimit, parent.GasLimit, limit)
+	}
+
+	return nil
+}
+
+func (c *testConsensus) ProcessHeaders(headers []*types.Header) error {
+	return nil
+}
+
+func (c *testConsensus) GetBlockCreator(header *types.Header) (types.Address, error) {
+	return types.BytesToAddress(header.Miner), nil
+}
+
+// PreCommitState applies the block and uncle rewards,
+// and keeps the receipts of the block to verify its logs bloom
+func (c *testConsensus) PreCommitState(header *types.Header, txn *state.Transition) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	reward := c.blockReward(header.Number)
+	minerReward := new(big.Int).Set(reward)
+
+	for _, uncle := range c.uncles[header.Hash] {
+		// uncles get 1/8 of the reward less for each block of distance
+		uncleReward := new(big.Int).SetUint64(uncle.Number + 8 - header.Number)
+		uncleReward.Mul(uncleReward, reward)
+		uncleReward.Div(uncleReward, big.NewInt(8))
+
+		txn.Txn().AddSealingReward(types.BytesToAddress(uncle.Miner), uncleReward)
+
+		// the miner gets 1/32 of the reward for each included uncle
+		minerReward.Add(minerReward, new(big.Int).Div(reward, big.NewInt(32)))
+	}
+
+	txn.Txn().AddSealingReward(types.BytesToAddress(header.Miner), minerReward)
+
+	c.receipts[header.Hash] = txn.Receipts()
+
+	return nil
+}
+
+func (c *testConsensus) blockReward(number uint64) *big.Int {
+	switch {
+	case c.forks.IsConstantinople(number):
+		return constantinopleBlockReward
+	case c.forks.IsByzantium(number):
+		return byzantiumBlockReward
+	default:
+		return frontierBlockReward
+	}
+}
+
+func (c *testConsensus) setUncles(block *types.Block) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.uncles[block.Hash()] = block.Uncles
+}
+
+func (c *testConsensus) getReceipts(hash types.Hash) []*types.Receipt {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.receipts[hash]
+}
+
+// newTestBlockchain creates a blockchain with the genesis and pre state of the test case
+func newTestBlockchain(t *testing.T, c *blockchainCase, forks *chain.Forks) (
+	*blockchain.Blockchain,
+	*testConsensus,
+	*itrie.State,
+) {
+	t.Helper()
+
+	genesisBlock := &types.Block{}
+	if err := genesisBlock.UnmarshalRLP(hex.MustDecodeHex(c.GenesisRLP)); err != nil {
+		t.Fatalf("failed to decode genesis: %v", err)
+	}
+
+	params := &chain.Params{
+		Forks:   forks,
+		ChainID: 1,
+	}
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+	executor := state.NewExecutor(params, st, hclog.NewNullLogger())
+
+	header := genesisBlock.Header
+	genesis := &chain.Genesis{
+		Config:     params,
+		Nonce:      header.Nonce,
+		Timestamp:  header.Timestamp,
+		ExtraData:  header.ExtraData,
+		GasLimit:   header.GasLimit,
+		Difficulty: header.Difficulty,
+		Mixhash:    header.MixHash,
+		Coinbase:   types.BytesToAddress(header.Miner),
+		Alloc:      c.Pre,
+		Number:     header.Number,
+		GasUsed:    header.GasUsed,
+		ParentHash: header.ParentHash,
+	}
+
+	genesis.StateRoot = executor.WriteGenesis(genesis.Alloc)
+
+	consensus := newTestConsensus(forks)
+
+	b, err := blockchain.NewBlockchain(
+		hclog.NewNullLogger(),
+		"",
+		&chain.Chain{Genesis: genesis, Params: params},
+		consensus,
+		executor,
+		crypto.NewSigner(forks.At(0), uint64(params.ChainID)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	consensus.blockchain = b
+	executor.GetHash = b.GetHashHelper
+
+	if err := b.ComputeGenesis(); err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Genesis() != header.Hash {
+		t.Fatalf("genesis hash mismatch: expected %s but found %s", header.Hash, b.Genesis())
+	}
+
+	return b, consensus, st
+}
+
+// importBlock verifies the block and writes it to the chain
+func importBlock(b *blockchain.Blockchain, consensus *testConsensus, block *types.Block) error {
+	for _, tx := range block.Transactions {
+		tx.ComputeHash()
+	}
+
+	consensus.setUncles(block)
+
+	if err := b.VerifyFinalizedBlock(block); err != nil {
+		return err
+	}
+
+	// the logs bloom is not part of the block verification
+	receipts := consensus.getReceipts(block.Hash())
+	if bloom := types.CreateBloom(receipts); bloom != block.Header.LogsBloom {
+		return fmt.Errorf("logs bloom mismatch: expected %s but found %s",
+			hex.EncodeToHex(block.Header.LogsBloom[:]),
+			hex.EncodeToHex(bloom[:]),
+		)
+	}
+
+	return b.WriteBlock(block, "test")
+}
+
+// checkPostState compares the state at the given root with the expected post state
+func checkPostState(st *itrie.State, root types.Hash, post map[types.Address]*chain.GenesisAccount) error {
+	snap, err := st.NewSnapshotAt(root)
+	if err != nil {
+		return err
+	}
+
+	for addr, expected := range post {
+		account, err := snap.GetAccount(addr)
+		if err != nil {
+			return err
+		}
+
+		if account == nil {
+			return fmt.Errorf("account %s not found", addr)
+		}
+
+		expectedBalance := expected.Balance
+		if expectedBalance == nil {
+			expectedBalance = big.NewInt(0)
+		}
+
+		if account.Balance.Cmp(expectedBalance) != 0 {
+			return fmt.Errorf("balance mismatch for %s: expected %s but found %s", addr, expectedBalance, account.Balance)
+		}
+
+		if account.Nonce != expected.Nonce {
+			return fmt.Errorf("nonce mismatch for %s: expected %d but found %d", addr, expected.Nonce, account.Nonce)
+		}
+
+		code, _ := snap.GetCode(types.BytesToHash(account.CodeHash))
+		if !bytes.Equal(code, expected.Code) {
+			return fmt.Errorf("code mismatch for %s", addr)
+		}
+
+		for key, val := range expected.Storage {
+			if found := snap.GetStorage(addr, account.Root, key); found != val {
+				return fmt.Errorf("storage mismatch for %s at %s: expected %s but found %s", addr, key, val, found)
+			}
+		}
+	}
+
+	return nil
+}
+
+func RunBlockchainTest(t *testing.T, c *blockchainCase) {
+	t.Helper()
+
+	forks, ok := getBlockchainForks(c.Network)
+	if !ok {
+		t.Skipf("network %s not supported", c.Network)
+	}
+
+	b, consensus, st := newTestBlockchain(t, c, forks)
+	defer b.Close()
+
+	// indicates if some blocks don't extend the canonical chain
+	sideChain := false
+
+	for idx, btBlock := range c.Blocks {
+		block := &types.Block{}
+
+		if err := block.UnmarshalRLP(hex.MustDecodeHex(btBlock.RLP)); err != nil {
+			if btBlock.Exception == "" {
+				t.Fatalf("block %d: failed to decode: %v", idx, err)
+			}
+
+			continue
+		}
+
+		// WriteBlock only extends the canonical chain, the side chain blocks are left out
+		// and the fixture passes as long as they don't become canonical
+		if block.ParentHash() != b.Header().Hash {
+			sideChain = true
+
+			continue
+		}
+
+		err := importBlock(b, consensus, block)
+
+		switch {
+		case err != nil && btBlock.Exception == "":
+			t.Fatalf("block %d: unexpected error: %v", idx, err)
+		case err == nil && btBlock.Exception != "":
+			t.Fatalf("block %d: expected failure %s", idx, btBlock.Exception)
+		}
+	}
+
+	head := b.Header()
+	if head.Hash != c.LastBlock && sideChain {
+		t.Skipf("the chain is reorganized to a side chain, which is not supported")
+	}
+
+	if head.Hash != c.LastBlock {
+		t.Fatalf("last block mismatch: expected %s but found %s", c.LastBlock, head.Hash)
+	}
+
+	if err := checkPostState(st, head.StateRoot, c.Post); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBlockchain(t *testing.T) {
+	t.Parallel()
+
+	long := []string{
+		"stQuadraticComplexityTest",
+		"stTimeConsuming",
+	}
+
+	folders, err := listFolders(blockchainTests)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, folder := range folders {
+		folder := folder
+		t.Run(folder, func(t *testing.T) {
+			t.Parallel()
+
+			files, err := listFiles(folder)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, file := range files {
+				if !strings.HasSuffix(file, ".json") {
+					continue
+				}
+
+				if contains(long, file) && testing.Short() {
+					continue
+				}
+
+				data, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var cases map[string]*blockchainCase
+				if err := json.Unmarshal(data, &cases); err != nil {
+					t.Fatal(err)
+				}
+
+				for name, c := range cases {
+					c := c
+
+					t.Run(name, func(t *testing.T) {
+						RunBlockchainTest(t, c)
+					})
+				}
+			}
+		})
+	}
+}
+
+func TestForks_CoverChainForks(t *testing.T) {
+	t.Parallel()
+
+	// the forks of the chain that aren't Ethereum forks, the fixtures don't use them
+	chainOnly := []string{"FeeDelegation"}
+
+	// every fork of the chain has to be enabled by at least one of the test networks
+	forksType := reflect.TypeOf(chain.Forks{})
+
+	for i := 0; i < forksType.NumField(); i++ {
+		field := forksType.Field(i)
+		if contains(chainOnly, field.Name) {
+			continue
+		}
+
+		covered := false
+
+		for _, forks := range Forks {
+			if !reflect.ValueOf(forks).Elem().Field(i).IsNil() {
+				covered = true
+
+				break
+			}
+		}
+
+		if !covered {
+			t.Fatalf("fork %s is not covered by the test networks", field.Name)
+		}
+	}
+}
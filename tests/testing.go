@@ -461,6 +461,25 @@ var Forks = map[string]*chain.Forks{
 		Byzantium:      chain.NewFork(0),
 		Constantinople: chain.NewFork(5),
 	},
+	"ByzantiumToConstantinopleFixAt5": {
+		Homestead:      chain.NewFork(0),
+		EIP150:         chain.NewFork(0),
+		EIP155:         chain.NewFork(0),
+		EIP158:         chain.NewFork(0),
+		Byzantium:      chain.NewFork(0),
+		Constantinople: chain.NewFork(5),
+		Petersburg:     chain.NewFork(5),
+	},
+	"ConstantinopleFixToIstanbulAt5": {
+		Homestead:      chain.NewFork(0),
+		EIP150:         chain.NewFork(0),
+		EIP155:         chain.NewFork(0),
+		EIP158:         chain.NewFork(0),
+		Byzantium:      chain.NewFork(0),
+		Constantinople: chain.NewFork(0),
+		Petersburg:     chain.NewFork(0),
+		Istanbul:       chain.NewFork(5),
+	},
 	"ConstantinopleFix": {
 		Homestead:      chain.NewFork(0),
 		EIP150:         chain.NewFork(0),
@@ -470,6 +489,15 @@ var Forks = map[string]*chain.Forks{
 		Constantinople: chain.NewFork(0),
 		Petersburg:     chain.NewFork(0),
 	},
+	"Petersburg": {
+		Homestead:      chain.NewFork(0),
+		EIP150:         chain.NewFork(0),
+		EIP155:         chain.NewFork(0),
+		EIP158:         chain.NewFork(0),
+		Byzantium:      chain.NewFork(0),
+		Constantinople: chain.NewFork(0),
+		Petersburg:     chain.NewFork(0),
+	},
 }
 
 func contains(l []string, name string) bool {
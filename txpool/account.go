@@ -1,6 +1,8 @@
 package txpool
 
 import (
+	"math/big"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -162,6 +164,34 @@ type account struct {
 
 	//	maximum number of enqueued transactions
 	maxEnqueued uint64
+
+	// flag indicating if the account submitted transactions
+	// through the local endpoints, protecting them from eviction
+	local uint32
+}
+
+// txs returns copies of the promoted and enqueued transactions of the account, sorted by nonce.
+func (a *account) txs() (promoted, enqueued []*types.Transaction) {
+	a.promoted.lock(false)
+	a.enqueued.lock(false)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	promoted = append(promoted, a.promoted.queue...)
+	enqueued = append(enqueued, a.enqueued.queue...)
+
+	sort.Slice(promoted, func(i, j int) bool {
+		return promoted[i].Nonce < promoted[j].Nonce
+	})
+
+	sort.Slice(enqueued, func(i, j int) bool {
+		return enqueued[i].Nonce < enqueued[j].Nonce
+	})
+
+	return
 }
 
 // getNonce returns the next expected nonce for this account.
@@ -174,6 +204,16 @@ func (a *account) setNonce(nonce uint64) {
 	atomic.StoreUint64(&a.nextNonce, nonce)
 }
 
+// isLocal returns true if the account submitted local transactions.
+func (a *account) isLocal() bool {
+	return atomic.LoadUint32(&a.local) == 1
+}
+
+// markLocal protects the account's transactions from eviction.
+func (a *account) markLocal() {
+	atomic.StoreUint32(&a.local, 1)
+}
+
 // Demotions returns the current value of demotions
 func (a *account) Demotions() uint64 {
 	return a.demotions
@@ -229,25 +269,96 @@ func (a *account) reset(nonce uint64, promoteCh chan<- promoteRequest) (
 }
 
 // enqueue attempts tp push the transaction onto the enqueued queue.
-func (a *account) enqueue(tx *types.Transaction) error {
+// A transaction with the nonce of an enqueued or promoted one replaces it
+// if its gas price is higher by at least priceBump percent. The replaced
+// transaction is returned, along with the flag indicating if it was promoted.
+func (a *account) enqueue(tx *types.Transaction, priceBump uint64) (
+	replaced *types.Transaction,
+	wasPromoted bool,
+	err error,
+) {
+	a.promoted.lock(true)
 	a.enqueued.lock(true)
-	defer a.enqueued.unlock()
 
-	if a.enqueued.length() == a.maxEnqueued {
-		return ErrMaxEnqueuedLimitReached
-	}
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
 
-	// reject low nonce tx
+	// low nonce tx can only replace a promoted one
 	if tx.Nonce < a.getNonce() {
-		return ErrNonceTooLow
+		if a.promoted.get(tx.Nonce) == nil {
+			return nil, false, ErrNonceTooLow
+		}
+
+		replaced, err = replaceTx(a.promoted, tx, priceBump)
+
+		return replaced, true, err
+	}
+
+	if a.enqueued.get(tx.Nonce) != nil {
+		replaced, err = replaceTx(a.enqueued, tx, priceBump)
+
+		return replaced, false, err
+	}
+
+	if a.enqueued.length() == a.maxEnqueued {
+		return nil, false, ErrMaxEnqueuedLimitReached
 	}
 
 	// enqueue tx
 	a.enqueued.push(tx)
 
+	return nil, false, nil
+}
+
+// validateReplacement checks if the transaction would be accepted
+// as a replacement of an enqueued or promoted one with the same nonce.
+func (a *account) validateReplacement(tx *types.Transaction, priceBump uint64) error {
+	a.promoted.lock(false)
+	a.enqueued.lock(false)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	old := a.promoted.get(tx.Nonce)
+	if old == nil {
+		old = a.enqueued.get(tx.Nonce)
+	}
+
+	// the same transaction is rejected as already known
+	if old != nil && old.Hash != tx.Hash && !isReplacementPriced(old, tx, priceBump) {
+		return ErrReplaceUnderpriced
+	}
+
 	return nil
 }
 
+// replaceTx replaces the queued transaction having the same nonce,
+// if the new one is priced high enough. Assumes the queue lock is held.
+func replaceTx(queue *accountQueue, tx *types.Transaction, priceBump uint64) (*types.Transaction, error) {
+	if !isReplacementPriced(queue.get(tx.Nonce), tx, priceBump) {
+		return nil, ErrReplaceUnderpriced
+	}
+
+	return queue.replace(tx), nil
+}
+
+// isReplacementPriced checks if the gas price of the new transaction
+// is higher than the old one's by at least priceBump percent
+func isReplacementPriced(oldTx, newTx *types.Transaction, priceBump uint64) bool {
+	if newTx.GasPrice.Cmp(oldTx.GasPrice) <= 0 {
+		return false
+	}
+
+	threshold := new(big.Int).Mul(oldTx.GasPrice, new(big.Int).SetUint64(100+priceBump))
+	threshold.Div(threshold, big.NewInt(100))
+
+	return newTx.GasPrice.Cmp(threshold) >= 0
+}
+
 // Promote moves eligible transactions from enqueued to promoted.
 //
 // Eligible transactions are all sequential in order of nonce
@@ -304,6 +415,115 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 	return
 }
 
+// evictionCandidates returns all transactions of the account,
+// in the order they can be evicted without creating nonce gaps.
+func (a *account) evictionCandidates() []*types.Transaction {
+	a.promoted.lock(false)
+	a.enqueued.lock(false)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	enqueued := sortedByNonceDesc(a.enqueued.queue)
+	promoted := sortedByNonceDesc(a.promoted.queue)
+
+	return append(enqueued, promoted...)
+}
+
+// evict removes the given transaction if it is still the highest nonce one
+// of the account. Evicting the last promoted transaction rolls back the nonce.
+func (a *account) evict(tx *types.Transaction) (evicted bool, wasPromoted bool) {
+	a.promoted.lock(true)
+	a.enqueued.lock(true)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	if last := a.enqueued.last(); last != nil {
+		if last.Hash != tx.Hash {
+			return false, false
+		}
+
+		a.enqueued.removeLast()
+
+		return true, false
+	}
+
+	if last := a.promoted.last(); last == nil || last.Hash != tx.Hash {
+		return false, false
+	}
+
+	a.promoted.removeLast()
+	a.setNonce(tx.Nonce)
+
+	return true, true
+}
+
+// sortedByNonceDesc returns a copy of the transactions sorted by nonce (descending).
+func sortedByNonceDesc(txs []*types.Transaction) []*types.Transaction {
+	sorted := make([]*types.Transaction, len(txs))
+	copy(sorted, txs)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Nonce > sorted[j].Nonce
+	})
+
+	return sorted
+}
+
+// pruneExpired removes the expired transactions of the account. The promoted
+// transactions following an expired one are moved back to enqueued, as they
+// can't be executed before the missing nonce, and the nonce is rolled back.
+// The enqueued transactions beyond maxEnqueued are then dropped, highest nonces first.
+func (a *account) pruneExpired(isExpired func(*types.Transaction) bool) (
+	prunedPromoted,
+	prunedEnqueued,
+	demoted,
+	dropped []*types.Transaction,
+) {
+	a.promoted.lock(true)
+	a.enqueued.lock(true)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	prunedEnqueued = a.enqueued.removeIf(isExpired)
+	prunedPromoted = a.promoted.removeIf(isExpired)
+
+	if len(prunedPromoted) == 0 {
+		return
+	}
+
+	lowestNonce := prunedPromoted[0].Nonce
+	for _, tx := range prunedPromoted {
+		if tx.Nonce < lowestNonce {
+			lowestNonce = tx.Nonce
+		}
+	}
+
+	demoted = a.promoted.removeIf(func(tx *types.Transaction) bool {
+		return tx.Nonce > lowestNonce
+	})
+
+	for _, tx := range demoted {
+		a.enqueued.push(tx)
+	}
+
+	for a.enqueued.length() > a.maxEnqueued {
+		dropped = append(dropped, a.enqueued.removeLast())
+	}
+
+	a.setNonce(lowestNonce)
+
+	return
+}
+
 // resetSkips sets 0 to skips
 func (a *account) resetSkips() {
 	a.skips = 0
@@ -0,0 +1,237 @@
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/SECRYPT-2022/SECRYPT/helper/keccak"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+)
+
+const (
+	// maximum number of transactions in a bundle
+	maxBundleTxs = 16
+
+	// maximum number of bundles kept by the pool
+	maxBundles = 256
+)
+
+var (
+	ErrEmptyBundle       = errors.New("bundle has no transactions")
+	ErrBundleTooLarge    = errors.New("bundle has too many transactions")
+	ErrBundlePoolFull    = errors.New("bundle pool is full")
+	ErrBundleKnown       = errors.New("bundle already known")
+	ErrBundleBlockPassed = errors.New("bundle target block already passed")
+)
+
+// Bundle is an ordered list of transactions,
+// included all together and in order in the target block, or not at all
+type Bundle struct {
+	Hash        types.Hash
+	Txs         []*types.Transaction
+	BlockNumber uint64
+
+	// transactions allowed to revert without discarding the bundle
+	revertingTxHashes map[types.Hash]struct{}
+
+	// arrival order of the bundle
+	seq uint64
+}
+
+// NewBundle creates a bundle of the given transactions, whose hashes are already computed
+func NewBundle(txs []*types.Transaction, blockNumber uint64, revertingTxHashes []types.Hash) *Bundle {
+	bundle := &Bundle{
+		Hash:              bundleHash(txs),
+		Txs:               txs,
+		BlockNumber:       blockNumber,
+		revertingTxHashes: make(map[types.Hash]struct{}, len(revertingTxHashes)),
+	}
+
+	for _, hash := range revertingTxHashes {
+		bundle.revertingTxHashes[hash] = struct{}{}
+	}
+
+	return bundle
+}
+
+// CanRevert returns true if the given bundle transaction is allowed to revert
+func (b *Bundle) CanRevert(txHash types.Hash) bool {
+	_, ok := b.revertingTxHashes[txHash]
+
+	return ok
+}
+
+// bundleHash returns the hash of the bundle, made of its transaction hashes
+func bundleHash(txs []*types.Transaction) types.Hash {
+	buf := make([]byte, 0, len(txs)*types.HashLength)
+	for _, tx := range txs {
+		buf = append(buf, tx.Hash.Bytes()...)
+	}
+
+	return types.BytesToHash(keccak.Keccak256(nil, buf))
+}
+
+// bundleStore keeps the bundles waiting for their target block
+type bundleStore struct {
+	sync.RWMutex
+
+	bundles map[types.Hash]*Bundle
+	nextSeq uint64
+}
+
+func newBundleStore() *bundleStore {
+	return &bundleStore{
+		bundles: make(map[types.Hash]*Bundle),
+	}
+}
+
+func (s *bundleStore) add(bundle *Bundle) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.bundles[bundle.Hash]; ok {
+		return ErrBundleKnown
+	}
+
+	if len(s.bundles) >= maxBundles {
+		return ErrBundlePoolFull
+	}
+
+	bundle.seq = s.nextSeq
+	s.nextSeq++
+
+	s.bundles[bundle.Hash] = bundle
+
+	return nil
+}
+
+func (s *bundleStore) remove(hash types.Hash) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.bundles, hash)
+}
+
+// forBlock returns the bundles targeting the given block, in arrival order
+func (s *bundleStore) forBlock(number uint64) []*Bundle {
+	s.RLock()
+	defer s.RUnlock()
+
+	bundles := make([]*Bundle, 0)
+
+	for _, bundle := range s.bundles {
+		if bundle.BlockNumber == number {
+			bundles = append(bundles, bundle)
+		}
+	}
+
+	sort.Slice(bundles, func(i, j int) bool {
+		return bundles[i].seq < bundles[j].seq
+	})
+
+	return bundles
+}
+
+// prune drops the bundles targeting the given block or an earlier one
+func (s *bundleStore) prune(number uint64) {
+	s.Lock()
+	defer s.Unlock()
+
+	for hash, bundle := range s.bundles {
+		if bundle.BlockNumber <= number {
+			delete(s.bundles, hash)
+		}
+	}
+}
+
+// AddBundle adds a new bundle of transactions (sent from json-RPC endpoints),
+// tried by the proposer of the target block before the pool transactions.
+// The bundle transactions aren't added to the pool, nor gossiped.
+func (p *TxPool) AddBundle(
+	txs []*types.Transaction,
+	blockNumber uint64,
+	revertingTxHashes []types.Hash,
+) (types.Hash, error) {
+	if len(txs) == 0 {
+		return types.ZeroHash, ErrEmptyBundle
+	}
+
+	if len(txs) > maxBundleTxs {
+		return types.ZeroHash, ErrBundleTooLarge
+	}
+
+	if blockNumber <= p.store.Header().Number {
+		return types.ZeroHash, ErrBundleBlockPassed
+	}
+
+	for _, tx := range txs {
+		if err := p.validateBundleTx(tx); err != nil {
+			return types.ZeroHash, fmt.Errorf("invalid bundle tx %s, %w", tx.Hash, err)
+		}
+	}
+
+	bundle := NewBundle(txs, blockNumber, revertingTxHashes)
+
+	if err := p.bundles.add(bundle); err != nil {
+		return types.ZeroHash, err
+	}
+
+	p.logger.Debug("bundle added", "hash", bundle.Hash, "block", blockNumber, "txs", len(txs))
+
+	return bundle.Hash, nil
+}
+
+// validateBundleTx runs the checks of a bundle transaction which don't depend on the execution
+// of the previous transactions, the others are left to the simulation by the proposer
+func (p *TxPool) validateBundleTx(tx *types.Transaction) error {
+	tx.ComputeHash()
+
+	if uint64(len(tx.MarshalRLP())) > txMaxSize {
+		return ErrOversizedData
+	}
+
+	if tx.Value.Sign() < 0 {
+		return ErrNegativeValue
+	}
+
+	from, err := p.signer.Sender(tx)
+	if err != nil {
+		return ErrExtractSignature
+	}
+
+	if tx.From != types.ZeroAddress && tx.From != from {
+		return ErrInvalidSender
+	}
+
+	tx.From = from
+
+	header := p.store.Header()
+
+	if tx.ExceedsBlockGasLimit(header.GasLimit) {
+		return ErrBlockLimitExceeded
+	}
+
+	// the bundles don't bypass the price limit of the pool
+	if tx.IsUnderpriced(p.priceLimit) {
+		return ErrUnderpriced
+	}
+
+	// the nonce can only grow from the latest state
+	if p.store.GetNonce(header.StateRoot, tx.From) > tx.Nonce {
+		return ErrNonceTooLow
+	}
+
+	return nil
+}
+
+// Bundles returns the bundles targeting the given block, in arrival order
+func (p *TxPool) Bundles(blockNumber uint64) []*Bundle {
+	return p.bundles.forBlock(blockNumber)
+}
+
+// RemoveBundle discards the given bundle
+func (p *TxPool) RemoveBundle(hash types.Hash) {
+	p.bundles.remove(hash)
+}
@@ -0,0 +1,162 @@
+package txpool
+
+import (
+	"testing"
+
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddBundle(t *testing.T) {
+	t.Parallel()
+
+	head := &types.Header{
+		Number:   10,
+		GasLimit: mockHeader.GasLimit,
+	}
+
+	key := new(eoa).create(t)
+
+	setupPool := func(t *testing.T) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool(defaultMockStore{DefaultHeader: head})
+		assert.NoError(t, err)
+
+		pool.SetSigner(signerEIP155)
+
+		return pool
+	}
+
+	newBundleTxs := func(t *testing.T, count int) []*types.Transaction {
+		t.Helper()
+
+		txs := make([]*types.Transaction, count)
+		for i := range txs {
+			txs[i] = newJournalTx(t, key, uint64(i))
+		}
+
+		return txs
+	}
+
+	t.Run("bundle added", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+		txs := newBundleTxs(t, 2)
+
+		hash, err := pool.AddBundle(txs, head.Number+1, []types.Hash{txs[1].Hash})
+		assert.NoError(t, err)
+		assert.Equal(t, bundleHash(txs), hash)
+
+		bundles := pool.Bundles(head.Number + 1)
+		assert.Len(t, bundles, 1)
+		assert.Equal(t, txs, bundles[0].Txs)
+		assert.False(t, bundles[0].CanRevert(txs[0].Hash))
+		assert.True(t, bundles[0].CanRevert(txs[1].Hash))
+
+		// the bundle txs don't enter the pool
+		assert.Len(t, pool.index.list(), 0)
+
+		_, err = pool.AddBundle(txs, head.Number+1, nil)
+		assert.ErrorIs(t, err, ErrBundleKnown)
+	})
+
+	testCases := []struct {
+		name        string
+		txs         int
+		blockNumber uint64
+		expected    error
+	}{
+		{"empty bundle", 0, head.Number + 1, ErrEmptyBundle},
+		{"too many txs", maxBundleTxs + 1, head.Number + 1, ErrBundleTooLarge},
+		{"passed block", 1, head.Number, ErrBundleBlockPassed},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t)
+
+			_, err := pool.AddBundle(newBundleTxs(t, tc.txs), tc.blockNumber, nil)
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Len(t, pool.Bundles(tc.blockNumber), 0)
+		})
+	}
+
+	t.Run("rejects unsigned tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		tx := newTx(key.Address, 0, 1)
+
+		_, err := pool.AddBundle([]*types.Transaction{tx}, head.Number+1, nil)
+		assert.ErrorIs(t, err, ErrExtractSignature)
+	})
+
+	t.Run("rejects underpriced tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		tx := newTx(key.Address, 0, 1)
+		tx.GasPrice.SetUint64(defaultPriceLimit - 1)
+
+		tx = key.signTx(tx, signerEIP155)
+
+		_, err := pool.AddBundle([]*types.Transaction{tx}, head.Number+1, nil)
+		assert.ErrorIs(t, err, ErrUnderpriced)
+		assert.Len(t, pool.Bundles(head.Number+1), 0)
+	})
+
+	t.Run("rejects tx with nonce below the state nonce", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool(nonceMockStore{
+			defaultMockStore: defaultMockStore{DefaultHeader: head},
+			nonces:           map[types.Address]uint64{key.Address: 1},
+		})
+		assert.NoError(t, err)
+
+		pool.SetSigner(signerEIP155)
+
+		_, err = pool.AddBundle(newBundleTxs(t, 2), head.Number+1, nil)
+		assert.ErrorIs(t, err, ErrNonceTooLow)
+		assert.Len(t, pool.Bundles(head.Number+1), 0)
+	})
+
+	t.Run("bundles ordered by arrival and pruned", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+		txs := newBundleTxs(t, 3)
+
+		first, err := pool.AddBundle(txs[2:], head.Number+1, nil)
+		assert.NoError(t, err)
+
+		second, err := pool.AddBundle(txs[:2], head.Number+1, nil)
+		assert.NoError(t, err)
+
+		later, err := pool.AddBundle(txs[:1], head.Number+2, nil)
+		assert.NoError(t, err)
+
+		bundles := pool.Bundles(head.Number + 1)
+		assert.Len(t, bundles, 2)
+		assert.Equal(t, first, bundles[0].Hash)
+		assert.Equal(t, second, bundles[1].Hash)
+
+		pool.RemoveBundle(first)
+		assert.Len(t, pool.Bundles(head.Number+1), 1)
+
+		pool.bundles.prune(head.Number + 1)
+		assert.Len(t, pool.Bundles(head.Number+1), 0)
+
+		bundles = pool.Bundles(head.Number + 2)
+		assert.Len(t, bundles, 1)
+		assert.Equal(t, later, bundles[0].Hash)
+	})
+}
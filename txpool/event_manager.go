@@ -16,6 +16,9 @@ type eventManager struct {
 	subscriptionsLock sync.RWMutex
 	numSubscriptions  int64
 	logger            hclog.Logger
+
+	// lifecycle history of the transactions
+	history *txHistory
 }
 
 func newEventManager(logger hclog.Logger) *eventManager {
@@ -23,6 +26,7 @@ func newEventManager(logger hclog.Logger) *eventManager {
 		logger:           logger.Named("event-manager"),
 		subscriptions:    make(map[subscriptionID]*eventSubscription),
 		numSubscriptions: 0,
+		history:          newTxHistory(),
 	}
 }
 
@@ -87,6 +91,14 @@ func (em *eventManager) Close() {
 
 // signalEvent is a helper method for alerting listeners of a new TxPool event
 func (em *eventManager) signalEvent(eventType proto.EventType, txHashes ...types.Hash) {
+	em.signalEventWithReason(eventType, defaultEventReasons[eventType], txHashes...)
+}
+
+// signalEventWithReason alerts listeners of a new TxPool event,
+// recorded along with its reason in the transactions' history
+func (em *eventManager) signalEventWithReason(eventType proto.EventType, reason string, txHashes ...types.Hash) {
+	em.history.record(eventTypeName(eventType), reason, txHashes...)
+
 	if atomic.LoadInt64(&em.numSubscriptions) < 1 {
 		// No reason to lock the subscriptions map
 		// if no subscriptions exist
@@ -105,3 +117,13 @@ func (em *eventManager) signalEvent(eventType proto.EventType, txHashes ...types
 		}
 	}
 }
+
+// recordRejection records the rejection of the transaction in its history
+func (em *eventManager) recordRejection(txHash types.Hash, err error) {
+	em.history.record(rejectedEventType, err.Error(), txHash)
+}
+
+// getHistory returns the recorded lifecycle events of the transaction
+func (em *eventManager) getHistory(txHash types.Hash) []*TxEvent {
+	return em.history.get(txHash)
+}
@@ -0,0 +1,117 @@
+package txpool
+
+import (
+	"github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/armon/go-metrics"
+)
+
+// evictionCandidate holds the transactions of a remote account
+// in the order they can be evicted (highest nonce first)
+type evictionCandidate struct {
+	account *account
+	txs     []*types.Transaction
+}
+
+// evictUnderpriced tries to make room for the given transaction when the pool is full,
+// by evicting the lowest priced remote transactions cheaper than it. Each account is
+// evicted starting from its highest nonce, so no nonce gaps are created. Transactions
+// of local accounts are never evicted. Returns true if the transaction fits afterwards.
+func (p *TxPool) evictUnderpriced(tx *types.Transaction) bool {
+	required := slotsRequired(tx)
+	if required > p.gauge.max {
+		return false
+	}
+
+	candidates := p.evictionCandidates(tx.From)
+
+	var (
+		victims []*types.Transaction
+		owners  []*account
+		freed   uint64
+	)
+
+	// select the victims before evicting anything,
+	// so nothing is evicted if there isn't enough room to make
+	for p.gauge.read()+required > p.gauge.max+freed {
+		cheapest := -1
+
+		for idx, candidate := range candidates {
+			if len(candidate.txs) == 0 {
+				continue
+			}
+
+			if cheapest < 0 ||
+				candidate.txs[0].GasPrice.Cmp(candidates[cheapest].txs[0].GasPrice) < 0 {
+				cheapest = idx
+			}
+		}
+
+		if cheapest < 0 || candidates[cheapest].txs[0].GasPrice.Cmp(tx.GasPrice) >= 0 {
+			return false
+		}
+
+		victim := candidates[cheapest].txs[0]
+		candidates[cheapest].txs = candidates[cheapest].txs[1:]
+
+		victims = append(victims, victim)
+		owners = append(owners, candidates[cheapest].account)
+		freed += slotsRequired(victim)
+	}
+
+	var (
+		evicted         []*types.Transaction
+		evictedPromoted int64
+	)
+
+	for idx, victim := range victims {
+		ok, wasPromoted := owners[idx].evict(victim)
+		if !ok {
+			// the account changed in the meantime
+			continue
+		}
+
+		if wasPromoted {
+			evictedPromoted++
+		}
+
+		evicted = append(evicted, victim)
+	}
+
+	if len(evicted) > 0 {
+		p.index.remove(evicted...)
+		p.gauge.decrease(slotsRequired(evicted...))
+		p.updatePending(-evictedPromoted)
+
+		metrics.IncrCounter([]string{txPoolMetrics, "evicted_transactions"}, float32(len(evicted)))
+
+		p.eventManager.signalEvent(proto.EventType_EVICTED, toHash(evicted...)...)
+		p.logger.Debug("evicted underpriced txs", "num", len(evicted), "hash", tx.Hash.String())
+	}
+
+	return p.gauge.read()+required <= p.gauge.max
+}
+
+// evictionCandidates collects the transactions of all remote accounts,
+// except the given one whose transactions could be gapped by the new one.
+func (p *TxPool) evictionCandidates(exclude types.Address) (candidates []*evictionCandidate) {
+	p.accounts.Range(func(key, value interface{}) bool {
+		addr, _ := key.(types.Address)
+		account, _ := value.(*account)
+
+		if addr == exclude || account.isLocal() {
+			return true
+		}
+
+		if txs := account.evictionCandidates(); len(txs) > 0 {
+			candidates = append(candidates, &evictionCandidate{
+				account: account,
+				txs:     txs,
+			})
+		}
+
+		return true
+	})
+
+	return
+}
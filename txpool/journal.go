@@ -0,0 +1,280 @@
+package txpool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/SECRYPT-2022/SECRYPT/types"
+)
+
+// journalSizeLength is the length of the size prefix of each journal entry
+const journalSizeLength = 4
+
+var errCorruptedJournal = errors.New("corrupted journal entry")
+
+// txJournal is a file of RLP encoded transactions, which keeps
+// the pool transactions across node restarts. Each entry is prefixed
+// with its size, so the file can be appended to on every added transaction.
+type txJournal struct {
+	sync.Mutex
+
+	path string
+
+	// writer appends the new transactions,
+	// it is only opened once the journal is rotated
+	writer *os.File
+
+	// rotating is set while the journal is rewritten, the transactions
+	// inserted meanwhile are kept in pending to be appended to the new journal
+	rotating bool
+	pending  []*types.Transaction
+
+	// closed is set once the journal is closed, it isn't reopened by the rotations
+	closed bool
+}
+
+func newTxJournal(path string) *txJournal {
+	return &txJournal{
+		path: path,
+	}
+}
+
+// load reads all transactions from the journal file. If the file
+// ends with a corrupted entry, the transactions read so far are returned
+// along with the error.
+func (j *txJournal) load() ([]*types.Transaction, error) {
+	file, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	var (
+		reader = bufio.NewReader(file)
+		txs    = make([]*types.Transaction, 0)
+		size   = make([]byte, journalSizeLength)
+	)
+
+	for {
+		if _, err := io.ReadFull(reader, size); err != nil {
+			if errors.Is(err, io.EOF) {
+				return txs, nil
+			}
+
+			return txs, fmt.Errorf("%w: %v", errCorruptedJournal, err)
+		}
+
+		length := binary.BigEndian.Uint32(size)
+		if length > txMaxSize {
+			return txs, fmt.Errorf("%w: oversized entry of %d bytes", errCorruptedJournal, length)
+		}
+
+		raw := make([]byte, length)
+		if _, err := io.ReadFull(reader, raw); err != nil {
+			return txs, fmt.Errorf("%w: %v", errCorruptedJournal, err)
+		}
+
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			return txs, fmt.Errorf("%w: %v", errCorruptedJournal, err)
+		}
+
+		txs = append(txs, tx)
+	}
+}
+
+// insert appends the transaction to the journal. It is a no-op
+// until the journal has been rotated for the first time.
+func (j *txJournal) insert(tx *types.Transaction) error {
+	j.Lock()
+	defer j.Unlock()
+
+	if j.writer == nil {
+		return nil
+	}
+
+	if j.rotating {
+		j.pending = append(j.pending, tx)
+	}
+
+	_, err := j.writer.Write(encodeJournalEntry(tx))
+
+	return err
+}
+
+// beginRotation starts keeping the inserted transactions for the next rotation,
+// returning false if a rotation is already running. It has to be called before
+// taking the snapshot of the transactions to rotate, so none of them is lost
+func (j *txJournal) beginRotation() bool {
+	j.Lock()
+	defer j.Unlock()
+
+	if j.rotating {
+		return false
+	}
+
+	j.rotating = true
+	j.pending = nil
+
+	return true
+}
+
+// rotate replaces the journal with the given transactions,
+// and opens it for appending the new ones. The new journal is written
+// without holding the lock, the transactions inserted meanwhile being
+// appended to it once written
+func (j *txJournal) rotate(txs []*types.Transaction) error {
+	newPath := j.path + ".new"
+
+	writeErr := writeJournalFile(newPath, txs)
+
+	j.Lock()
+	defer j.Unlock()
+
+	pending := j.pending
+	j.rotating = false
+	j.pending = nil
+
+	if writeErr != nil {
+		return writeErr
+	}
+
+	if err := appendJournalFile(newPath, txs, pending); err != nil {
+		return err
+	}
+
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return err
+		}
+
+		j.writer = nil
+	}
+
+	if err := os.Rename(newPath, j.path); err != nil {
+		return err
+	}
+
+	if j.closed {
+		return nil
+	}
+
+	writer, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	j.writer = writer
+
+	return nil
+}
+
+// isActive returns true if the journal has been rotated and accepts new transactions
+func (j *txJournal) isActive() bool {
+	j.Lock()
+	defer j.Unlock()
+
+	return j.writer != nil
+}
+
+// close closes the journal file
+func (j *txJournal) close() error {
+	j.Lock()
+	defer j.Unlock()
+
+	j.closed = true
+
+	if j.writer == nil {
+		return nil
+	}
+
+	err := j.writer.Close()
+	j.writer = nil
+
+	return err
+}
+
+// writeJournalFile writes the transactions to a new file at the given path
+func writeJournalFile(path string, txs []*types.Transaction) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	return writeJournalEntries(file, txs)
+}
+
+// appendJournalFile appends the pending transactions which aren't already written
+// to the file at the given path
+func appendJournalFile(path string, written, pending []*types.Transaction) error {
+	if len(pending) == 0 {
+		return nil
+	}
+
+	writtenHashes := make(map[types.Hash]struct{}, len(written))
+	for _, tx := range written {
+		writtenHashes[tx.Hash] = struct{}{}
+	}
+
+	txs := make([]*types.Transaction, 0, len(pending))
+
+	for _, tx := range pending {
+		if _, ok := writtenHashes[tx.Hash]; !ok {
+			txs = append(txs, tx)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	return writeJournalEntries(file, txs)
+}
+
+// writeJournalEntries writes the transactions to the file, then syncs and closes it
+func writeJournalEntries(file *os.File, txs []*types.Transaction) error {
+	writer := bufio.NewWriter(file)
+
+	for _, tx := range txs {
+		if _, err := writer.Write(encodeJournalEntry(tx)); err != nil {
+			file.Close()
+
+			return err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+// encodeJournalEntry returns the size prefixed RLP encoding of the transaction
+func encodeJournalEntry(tx *types.Transaction) []byte {
+	raw := tx.MarshalRLP()
+
+	entry := make([]byte, journalSizeLength, journalSizeLength+len(raw))
+	binary.BigEndian.PutUint32(entry, uint32(len(raw)))
+
+	return append(entry, raw...)
+}
@@ -0,0 +1,220 @@
+package txpool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newJournalTx(t *testing.T, key *eoa, nonce uint64) *types.Transaction {
+	t.Helper()
+
+	tx := key.signTx(newTx(key.Address, nonce, 1), signerEIP155)
+	tx.ComputeHash()
+
+	return tx
+}
+
+func TestTxJournal(t *testing.T) {
+	t.Parallel()
+
+	key := new(eoa).create(t)
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		journal := newTxJournal(filepath.Join(t.TempDir(), "journal"))
+
+		txs, err := journal.load()
+		assert.NoError(t, err)
+		assert.Len(t, txs, 0)
+	})
+
+	t.Run("insert is a no-op before rotating", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "journal")
+		journal := newTxJournal(path)
+
+		assert.False(t, journal.isActive())
+		assert.NoError(t, journal.insert(newJournalTx(t, key, 0)))
+
+		_, err := os.Stat(path)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("rotate and insert", func(t *testing.T) {
+		t.Parallel()
+
+		journal := newTxJournal(filepath.Join(t.TempDir(), "journal"))
+		defer journal.close()
+
+		tx0 := newJournalTx(t, key, 0)
+		tx1 := newJournalTx(t, key, 1)
+		tx2 := newJournalTx(t, key, 2)
+
+		assert.NoError(t, journal.rotate([]*types.Transaction{tx0, tx1}))
+		assert.True(t, journal.isActive())
+		assert.NoError(t, journal.insert(tx2))
+
+		txs, err := journal.load()
+		assert.NoError(t, err)
+		assert.Equal(t, []types.Hash{tx0.Hash, tx1.Hash, tx2.Hash}, toHash(txs...))
+
+		// compaction drops the transactions not passed
+		assert.NoError(t, journal.rotate([]*types.Transaction{tx2}))
+
+		txs, err = journal.load()
+		assert.NoError(t, err)
+		assert.Equal(t, []types.Hash{tx2.Hash}, toHash(txs...))
+	})
+
+	t.Run("insert while rotating", func(t *testing.T) {
+		t.Parallel()
+
+		journal := newTxJournal(filepath.Join(t.TempDir(), "journal"))
+		defer journal.close()
+
+		tx0 := newJournalTx(t, key, 0)
+		tx1 := newJournalTx(t, key, 1)
+		tx2 := newJournalTx(t, key, 2)
+
+		assert.NoError(t, journal.rotate([]*types.Transaction{tx0}))
+
+		// the transactions inserted after the snapshot are kept by the rotation
+		assert.True(t, journal.beginRotation())
+		assert.False(t, journal.beginRotation())
+
+		assert.NoError(t, journal.insert(tx1))
+		assert.NoError(t, journal.insert(tx2))
+
+		assert.NoError(t, journal.rotate([]*types.Transaction{tx0, tx1}))
+
+		txs, err := journal.load()
+		assert.NoError(t, err)
+		assert.Equal(t, []types.Hash{tx0.Hash, tx1.Hash, tx2.Hash}, toHash(txs...))
+
+		// the next rotations don't keep them anymore
+		assert.True(t, journal.beginRotation())
+		assert.NoError(t, journal.rotate([]*types.Transaction{tx0}))
+
+		txs, err = journal.load()
+		assert.NoError(t, err)
+		assert.Equal(t, []types.Hash{tx0.Hash}, toHash(txs...))
+	})
+
+	t.Run("truncated entry", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "journal")
+		tx0 := newJournalTx(t, key, 0)
+		tx1 := newJournalTx(t, key, 1)
+
+		entry := encodeJournalEntry(tx1)
+		data := append(encodeJournalEntry(tx0), entry[:len(entry)/2]...)
+
+		assert.NoError(t, os.WriteFile(path, data, 0600))
+
+		txs, err := newTxJournal(path).load()
+		assert.ErrorIs(t, err, errCorruptedJournal)
+		assert.Equal(t, []types.Hash{tx0.Hash}, toHash(txs...))
+	})
+}
+
+func TestLoadJournal(t *testing.T) {
+	t.Parallel()
+
+	var (
+		localKey  = new(eoa).create(t)
+		remoteKey = new(eoa).create(t)
+	)
+
+	newJournaledPool := func(t *testing.T, path string, journalRemotes bool) *TxPool {
+		t.Helper()
+
+		pool, err := NewTxPool(
+			hclog.NewNullLogger(),
+			forks.At(0),
+			defaultMockStore{DefaultHeader: mockHeader},
+			nil,
+			nil,
+			&Config{
+				PriceLimit:         defaultPriceLimit,
+				MaxSlots:           defaultMaxSlots,
+				MaxAccountEnqueued: defaultMaxAccountEnqueued,
+				JournalPath:        path,
+				JournalRemotes:     journalRemotes,
+			},
+		)
+		assert.NoError(t, err)
+
+		pool.SetSigner(signerEIP155)
+		pool.Start()
+
+		return pool
+	}
+
+	waitPromoted := func(t *testing.T, pool *TxPool, count int, add func()) {
+		t.Helper()
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
+		defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+		add()
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancelFn()
+
+		assert.Len(t, waitForEvents(ctx, subscription, count), count)
+	}
+
+	testCases := []struct {
+		name           string
+		journalRemotes bool
+		expectedLocals uint64
+		expectedRemote uint64
+	}{
+		{"local txs only", false, 2, 0},
+		{"all txs", true, 2, 1},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "journal")
+
+			// fill the first pool
+			pool := newJournaledPool(t, path, tc.journalRemotes)
+			assert.NoError(t, pool.LoadJournal())
+
+			waitPromoted(t, pool, 3, func() {
+				assert.NoError(t, pool.addTx(local, newJournalTx(t, localKey, 0)))
+				assert.NoError(t, pool.addTx(local, newJournalTx(t, localKey, 1)))
+				assert.NoError(t, pool.addTx(gossip, newJournalTx(t, remoteKey, 0)))
+			})
+
+			pool.Close()
+
+			// the restarted pool gets the journaled transactions back
+			restarted := newJournaledPool(t, path, tc.journalRemotes)
+			defer restarted.Close()
+
+			waitPromoted(t, restarted, int(tc.expectedLocals+tc.expectedRemote), func() {
+				assert.NoError(t, restarted.LoadJournal())
+			})
+
+			assert.Equal(t, tc.expectedLocals+tc.expectedRemote, restarted.Length())
+			assert.Equal(t, tc.expectedLocals, restarted.accounts.get(localKey.Address).promoted.length())
+		})
+	}
+}
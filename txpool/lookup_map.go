@@ -2,6 +2,7 @@ package txpool
 
 import (
 	"sync"
+	"time"
 
 	"github.com/SECRYPT-2022/SECRYPT/types"
 )
@@ -10,6 +11,16 @@ import (
 type lookupMap struct {
 	sync.RWMutex
 	all map[types.Hash]*types.Transaction
+
+	// arrival time of each transaction, used for the lifetime
+	arrivals map[types.Hash]time.Time
+}
+
+func newLookupMap() lookupMap {
+	return lookupMap{
+		all:      make(map[types.Hash]*types.Transaction),
+		arrivals: make(map[types.Hash]time.Time),
+	}
 }
 
 // add inserts the given transaction into the map. Returns false
@@ -23,6 +34,7 @@ func (m *lookupMap) add(tx *types.Transaction) bool {
 	}
 
 	m.all[tx.Hash] = tx
+	m.arrivals[tx.Hash] = time.Now()
 
 	return true
 }
@@ -34,9 +46,33 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 
 	for _, tx := range txs {
 		delete(m.all, tx.Hash)
+		delete(m.arrivals, tx.Hash)
 	}
 }
 
+// list returns all transactions present in the map. [thread-safe]
+func (m *lookupMap) list() []*types.Transaction {
+	m.RLock()
+	defer m.RUnlock()
+
+	txs := make([]*types.Transaction, 0, len(m.all))
+	for _, tx := range m.all {
+		txs = append(txs, tx)
+	}
+
+	return txs
+}
+
+// arrival returns the time the transaction entered the map. [thread-safe]
+func (m *lookupMap) arrival(hash types.Hash) (time.Time, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	arrival, ok := m.arrivals[hash]
+
+	return arrival, ok
+}
+
 // get returns the transaction associated with the given hash. [thread-safe]
 func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 	m.RLock()
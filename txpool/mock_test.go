@@ -41,6 +41,32 @@ func (m defaultMockStore) GetBalance(types.Hash, types.Address) (*big.Int, error
 	return balance, nil
 }
 
+func (m defaultMockStore) GetStorage(types.Hash, types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+// storageMockStore is a default store with the account storage set
+type storageMockStore struct {
+	defaultMockStore
+
+	storage map[types.Address]map[types.Hash]types.Hash
+}
+
+func (m storageMockStore) GetStorage(_ types.Hash, addr types.Address, slot types.Hash) types.Hash {
+	return m.storage[addr][slot]
+}
+
+// nonceMockStore is a default store with the account nonces set
+type nonceMockStore struct {
+	defaultMockStore
+
+	nonces map[types.Address]uint64
+}
+
+func (m nonceMockStore) GetNonce(_ types.Hash, addr types.Address) uint64 {
+	return m.nonces[addr]
+}
+
 type faultyMockStore struct {
 }
 
@@ -60,9 +86,17 @@ func (fms faultyMockStore) GetBalance(root types.Hash, addr types.Address) (*big
 	return nil, fmt.Errorf("unable to fetch account state")
 }
 
+func (fms faultyMockStore) GetStorage(root types.Hash, addr types.Address, slot types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
 type mockSigner struct {
 }
 
 func (s *mockSigner) Sender(tx *types.Transaction) (types.Address, error) {
 	return tx.From, nil
 }
+
+func (s *mockSigner) FeePayer(tx *types.Transaction) (types.Address, error) {
+	return *tx.FeePayer, nil
+}
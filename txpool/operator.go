@@ -1,14 +1,27 @@
 package txpool
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/SECRYPT-2022/SECRYPT/txpool/proto"
 	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/armon/go-metrics"
+	"github.com/golang/protobuf/ptypes/any"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
+// maximum number of transactions returned by ListTxns
+const maxListedTxns = 1000
+
+var (
+	ErrTxNotFound      = errors.New("transaction not found")
+	ErrAccountNotFound = errors.New("account not found")
+)
+
 // Status implements the GRPC status endpoint. Returns the number of transactions in the pool
 func (p *TxPool) Status(ctx context.Context, req *empty.Empty) (*proto.TxnPoolStatusResp, error) {
 	resp := &proto.TxnPoolStatusResp{
@@ -38,7 +51,14 @@ func (p *TxPool) AddTxn(ctx context.Context, raw *proto.AddTxnReq) (*proto.AddTx
 		txn.From = from
 	}
 
-	if err := p.AddTx(txn); err != nil {
+	addTx := p.AddTx
+	if raw.Private {
+		addTx = func(tx *types.Transaction) error {
+			return p.AddPrivateTx(tx, raw.MaxBlockNumber)
+		}
+	}
+
+	if err := addTx(txn); err != nil {
 		return nil, err
 	}
 
@@ -78,3 +98,272 @@ func (p *TxPool) Subscribe(
 		}
 	}
 }
+
+// ListTxns implements the operator endpoint. Returns the transactions in the pool,
+// ordered by account and nonce, matching the requested account and state
+func (p *TxPool) ListTxns(ctx context.Context, req *proto.ListTxnsReq) (*proto.ListTxnsResp, error) {
+	addrs := make([]types.Address, 0)
+
+	if req.Account != "" {
+		addr, err := parseAddress(req.Account)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.accounts.exists(addr) {
+			addrs = append(addrs, addr)
+		}
+	} else {
+		p.accounts.Range(func(key, _ interface{}) bool {
+			if addr, ok := key.(types.Address); ok {
+				addrs = append(addrs, addr)
+			}
+
+			return true
+		})
+
+		sort.Slice(addrs, func(i, j int) bool {
+			return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+		})
+	}
+
+	txns := make([]*proto.TxnInfo, 0)
+
+	for _, addr := range addrs {
+		promoted, enqueued := p.accounts.get(addr).txs()
+
+		if req.State != proto.TxnState_STATE_ENQUEUED {
+			for _, tx := range promoted {
+				txns = append(txns, p.toTxnInfo(tx, proto.TxnState_STATE_PROMOTED))
+			}
+		}
+
+		if req.State != proto.TxnState_STATE_PROMOTED {
+			for _, tx := range enqueued {
+				txns = append(txns, p.toTxnInfo(tx, proto.TxnState_STATE_ENQUEUED))
+			}
+		}
+	}
+
+	resp := &proto.ListTxnsResp{
+		Total: uint64(len(txns)),
+	}
+
+	limit := req.Limit
+	if limit == 0 || limit > maxListedTxns {
+		limit = maxListedTxns
+	}
+
+	if req.Offset < resp.Total {
+		end := req.Offset + limit
+		if end > resp.Total {
+			end = resp.Total
+		}
+
+		resp.Txns = txns[req.Offset:end]
+	}
+
+	return resp, nil
+}
+
+// GetTxn implements the operator endpoint. Returns the transaction in the pool with the given hash
+func (p *TxPool) GetTxn(ctx context.Context, req *proto.GetTxnReq) (*proto.GetTxnResp, error) {
+	hash, err := parseHash(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, ok := p.index.get(hash)
+	if !ok {
+		return nil, ErrTxNotFound
+	}
+
+	state := proto.TxnState_STATE_ENQUEUED
+
+	if account := p.accounts.get(tx.From); account != nil {
+		promoted, _ := account.txs()
+
+		for _, promotedTx := range promoted {
+			if promotedTx.Hash == hash {
+				state = proto.TxnState_STATE_PROMOTED
+
+				break
+			}
+		}
+	}
+
+	return &proto.GetTxnResp{
+		Txn: p.toTxnInfo(tx, state),
+		Raw: &any.Any{
+			Value: tx.MarshalRLP(),
+		},
+	}, nil
+}
+
+// RemoveTxn implements the operator endpoint. Removes the transaction from the pool,
+// the following promoted transactions of the account are enqueued again
+func (p *TxPool) RemoveTxn(ctx context.Context, req *proto.RemoveTxnReq) (*proto.RemoveTxnsResp, error) {
+	hash, err := parseHash(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := p.index.get(hash); !ok {
+		return nil, ErrTxNotFound
+	}
+
+	return p.removeOperatorTxs(func(tx *types.Transaction) bool {
+		return tx.Hash == hash
+	}), nil
+}
+
+// RemoveAccount implements the operator endpoint. Removes all the transactions of the account from the pool
+func (p *TxPool) RemoveAccount(ctx context.Context, req *proto.AccountReq) (*proto.RemoveTxnsResp, error) {
+	addr, err := parseAddress(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.accounts.exists(addr) {
+		return nil, ErrAccountNotFound
+	}
+
+	return p.removeOperatorTxs(func(tx *types.Transaction) bool {
+		return tx.From == addr
+	}), nil
+}
+
+// AccountStatus implements the operator endpoint. Returns the nonce, the transaction counts
+// and the nonce gaps of the account in the pool
+func (p *TxPool) AccountStatus(ctx context.Context, req *proto.AccountReq) (*proto.AccountStatusResp, error) {
+	addr, err := parseAddress(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	account := p.accounts.get(addr)
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	promoted, enqueued := account.txs()
+	nextNonce := account.getNonce()
+
+	resp := &proto.AccountStatusResp{
+		NextNonce: nextNonce,
+		Promoted:  uint64(len(promoted)),
+		Enqueued:  uint64(len(enqueued)),
+		Gaps:      make([]*proto.NonceGap, 0),
+		Local:     account.isLocal(),
+	}
+
+	// the enqueued transactions are sorted by nonce
+	expected := nextNonce
+
+	for _, tx := range enqueued {
+		if tx.Nonce > expected {
+			resp.Gaps = append(resp.Gaps, &proto.NonceGap{
+				First: expected,
+				Last:  tx.Nonce - 1,
+			})
+		}
+
+		expected = tx.Nonce + 1
+	}
+
+	return resp, nil
+}
+
+// removeOperatorTxs removes the matching transactions on the operator request
+func (p *TxPool) removeOperatorTxs(match func(tx *types.Transaction) bool) *proto.RemoveTxnsResp {
+	removed, demoted := p.removeTxs(match, "removed by the operator")
+
+	for _, tx := range removed {
+		p.privateTxs.remove(tx.Hash)
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "removed_transactions"}, float32(len(removed)))
+
+	p.logger.Info("removed txs on operator request", "num", len(removed), "demoted", demoted)
+
+	hashes := make([]string, len(removed))
+	for idx, tx := range removed {
+		hashes[idx] = tx.Hash.String()
+	}
+
+	return &proto.RemoveTxnsResp{
+		TxHashes: hashes,
+	}
+}
+
+func (p *TxPool) toTxnInfo(tx *types.Transaction, state proto.TxnState) *proto.TxnInfo {
+	info := &proto.TxnInfo{
+		Hash:     tx.Hash.String(),
+		From:     tx.From.String(),
+		Nonce:    tx.Nonce,
+		Value:    tx.Value.String(),
+		GasPrice: tx.GasPrice.String(),
+		Gas:      tx.Gas,
+		State:    state,
+		Private:  p.privateTxs.isPrivate(tx.Hash),
+	}
+
+	if tx.To != nil {
+		info.To = tx.To.String()
+	}
+
+	return info
+}
+
+func parseAddress(raw string) (types.Address, error) {
+	addr := types.Address{}
+	if err := addr.UnmarshalText([]byte(raw)); err != nil {
+		return types.ZeroAddress, fmt.Errorf("invalid address %s, %w", raw, err)
+	}
+
+	return addr, nil
+}
+
+func parseHash(raw string) (types.Hash, error) {
+	hash := types.Hash{}
+	if err := hash.UnmarshalText([]byte(raw)); err != nil {
+		return types.ZeroHash, fmt.Errorf("invalid hash %s, %w", raw, err)
+	}
+
+	return hash, nil
+}
+
+// TxnStatus implements the operator endpoint. Returns the state of the transaction in the pool,
+// along with its lifecycle history
+func (p *TxPool) TxnStatus(ctx context.Context, req *proto.GetTxnReq) (*proto.TxnStatusResp, error) {
+	hash, err := parseHash(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	status := p.GetTxStatus(hash)
+
+	resp := &proto.TxnStatusResp{
+		State:            status.State,
+		Nonce:            status.Nonce,
+		NextNonce:        status.NextNonce,
+		MissingNonces:    status.MissingNonces,
+		RequiredGasPrice: status.RequiredGasPrice.String(),
+		History:          make([]*proto.TxnEvent, len(status.History)),
+	}
+
+	if status.GasPrice != nil {
+		resp.From = status.From.String()
+		resp.GasPrice = status.GasPrice.String()
+	}
+
+	for idx, event := range status.History {
+		resp.History[idx] = &proto.TxnEvent{
+			Type:   event.Type,
+			Reason: event.Reason,
+			Time:   event.Time.UnixMilli(),
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,206 @@
+package txpool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newOperatorTestPool returns a pool with the promoted txs 0-2 of addr1,
+// the promoted tx 0 of addr2 and the enqueued txs 3 and 5 of addr2
+func newOperatorTestPool(t *testing.T) (*TxPool, map[types.Address][]*types.Transaction) {
+	t.Helper()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+
+	pool.SetSigner(&mockSigner{})
+
+	pool.Start()
+	t.Cleanup(pool.Close)
+
+	txs := map[types.Address][]*types.Transaction{
+		addr1: {newTx(addr1, 0, 1), newTx(addr1, 1, 1), newTx(addr1, 2, 1)},
+		addr2: {newTx(addr2, 0, 1), newTx(addr2, 3, 1), newTx(addr2, 5, 1)},
+	}
+
+	for _, accountTxs := range txs {
+		for _, tx := range accountTxs {
+			assert.NoError(t, pool.addTx(local, tx))
+		}
+	}
+
+	assert.Eventually(t, func() bool {
+		return pool.accounts.promoted() == 4 && pool.accounts.get(addr2).enqueued.length() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return pool, txs
+}
+
+func TestOperator_ListTxns(t *testing.T) {
+	t.Parallel()
+
+	pool, txs := newOperatorTestPool(t)
+
+	testCases := []struct {
+		name     string
+		req      *proto.ListTxnsReq
+		expected []*types.Transaction
+		total    uint64
+	}{
+		{
+			"all txs ordered by account and nonce",
+			&proto.ListTxnsReq{},
+			append(append([]*types.Transaction{}, txs[addr1]...), txs[addr2]...),
+			6,
+		},
+		{
+			"account filter",
+			&proto.ListTxnsReq{Account: addr2.String()},
+			txs[addr2],
+			3,
+		},
+		{
+			"state filter",
+			&proto.ListTxnsReq{State: proto.TxnState_STATE_ENQUEUED},
+			txs[addr2][1:],
+			2,
+		},
+		{
+			"paging",
+			&proto.ListTxnsReq{Offset: 2, Limit: 2},
+			[]*types.Transaction{txs[addr1][2], txs[addr2][0]},
+			6,
+		},
+		{
+			"offset past the end",
+			&proto.ListTxnsReq{Offset: 10},
+			nil,
+			6,
+		},
+		{
+			"unknown account",
+			&proto.ListTxnsReq{Account: addr3.String()},
+			nil,
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := pool.ListTxns(context.Background(), tc.req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.total, resp.Total)
+			assert.Len(t, resp.Txns, len(tc.expected))
+
+			for idx, tx := range tc.expected {
+				assert.Equal(t, tx.Hash.String(), resp.Txns[idx].Hash)
+			}
+		})
+	}
+
+	_, err := pool.ListTxns(context.Background(), &proto.ListTxnsReq{Account: "0xinvalid"})
+	assert.Error(t, err)
+}
+
+func TestOperator_GetTxn(t *testing.T) {
+	t.Parallel()
+
+	pool, txs := newOperatorTestPool(t)
+
+	resp, err := pool.GetTxn(context.Background(), &proto.GetTxnReq{Hash: txs[addr1][1].Hash.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, txs[addr1][1].Hash.String(), resp.Txn.Hash)
+	assert.Equal(t, addr1.String(), resp.Txn.From)
+	assert.Equal(t, uint64(1), resp.Txn.Nonce)
+	assert.Equal(t, proto.TxnState_STATE_PROMOTED, resp.Txn.State)
+	assert.Equal(t, txs[addr1][1].MarshalRLP(), resp.Raw.Value)
+
+	resp, err = pool.GetTxn(context.Background(), &proto.GetTxnReq{Hash: txs[addr2][2].Hash.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, proto.TxnState_STATE_ENQUEUED, resp.Txn.State)
+
+	_, err = pool.GetTxn(context.Background(), &proto.GetTxnReq{Hash: types.StringToHash("0x1").String()})
+	assert.ErrorIs(t, err, ErrTxNotFound)
+}
+
+func TestOperator_RemoveTxn(t *testing.T) {
+	t.Parallel()
+
+	pool, txs := newOperatorTestPool(t)
+
+	resp, err := pool.RemoveTxn(context.Background(), &proto.RemoveTxnReq{Hash: txs[addr1][1].Hash.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{txs[addr1][1].Hash.String()}, resp.TxHashes)
+
+	_, ok := pool.index.get(txs[addr1][1].Hash)
+	assert.False(t, ok)
+
+	// the following tx can't be executed anymore
+	account := pool.accounts.get(addr1)
+	assert.Equal(t, uint64(1), account.getNonce())
+	assert.Equal(t, uint64(1), account.promoted.length())
+	assert.Equal(t, uint64(1), account.enqueued.length())
+	assert.Equal(t, uint64(5), pool.gauge.read())
+
+	_, err = pool.RemoveTxn(context.Background(), &proto.RemoveTxnReq{Hash: txs[addr1][1].Hash.String()})
+	assert.ErrorIs(t, err, ErrTxNotFound)
+}
+
+func TestOperator_RemoveAccount(t *testing.T) {
+	t.Parallel()
+
+	pool, txs := newOperatorTestPool(t)
+
+	resp, err := pool.RemoveAccount(context.Background(), &proto.AccountReq{Address: addr2.String()})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, toHashStrings(txs[addr2]...), resp.TxHashes)
+
+	assert.Len(t, pool.index.list(), 3)
+	assert.Equal(t, uint64(3), pool.accounts.promoted())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr2).enqueued.length())
+
+	_, err = pool.RemoveAccount(context.Background(), &proto.AccountReq{Address: addr3.String()})
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+}
+
+func TestOperator_AccountStatus(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := newOperatorTestPool(t)
+
+	resp, err := pool.AccountStatus(context.Background(), &proto.AccountReq{Address: addr2.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), resp.NextNonce)
+	assert.Equal(t, uint64(1), resp.Promoted)
+	assert.Equal(t, uint64(2), resp.Enqueued)
+	assert.True(t, resp.Local)
+	assert.Len(t, resp.Gaps, 2)
+	assert.Equal(t, []uint64{1, 2}, []uint64{resp.Gaps[0].First, resp.Gaps[0].Last})
+	assert.Equal(t, []uint64{4, 4}, []uint64{resp.Gaps[1].First, resp.Gaps[1].Last})
+
+	resp, err = pool.AccountStatus(context.Background(), &proto.AccountReq{Address: addr1.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), resp.NextNonce)
+	assert.Len(t, resp.Gaps, 0)
+
+	_, err = pool.AccountStatus(context.Background(), &proto.AccountReq{Address: addr3.String()})
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+}
+
+func toHashStrings(txs ...*types.Transaction) []string {
+	hashes := make([]string, len(txs))
+	for idx, tx := range txs {
+		hashes[idx] = tx.Hash.String()
+	}
+
+	return hashes
+}
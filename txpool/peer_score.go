@@ -0,0 +1,221 @@
+package txpool
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// penalties added to the score of a peer for each transaction it sent
+	invalidTxPenalty     = 10.0
+	underpricedTxPenalty = 1.0
+
+	// messages from the peers scoring above peerDropThreshold are dropped,
+	// and the peers scoring above peerReportThreshold with invalid transactions alone are banned
+	peerDropThreshold   = 100.0
+	peerReportThreshold = 200.0
+
+	// time for the score of a peer to be halved
+	peerScoreHalfLife = time.Minute
+
+	// duration of the ban of the reported peers
+	peerBanDuration = 30 * time.Minute
+
+	// number of tracked peers above which the forgotten scores are pruned
+	maxScoredPeers = 1024
+)
+
+// peerScore is the decaying penalty score of a peer
+type peerScore struct {
+	score    float64
+	updated  time.Time
+	reported bool
+
+	// part of the score caused by the invalid transactions, the only one leading to a ban
+	banScore float64
+}
+
+// peerScorer keeps track of the invalid and underpriced transactions rate of the peers,
+// as a score decaying over time
+type peerScorer struct {
+	sync.Mutex
+
+	scores map[peer.ID]*peerScore
+
+	// overridden in tests
+	now func() time.Time
+}
+
+func newPeerScorer() *peerScorer {
+	return &peerScorer{
+		scores: make(map[peer.ID]*peerScore),
+		now:    time.Now,
+	}
+}
+
+// decay returns the current score of the peer, decayed since its last update
+func (ps *peerScore) decay(now time.Time) float64 {
+	return ps.score * ps.decayFactor(now)
+}
+
+// decayFactor returns the factor the scores decayed by since the last update
+func (ps *peerScore) decayFactor(now time.Time) float64 {
+	elapsed := now.Sub(ps.updated)
+	if elapsed <= 0 {
+		return 1
+	}
+
+	return math.Pow(0.5, float64(elapsed)/float64(peerScoreHalfLife))
+}
+
+// allow returns false if the messages of the peer should be dropped
+func (s *peerScorer) allow(peerID peer.ID) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	ps, ok := s.scores[peerID]
+	if !ok {
+		return true
+	}
+
+	return ps.decay(s.now()) < peerDropThreshold
+}
+
+// penalize adds the penalty of an invalid transaction to the score of the peer,
+// and returns true if the peer crossed the report threshold
+func (s *peerScorer) penalize(peerID peer.ID, penalty float64) bool {
+	return s.add(peerID, penalty, true)
+}
+
+// throttle adds the penalty to the score of the peer, without leading to a ban,
+// for the transactions which aren't invalid but shouldn't be sent at a high rate
+func (s *peerScorer) throttle(peerID peer.ID, penalty float64) {
+	s.add(peerID, penalty, false)
+}
+
+// add adds the penalty to the score of the peer, and to its ban score if bannable.
+// Returns true if the peer crossed the report threshold
+func (s *peerScorer) add(peerID peer.ID, penalty float64, bannable bool) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.now()
+
+	ps, ok := s.scores[peerID]
+	if !ok {
+		s.prune(now)
+
+		ps = &peerScore{}
+		s.scores[peerID] = ps
+	}
+
+	factor := ps.decayFactor(now)
+
+	ps.score = ps.score*factor + penalty
+	ps.banScore *= factor
+	ps.updated = now
+
+	if bannable {
+		ps.banScore += penalty
+	}
+
+	// the peer is reported again if it keeps misbehaving after its ban
+	if ps.banScore < peerDropThreshold {
+		ps.reported = false
+	}
+
+	if ps.banScore >= peerReportThreshold && !ps.reported {
+		ps.reported = true
+
+		return true
+	}
+
+	return false
+}
+
+// prune forgets the scores which decayed away, once too many peers are tracked
+func (s *peerScorer) prune(now time.Time) {
+	if len(s.scores) < maxScoredPeers {
+		return
+	}
+
+	for peerID, ps := range s.scores {
+		if ps.decay(now) < underpricedTxPenalty {
+			delete(s.scores, peerID)
+		}
+	}
+}
+
+// isInvalidTxErr returns true if the error proves the transaction could never be valid
+func isInvalidTxErr(err error) bool {
+	for _, invalidErr := range []error{
+		ErrIntrinsicGas,
+		ErrBlockLimitExceeded,
+		ErrNegativeValue,
+		ErrExtractSignature,
+		ErrInvalidSender,
+		ErrOversizedData,
+		ErrInvalidFeePayer,
+	} {
+		if errors.Is(err, invalidErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isUnderpricedTxErr returns true if the error is caused by a too low gas price
+func isUnderpricedTxErr(err error) bool {
+	return errors.Is(err, ErrUnderpriced) || errors.Is(err, ErrReplaceUnderpriced)
+}
+
+// allowPeer returns false if the messages of the peer should be dropped
+func (p *TxPool) allowPeer(from peer.ID) bool {
+	if from == "" || p.peerScores.allow(from) {
+		return true
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "gossip_dropped_messages"}, 1)
+
+	return false
+}
+
+// scoreGossipError penalizes the peer which sent an invalid or underpriced transaction.
+// The underpriced transactions get the messages of the peer dropped, but never the peer banned,
+// as they may have been valid when they were sent
+func (p *TxPool) scoreGossipError(from peer.ID, err error) {
+	switch {
+	case isInvalidTxErr(err):
+		p.penalizePeer(from, invalidTxPenalty, "gossip_invalid_transactions", err)
+	case isUnderpricedTxErr(err):
+		metrics.IncrCounter([]string{txPoolMetrics, "gossip_underpriced_transactions"}, 1)
+
+		if from != "" {
+			p.peerScores.throttle(from, underpricedTxPenalty)
+		}
+	}
+}
+
+// penalizePeer adds the penalty to the score of the peer,
+// and bans the peer once it crosses the report threshold
+func (p *TxPool) penalizePeer(from peer.ID, penalty float64, metric string, reason error) {
+	metrics.IncrCounter([]string{txPoolMetrics, metric}, 1)
+
+	if from == "" || !p.peerScores.penalize(from, penalty) {
+		return
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "reported_peers"}, 1)
+
+	p.logger.Warn("reporting misbehaving peer", "peer", from, "err", reason)
+
+	if p.network != nil {
+		p.network.BanPeer(from, peerBanDuration, "sent invalid transactions")
+	}
+}
@@ -0,0 +1,184 @@
+package txpool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/network/grpc"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerScorer(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+
+	scorer := newPeerScorer()
+	scorer.now = func() time.Time {
+		return now
+	}
+
+	// not reported until the report threshold is crossed
+	for i := 0; i < int(peerDropThreshold/invalidTxPenalty)-1; i++ {
+		assert.False(t, scorer.penalize("A", invalidTxPenalty))
+	}
+
+	assert.True(t, scorer.allow("A"))
+	assert.False(t, scorer.penalize("A", invalidTxPenalty))
+	assert.False(t, scorer.allow("A"))
+
+	// the other peers aren't affected
+	assert.True(t, scorer.allow("B"))
+
+	for i := 0; i < int((peerReportThreshold-peerDropThreshold)/invalidTxPenalty)-1; i++ {
+		assert.False(t, scorer.penalize("A", invalidTxPenalty))
+	}
+
+	// reported only once
+	assert.True(t, scorer.penalize("A", invalidTxPenalty))
+	assert.False(t, scorer.penalize("A", invalidTxPenalty))
+
+	// the score decays over time
+	now = now.Add(2 * peerScoreHalfLife)
+	assert.True(t, scorer.allow("A"))
+
+	// and the peer gets reported again if it keeps misbehaving
+	assert.False(t, scorer.penalize("A", invalidTxPenalty))
+
+	for i := 0; i < int(peerReportThreshold/invalidTxPenalty); i++ {
+		if scorer.penalize("A", invalidTxPenalty) {
+			return
+		}
+	}
+
+	t.Fatal("peer not reported again")
+}
+
+func TestPeerScorer_Throttle(t *testing.T) {
+	t.Parallel()
+
+	scorer := newPeerScorer()
+	scorer.now = func() time.Time {
+		return time.Unix(0, 0)
+	}
+
+	// the underpriced txs get the messages dropped, but never the peer reported
+	for i := 0; i < int(2*peerReportThreshold/underpricedTxPenalty); i++ {
+		scorer.throttle("A", underpricedTxPenalty)
+	}
+
+	assert.False(t, scorer.allow("A"))
+
+	// nor do they count toward the report of the invalid txs
+	assert.False(t, scorer.penalize("A", invalidTxPenalty))
+
+	for i := 0; i < int(peerReportThreshold/invalidTxPenalty)-2; i++ {
+		assert.False(t, scorer.penalize("A", invalidTxPenalty))
+	}
+
+	assert.True(t, scorer.penalize("A", invalidTxPenalty))
+}
+
+func TestPeerScorer_Prune(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+
+	scorer := newPeerScorer()
+	scorer.now = func() time.Time {
+		return now
+	}
+
+	for i := 0; i < maxScoredPeers; i++ {
+		scorer.penalize(peer.ID(fmt.Sprint(i)), underpricedTxPenalty)
+	}
+
+	scorer.penalize("A", 3*peerDropThreshold)
+
+	now = now.Add(peerScoreHalfLife)
+
+	// the decayed scores are forgotten, the others are kept
+	scorer.penalize("B", underpricedTxPenalty)
+
+	assert.Len(t, scorer.scores, 2)
+	assert.False(t, scorer.allow("A"))
+}
+
+func TestScoreGossipError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected float64
+	}{
+		{"invalid tx", ErrExtractSignature, invalidTxPenalty},
+		{"underpriced tx", ErrUnderpriced, underpricedTxPenalty},
+		{"underpriced replacement", ErrReplaceUnderpriced, underpricedTxPenalty},
+		{"valid but not accepted", ErrNonceTooLow, 0},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := newTestPool()
+			assert.NoError(t, err)
+
+			pool.scoreGossipError("A", tc.err)
+
+			var score float64
+			if ps, ok := pool.peerScores.scores["A"]; ok {
+				score = ps.score
+			}
+
+			assert.Equal(t, tc.expected, score)
+		})
+	}
+}
+
+func TestPropagationService_DropsBadPeers(t *testing.T) {
+	t.Parallel()
+
+	pool, net, _ := newPropagationTestPool(t, "A")
+	pool.network = net
+
+	pool.Start()
+	t.Cleanup(pool.Close)
+
+	service := &propagationService{pool: pool}
+	ctx := &grpc.Context{Context: context.Background(), PeerID: "A"}
+
+	// unsigned transactions
+	txs := make([]*types.Transaction, int(peerReportThreshold/invalidTxPenalty)+1)
+	for idx := range txs {
+		txs[idx] = newTx(addr1, uint64(idx), 1)
+	}
+
+	pool.SetSigner(signerEIP155)
+
+	batch := toTxnBatch(txs)
+
+	_, err := service.Push(ctx, batch)
+	assert.NoError(t, err)
+
+	assert.False(t, pool.peerScores.allow("A"))
+
+	net.Lock()
+	assert.Equal(t, []peer.ID{"A"}, net.banned)
+	net.Unlock()
+
+	// the next messages are dropped without being looked at
+	pool.SetSigner(&mockSigner{})
+
+	_, err = service.Push(ctx, toTxnBatch([]*types.Transaction{newTx(addr2, 0, 1)}))
+	assert.NoError(t, err)
+
+	assert.Len(t, pool.index.list(), 0)
+}
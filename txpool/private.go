@@ -0,0 +1,247 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/network"
+	"github.com/SECRYPT-2022/SECRYPT/network/grpc"
+	"github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/libp2p/go-libp2p/core/peer"
+	rawGrpc "google.golang.org/grpc"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	privateTxProto = "/txpool/private/0.1"
+
+	// number of blocks a private transaction is kept for, if no max block number is given
+	defaultPrivateTxMaxBlocks = 25
+
+	// timeout of forwarding a private transaction to a validator peer
+	privateTxForwardTimeout = 5 * time.Second
+)
+
+var (
+	ErrPrivateTxExpired   = errors.New("private transaction max block number already passed")
+	ErrNotValidator       = errors.New("private transactions are only accepted by validators")
+	ErrEmptyPrivateTxData = errors.New("private transaction's field raw is empty")
+)
+
+// txNetwork is the part of the networking stack used to send transactions to the peers
+type txNetwork interface {
+	Peers() []*network.PeerConnInfo
+	IsConnected(peerID peer.ID) bool
+	NewProtoConnection(protocol string, peerID peer.ID) (*rawGrpc.ClientConn, error)
+	RegisterProtocol(id string, p network.Protocol)
+	BanPeer(peerID peer.ID, duration time.Duration, reason string)
+}
+
+// privateTxs keeps the max block numbers of the private transactions in the pool
+type privateTxs struct {
+	sync.RWMutex
+	maxBlocks map[types.Hash]uint64
+}
+
+func newPrivateTxs() *privateTxs {
+	return &privateTxs{
+		maxBlocks: make(map[types.Hash]uint64),
+	}
+}
+
+func (m *privateTxs) add(hash types.Hash, maxBlockNumber uint64) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.maxBlocks[hash] = maxBlockNumber
+}
+
+func (m *privateTxs) remove(hash types.Hash) {
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.maxBlocks, hash)
+}
+
+// isPrivate checks if the transaction was submitted privately
+func (m *privateTxs) isPrivate(hash types.Hash) bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	_, ok := m.maxBlocks[hash]
+
+	return ok
+}
+
+// expired returns the private transactions which can't be included after the given block,
+// and forgets the ones which left the pool
+func (m *privateTxs) expired(number uint64, inPool func(types.Hash) bool) map[types.Hash]struct{} {
+	m.Lock()
+	defer m.Unlock()
+
+	expired := make(map[types.Hash]struct{})
+
+	for hash, maxBlockNumber := range m.maxBlocks {
+		if !inPool(hash) {
+			delete(m.maxBlocks, hash)
+
+			continue
+		}
+
+		if maxBlockNumber <= number {
+			expired[hash] = struct{}{}
+		}
+	}
+
+	return expired
+}
+
+// privateTxService receives the private transactions forwarded by the other nodes
+type privateTxService struct {
+	proto.UnimplementedPrivateTxnServer
+
+	pool *TxPool
+}
+
+// Forward adds a private transaction forwarded by a peer, if the node is a validator
+func (s *privateTxService) Forward(ctx context.Context, req *proto.PrivateTxnReq) (*empty.Empty, error) {
+	if !s.pool.getSealing() {
+		return nil, ErrNotValidator
+	}
+
+	if req.Raw == nil {
+		return nil, ErrEmptyPrivateTxData
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalRLP(req.Raw.Value); err != nil {
+		return nil, err
+	}
+
+	if err := s.pool.addPrivateTx(tx, req.MaxBlockNumber); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// setupPrivateTxs registers the protocol receiving private transactions from the peers
+func (p *TxPool) setupPrivateTxs(network txNetwork, validatorPeers []peer.ID) {
+	p.network = network
+	p.privatePeers = validatorPeers
+
+	stream := grpc.NewGrpcStream()
+
+	proto.RegisterPrivateTxnServer(stream.GrpcServer(), &privateTxService{pool: p})
+	stream.Serve()
+
+	network.RegisterProtocol(privateTxProto, stream)
+}
+
+// AddPrivateTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
+// without gossiping it. It is only forwarded to the configured validator peers,
+// and dropped from the pool once the max block number has passed.
+// A zero max block number defaults to defaultPrivateTxMaxBlocks after the head.
+func (p *TxPool) AddPrivateTx(tx *types.Transaction, maxBlockNumber uint64) error {
+	if maxBlockNumber == 0 {
+		maxBlockNumber = p.store.Header().Number + defaultPrivateTxMaxBlocks
+	}
+
+	if err := p.addPrivateTx(tx, maxBlockNumber); err != nil {
+		p.logger.Error("failed to add private tx", "err", err)
+
+		return err
+	}
+
+	if p.network != nil && len(p.privatePeers) > 0 {
+		go p.forwardPrivateTx(tx, maxBlockNumber)
+	}
+
+	return nil
+}
+
+// addPrivateTx adds the transaction to the pool, marked as private until the max block number
+func (p *TxPool) addPrivateTx(tx *types.Transaction, maxBlockNumber uint64) error {
+	if maxBlockNumber <= p.store.Header().Number {
+		return ErrPrivateTxExpired
+	}
+
+	// marked beforehand so it can't leak from the pool
+	tx.ComputeHash()
+	p.privateTxs.add(tx.Hash, maxBlockNumber)
+
+	if err := p.addTx(private, tx); err != nil {
+		p.privateTxs.remove(tx.Hash)
+
+		return err
+	}
+
+	return nil
+}
+
+// forwardPrivateTx sends the private transaction to the connected validator peers
+func (p *TxPool) forwardPrivateTx(tx *types.Transaction, maxBlockNumber uint64) {
+	req := &proto.PrivateTxnReq{
+		Raw: &any.Any{
+			Value: tx.MarshalRLP(),
+		},
+		MaxBlockNumber: maxBlockNumber,
+	}
+
+	for _, peerID := range p.privatePeers {
+		if !p.network.IsConnected(peerID) {
+			p.logger.Warn("validator peer not connected, private tx not forwarded", "peer", peerID)
+
+			continue
+		}
+
+		if err := p.forwardPrivateTxTo(peerID, req); err != nil {
+			p.logger.Error("failed to forward private tx", "peer", peerID, "hash", tx.Hash, "err", err)
+		}
+	}
+}
+
+func (p *TxPool) forwardPrivateTxTo(peerID peer.ID, req *proto.PrivateTxnReq) error {
+	conn, err := p.network.NewProtoConnection(privateTxProto, peerID)
+	if err != nil {
+		return fmt.Errorf("failed to open a stream, %w", err)
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), privateTxForwardTimeout)
+	defer cancel()
+
+	_, err = proto.NewPrivateTxnClient(conn).Forward(ctx, req)
+
+	return err
+}
+
+// pruneExpiredPrivateTxs drops the private transactions
+// which can't be included in the blocks after the given one
+func (p *TxPool) pruneExpiredPrivateTxs(number uint64) {
+	expired := p.privateTxs.expired(number, func(hash types.Hash) bool {
+		_, ok := p.index.get(hash)
+
+		return ok
+	})
+
+	if len(expired) == 0 {
+		return
+	}
+
+	p.pruneTxs(func(tx *types.Transaction) bool {
+		_, ok := expired[tx.Hash]
+
+		return ok
+	}, "private transaction max block number passed")
+
+	for hash := range expired {
+		p.privateTxs.remove(hash)
+	}
+}
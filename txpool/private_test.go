@@ -0,0 +1,205 @@
+package txpool
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddPrivateTx(t *testing.T) {
+	t.Parallel()
+
+	head := &types.Header{
+		Number:   10,
+		GasLimit: mockHeader.GasLimit,
+	}
+
+	setupPool := func(t *testing.T) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool(defaultMockStore{DefaultHeader: head})
+		assert.NoError(t, err)
+
+		pool.SetSigner(&mockSigner{})
+
+		pool.Start()
+		t.Cleanup(pool.Close)
+
+		return pool
+	}
+
+	t.Run("defaults the max block number", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+		tx := newTx(addr1, 0, 1)
+
+		assert.NoError(t, pool.AddPrivateTx(tx, 0))
+
+		_, exists := pool.index.get(tx.Hash)
+		assert.True(t, exists)
+		assert.True(t, pool.privateTxs.isPrivate(tx.Hash))
+		assert.Equal(t, head.Number+defaultPrivateTxMaxBlocks, pool.privateTxs.maxBlocks[tx.Hash])
+		assert.True(t, pool.accounts.get(addr1).isLocal())
+	})
+
+	t.Run("rejects passed max block number", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+		tx := newTx(addr1, 0, 1)
+
+		assert.ErrorIs(t, pool.AddPrivateTx(tx, head.Number), ErrPrivateTxExpired)
+
+		_, exists := pool.index.get(tx.Hash)
+		assert.False(t, exists)
+		assert.False(t, pool.privateTxs.isPrivate(tx.Hash))
+	})
+
+	t.Run("forgets rejected tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+		tx := newTx(addr1, 0, 1)
+		tx.GasPrice.SetUint64(0)
+
+		assert.ErrorIs(t, pool.AddPrivateTx(tx, head.Number+1), ErrUnderpriced)
+		assert.False(t, pool.privateTxs.isPrivate(tx.Hash))
+	})
+
+	t.Run("prunes expired private txs", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
+		defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+		public := newTx(addr1, 0, 1)
+		short := newTx(addr2, 0, 1)
+		long := newTx(addr3, 0, 1)
+
+		assert.NoError(t, pool.addTx(local, public))
+		assert.NoError(t, pool.AddPrivateTx(short, head.Number+1))
+		assert.NoError(t, pool.AddPrivateTx(long, head.Number+5))
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancelFn()
+
+		assert.Len(t, waitForEvents(ctx, subscription, 3), 3)
+
+		pool.pruneExpiredPrivateTxs(head.Number + 1)
+
+		_, exists := pool.index.get(short.Hash)
+		assert.False(t, exists)
+		assert.False(t, pool.privateTxs.isPrivate(short.Hash))
+
+		for _, tx := range []*types.Transaction{public, long} {
+			_, exists := pool.index.get(tx.Hash)
+			assert.True(t, exists)
+		}
+
+		assert.Equal(t, slotsRequired(public, long), pool.gauge.read())
+	})
+}
+
+func TestPrivateTxNotJournaled(t *testing.T) {
+	t.Parallel()
+
+	key := new(eoa).create(t)
+	path := filepath.Join(t.TempDir(), "journal")
+
+	pool, err := NewTxPool(
+		hclog.NewNullLogger(),
+		forks.At(0),
+		defaultMockStore{DefaultHeader: mockHeader},
+		nil,
+		nil,
+		&Config{
+			PriceLimit:         defaultPriceLimit,
+			MaxSlots:           defaultMaxSlots,
+			MaxAccountEnqueued: defaultMaxAccountEnqueued,
+			JournalPath:        path,
+			JournalRemotes:     true,
+		},
+	)
+	assert.NoError(t, err)
+
+	pool.SetSigner(signerEIP155)
+	pool.Start()
+
+	defer pool.Close()
+
+	assert.NoError(t, pool.LoadJournal())
+
+	publicTx := newJournalTx(t, key, 0)
+	privateTx := newJournalTx(t, key, 1)
+
+	assert.NoError(t, pool.addTx(local, publicTx))
+	assert.NoError(t, pool.AddPrivateTx(privateTx, 0))
+
+	// neither appended nor kept on compaction
+	txs, err := newTxJournal(path).load()
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{publicTx.Hash}, toHash(txs...))
+
+	assert.NoError(t, pool.rejournal())
+
+	txs, err = newTxJournal(path).load()
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{publicTx.Hash}, toHash(txs...))
+}
+
+func TestPrivateTxService_Forward(t *testing.T) {
+	t.Parallel()
+
+	key := new(eoa).create(t)
+
+	testCases := []struct {
+		name     string
+		sealing  bool
+		raw      *any.Any
+		expected error
+	}{
+		{"not a validator", false, &any.Any{}, ErrNotValidator},
+		{"empty raw", true, nil, ErrEmptyPrivateTxData},
+		{"added", true, &any.Any{Value: newJournalTx(t, key, 0).MarshalRLP()}, nil},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := newTestPool()
+			assert.NoError(t, err)
+
+			pool.SetSigner(signerEIP155)
+			pool.SetSealing(tc.sealing)
+
+			pool.Start()
+			defer pool.Close()
+
+			service := &privateTxService{pool: pool}
+
+			_, err = service.Forward(context.Background(), &proto.PrivateTxnReq{
+				Raw:            tc.raw,
+				MaxBlockNumber: 5,
+			})
+			assert.ErrorIs(t, err, tc.expected)
+
+			if tc.expected == nil {
+				assert.Len(t, pool.index.list(), 1)
+				assert.Len(t, pool.privateTxs.maxBlocks, 1)
+			}
+		})
+	}
+}
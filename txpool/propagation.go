@@ -0,0 +1,720 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/network/grpc"
+	"github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	txPropagationProto = "/txpool/propagation/0.1"
+
+	// time the announcements and pushes are batched for
+	propagationInterval = 100 * time.Millisecond
+
+	// timeout of a propagation request to a peer
+	propagationTimeout = 5 * time.Second
+
+	// maximum number of transactions (or hashes) in a single message
+	propagationBatchSize = 256
+
+	// maximum number of transactions (or hashes) waiting to be sent to a peer
+	maxPeerQueue = 4096
+
+	// maximum number of transactions requested from a peer, and not received yet
+	maxInflightFetches = 1024
+)
+
+var (
+	ErrPropagationBatchTooLarge = errors.New("too many transactions in the batch")
+	ErrUnknownPropagationPeer   = errors.New("unable to identify the peer")
+)
+
+// propagationPeer keeps the transactions waiting to be sent to a peer
+type propagationPeer struct {
+	sync.Mutex
+
+	id peer.ID
+
+	// client of the peer, opened on the first request
+	client  proto.TxnPropagationClient
+	closeFn func() error
+
+	// transactions sent in full, and announced by their hashes
+	txs    []*types.Transaction
+	hashes []types.Hash
+
+	// indicates if a batch is being sent to the peer
+	sending bool
+
+	// number of transactions requested from the peer, and not received yet
+	inflight int
+
+	// indicates if the peer doesn't support the propagation protocol,
+	// in which case it is reached through the gossip topic
+	legacy bool
+}
+
+// dial returns the client of the peer, opening a new stream if needed.
+// The peer lock must be held.
+func (pp *propagationPeer) dial(prop *propagator) (proto.TxnPropagationClient, error) {
+	if pp.client != nil {
+		return pp.client, nil
+	}
+
+	client, closeFn, err := prop.connect(pp.id)
+	if err != nil {
+		return nil, err
+	}
+
+	pp.client, pp.closeFn = client, closeFn
+
+	return client, nil
+}
+
+// reset closes the stream to the peer, so it is opened again by the next request.
+// The peer lock must be held.
+func (pp *propagationPeer) reset() {
+	if pp.closeFn != nil {
+		_ = pp.closeFn()
+	}
+
+	pp.client, pp.closeFn = nil, nil
+}
+
+// propagator sends the pool transactions to the peers. The transactions are pushed
+// in full to a sqrt(n) subset of the peers, and only announced to the others,
+// which request the bodies they don't know yet. The peers which can't be reached
+// through the propagation protocol get the transactions from the gossip topic.
+type propagator struct {
+	logger  hclog.Logger
+	network txNetwork
+
+	// opens a stream to the peer
+	connect func(peer.ID) (proto.TxnPropagationClient, func() error, error)
+
+	// publishes the transactions to the gossip topic, nil without it
+	publish func(txs []*types.Transaction, hashes []types.Hash)
+
+	peersLock sync.Mutex
+	peers     map[peer.ID]*propagationPeer
+
+	// transactions requested from the peers, and not received yet
+	fetchingLock sync.Mutex
+	fetching     map[types.Hash]struct{}
+
+	closeCh chan struct{}
+}
+
+func newPropagator(logger hclog.Logger, network txNetwork) *propagator {
+	prop := &propagator{
+		logger:   logger,
+		network:  network,
+		peers:    make(map[peer.ID]*propagationPeer),
+		fetching: make(map[types.Hash]struct{}),
+		closeCh:  make(chan struct{}),
+	}
+
+	prop.connect = func(peerID peer.ID) (proto.TxnPropagationClient, func() error, error) {
+		conn, err := network.NewProtoConnection(txPropagationProto, peerID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return proto.NewTxnPropagationClient(conn), conn.Close, nil
+	}
+
+	return prop
+}
+
+// getPeer returns the propagation state of the peer, created if needed
+func (prop *propagator) getPeer(peerID peer.ID) *propagationPeer {
+	prop.peersLock.Lock()
+	defer prop.peersLock.Unlock()
+
+	pp, ok := prop.peers[peerID]
+	if !ok {
+		pp = &propagationPeer{id: peerID}
+		prop.peers[peerID] = pp
+	}
+
+	return pp
+}
+
+// queue schedules the transaction to be sent to the connected peers, except the given one.
+// The transaction is published to the gossip topic if some of the peers don't support
+// the propagation protocol
+func (prop *propagator) queue(tx *types.Transaction, from peer.ID) {
+	var (
+		peers  = make([]peer.ID, 0)
+		legacy = false
+	)
+
+	for _, info := range prop.network.Peers() {
+		if info.Info.ID == from {
+			continue
+		}
+
+		if prop.isLegacy(info.Info.ID) {
+			legacy = true
+
+			continue
+		}
+
+		peers = append(peers, info.Info.ID)
+	}
+
+	if legacy {
+		prop.fallback([]*types.Transaction{tx}, nil)
+	}
+
+	rand.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+
+	// the transaction is pushed in full to sqrt(n) peers, and announced to the others
+	direct := int(math.Ceil(math.Sqrt(float64(len(peers)))))
+
+	for idx, peerID := range peers {
+		pp := prop.getPeer(peerID)
+
+		pp.Lock()
+
+		if len(pp.txs)+len(pp.hashes) >= maxPeerQueue {
+			pp.Unlock()
+			prop.logger.Debug("propagation queue full, tx dropped", "peer", peerID, "hash", tx.Hash)
+
+			continue
+		}
+
+		if idx < direct {
+			pp.txs = append(pp.txs, tx)
+		} else {
+			pp.hashes = append(pp.hashes, tx.Hash)
+		}
+
+		pp.Unlock()
+	}
+}
+
+// isLegacy checks if the peer is known not to support the propagation protocol
+func (prop *propagator) isLegacy(peerID peer.ID) bool {
+	prop.peersLock.Lock()
+	pp, ok := prop.peers[peerID]
+	prop.peersLock.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	pp.Lock()
+	defer pp.Unlock()
+
+	return pp.legacy
+}
+
+// fallback publishes the transactions which couldn't be propagated to the gossip topic
+func (prop *propagator) fallback(txs []*types.Transaction, hashes []types.Hash) {
+	if prop.publish == nil || (len(txs) == 0 && len(hashes) == 0) {
+		return
+	}
+
+	prop.publish(txs, hashes)
+}
+
+// run sends the queued batches periodically, until the propagator is closed
+func (prop *propagator) run() {
+	ticker := time.NewTicker(propagationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-prop.closeCh:
+			return
+		case <-ticker.C:
+			prop.flush()
+		}
+	}
+}
+
+// flush sends the next batch queued for each peer, and forgets the disconnected peers
+func (prop *propagator) flush() {
+	prop.peersLock.Lock()
+	defer prop.peersLock.Unlock()
+
+	for peerID, pp := range prop.peers {
+		pp.Lock()
+
+		if !prop.network.IsConnected(peerID) {
+			pp.reset()
+			pp.Unlock()
+
+			delete(prop.peers, peerID)
+
+			continue
+		}
+
+		if pp.sending || pp.legacy || (len(pp.txs) == 0 && len(pp.hashes) == 0) {
+			pp.Unlock()
+
+			continue
+		}
+
+		txs := pp.txs[:batchSize(len(pp.txs))]
+		pp.txs = pp.txs[len(txs):]
+
+		hashes := pp.hashes[:batchSize(len(pp.hashes))]
+		pp.hashes = pp.hashes[len(hashes):]
+
+		pp.sending = true
+
+		pp.Unlock()
+
+		go prop.send(pp, txs, hashes)
+	}
+}
+
+// batchSize returns the size of the next batch taken from a queue of the given length
+func batchSize(length int) int {
+	if length > propagationBatchSize {
+		return propagationBatchSize
+	}
+
+	return length
+}
+
+// send pushes the transactions to the peer, and announces the hashes.
+// The batch is published to the gossip topic if it can't be sent, and the peer
+// is reached through the topic from then on if it doesn't support the protocol
+func (prop *propagator) send(pp *propagationPeer, txs []*types.Transaction, hashes []types.Hash) {
+	pp.Lock()
+	client, dialErr := pp.dial(prop)
+	pp.Unlock()
+
+	err := dialErr
+	if err == nil {
+		err = prop.sendBatch(client, txs, hashes)
+	}
+
+	pp.Lock()
+
+	pp.sending = false
+
+	if err != nil {
+		prop.logger.Debug("failed to propagate txs", "peer", pp.id, "err", err)
+
+		pp.reset()
+
+		if dialErr != nil {
+			pp.legacy = true
+
+			// the queued transactions can't be sent through the protocol either
+			txs, hashes = append(txs, pp.txs...), append(hashes, pp.hashes...)
+			pp.txs, pp.hashes = nil, nil
+		}
+	}
+
+	pp.Unlock()
+
+	if err != nil {
+		prop.fallback(txs, hashes)
+	}
+}
+
+func (prop *propagator) sendBatch(
+	client proto.TxnPropagationClient,
+	txs []*types.Transaction,
+	hashes []types.Hash,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), propagationTimeout)
+	defer cancel()
+
+	if len(txs) > 0 {
+		if _, err := client.Push(ctx, toTxnBatch(txs)); err != nil {
+			return fmt.Errorf("unable to push txs, %w", err)
+		}
+	}
+
+	if len(hashes) > 0 {
+		if _, err := client.Announce(ctx, toTxnHashes(hashes)); err != nil {
+			return fmt.Errorf("unable to announce txs, %w", err)
+		}
+	}
+
+	return nil
+}
+
+// markFetching marks the given transactions as requested,
+// and returns the ones which weren't already
+func (prop *propagator) markFetching(hashes []types.Hash) []types.Hash {
+	prop.fetchingLock.Lock()
+	defer prop.fetchingLock.Unlock()
+
+	marked := make([]types.Hash, 0, len(hashes))
+
+	for _, hash := range hashes {
+		if _, ok := prop.fetching[hash]; ok {
+			continue
+		}
+
+		prop.fetching[hash] = struct{}{}
+		marked = append(marked, hash)
+	}
+
+	return marked
+}
+
+func (prop *propagator) unmarkFetching(hashes []types.Hash) {
+	prop.fetchingLock.Lock()
+	defer prop.fetchingLock.Unlock()
+
+	for _, hash := range hashes {
+		delete(prop.fetching, hash)
+	}
+}
+
+// reserveFetches reserves the in-flight fetches of the peer for
+// as many of the hashes as allowed, and returns them
+func (prop *propagator) reserveFetches(pp *propagationPeer, hashes []types.Hash) []types.Hash {
+	pp.Lock()
+	defer pp.Unlock()
+
+	allowed := maxInflightFetches - pp.inflight
+	if allowed <= 0 {
+		return nil
+	}
+
+	if len(hashes) > allowed {
+		hashes = hashes[:allowed]
+	}
+
+	pp.inflight += len(hashes)
+
+	return hashes
+}
+
+func (prop *propagator) releaseFetches(pp *propagationPeer, count int) {
+	pp.Lock()
+	defer pp.Unlock()
+
+	pp.inflight -= count
+}
+
+func (prop *propagator) close() {
+	close(prop.closeCh)
+
+	prop.peersLock.Lock()
+	defer prop.peersLock.Unlock()
+
+	for _, pp := range prop.peers {
+		pp.Lock()
+		pp.reset()
+		pp.Unlock()
+	}
+}
+
+func toTxnBatch(txs []*types.Transaction) *proto.TxnBatch {
+	batch := &proto.TxnBatch{
+		Raw: make([]*any.Any, len(txs)),
+	}
+
+	for idx, tx := range txs {
+		batch.Raw[idx] = &any.Any{
+			Value: tx.MarshalRLP(),
+		}
+	}
+
+	return batch
+}
+
+func toTxnHashes(hashes []types.Hash) *proto.TxnHashes {
+	req := &proto.TxnHashes{
+		Hashes: make([][]byte, len(hashes)),
+	}
+
+	for idx, hash := range hashes {
+		req.Hashes[idx] = hash.Bytes()
+	}
+
+	return req
+}
+
+// fromTxnHashes decodes the hashes of the request, skipping the malformed ones
+func fromTxnHashes(req *proto.TxnHashes) []types.Hash {
+	hashes := make([]types.Hash, 0, len(req.Hashes))
+
+	for _, raw := range req.Hashes {
+		if len(raw) != types.HashLength {
+			continue
+		}
+
+		hashes = append(hashes, types.BytesToHash(raw))
+	}
+
+	return hashes
+}
+
+// propagationService receives the transactions propagated by the peers
+type propagationService struct {
+	proto.UnimplementedTxnPropagationServer
+
+	pool *TxPool
+}
+
+// peerID returns the peer the request comes from
+func peerID(ctx context.Context) (peer.ID, error) {
+	grpcCtx, ok := ctx.(*grpc.Context)
+	if !ok {
+		return "", ErrUnknownPropagationPeer
+	}
+
+	return grpcCtx.PeerID, nil
+}
+
+// Announce requests the announced transactions which are not known yet
+func (s *propagationService) Announce(ctx context.Context, req *proto.TxnHashes) (*empty.Empty, error) {
+	if len(req.Hashes) > propagationBatchSize {
+		return nil, ErrPropagationBatchTooLarge
+	}
+
+	from, err := peerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.pool.acceptsPropagation(from) {
+		return &empty.Empty{}, nil
+	}
+
+	unknown := make([]types.Hash, 0)
+
+	for _, hash := range fromTxnHashes(req) {
+		if _, ok := s.pool.index.get(hash); !ok {
+			unknown = append(unknown, hash)
+		}
+	}
+
+	if len(unknown) > 0 {
+		s.pool.fetchTxs(from, unknown)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Push adds the transactions pushed by the peer
+func (s *propagationService) Push(ctx context.Context, req *proto.TxnBatch) (*empty.Empty, error) {
+	if len(req.Raw) > propagationBatchSize {
+		return nil, ErrPropagationBatchTooLarge
+	}
+
+	from, err := peerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.pool.acceptsPropagation(from) {
+		return &empty.Empty{}, nil
+	}
+
+	for _, raw := range req.Raw {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(raw.Value); err != nil {
+			s.pool.penalizePeer(from, invalidTxPenalty, "gossip_invalid_transactions", err)
+
+			return nil, fmt.Errorf("failed to decode pushed tx, %w", err)
+		}
+
+		s.pool.addPropagatedTx(tx, from)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// GetTxns returns the requested transactions present in the pool, except the private ones
+func (s *propagationService) GetTxns(_ context.Context, req *proto.TxnHashes) (*proto.TxnBatch, error) {
+	if len(req.Hashes) > propagationBatchSize {
+		return nil, ErrPropagationBatchTooLarge
+	}
+
+	txs := make([]*types.Transaction, 0, len(req.Hashes))
+
+	for _, hash := range fromTxnHashes(req) {
+		tx, ok := s.pool.index.get(hash)
+		if !ok || s.pool.privateTxs.isPrivate(hash) {
+			continue
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return toTxnBatch(txs), nil
+}
+
+// setupPropagation registers the protocol receiving the transactions propagated by the peers
+func (p *TxPool) setupPropagation(network txNetwork) {
+	p.propagator = newPropagator(p.logger.Named("propagation"), network)
+
+	if p.topic != nil {
+		p.propagator.publish = p.publishTxs
+	}
+
+	stream := grpc.NewGrpcStream()
+
+	proto.RegisterTxnPropagationServer(stream.GrpcServer(), &propagationService{pool: p})
+	stream.Serve()
+
+	network.RegisterProtocol(txPropagationProto, stream)
+}
+
+// propagate sends the transaction to the peers, except the one it was received from
+func (p *TxPool) propagate(tx *types.Transaction, from peer.ID) {
+	if p.propagator == nil || p.privateTxs.isPrivate(tx.Hash) {
+		return
+	}
+
+	p.propagator.queue(tx, from)
+}
+
+// publishTxs publishes the transactions, and the ones of the hashes still in the pool,
+// to the gossip topic, for the peers which can't be reached through the propagation protocol
+func (p *TxPool) publishTxs(txs []*types.Transaction, hashes []types.Hash) {
+	for _, hash := range hashes {
+		if tx, ok := p.index.get(hash); ok {
+			txs = append(txs, tx)
+		}
+	}
+
+	for _, tx := range txs {
+		if p.privateTxs.isPrivate(tx.Hash) {
+			continue
+		}
+
+		msg := &proto.Txn{
+			Raw: &any.Any{
+				Value: tx.MarshalRLP(),
+			},
+		}
+
+		if err := p.topic.Publish(msg); err != nil {
+			p.logger.Error("failed to publish tx", "err", err, "hash", tx.Hash.String())
+		}
+	}
+}
+
+// fetchTxs requests the announced transactions from the peer, in batches,
+// as long as the in-flight limit of the peer allows it
+func (p *TxPool) fetchTxs(from peer.ID, hashes []types.Hash) {
+	prop := p.propagator
+	pp := prop.getPeer(from)
+
+	// the transactions already requested from another peer are skipped
+	hashes = prop.markFetching(hashes)
+
+	reserved := prop.reserveFetches(pp, hashes)
+	prop.unmarkFetching(hashes[len(reserved):])
+
+	for len(reserved) > 0 {
+		batch := reserved[:batchSize(len(reserved))]
+		reserved = reserved[len(batch):]
+
+		go func() {
+			defer func() {
+				prop.unmarkFetching(batch)
+				prop.releaseFetches(pp, len(batch))
+			}()
+
+			if err := p.fetchBatch(pp, batch); err != nil {
+				p.logger.Debug("failed to fetch txs", "peer", from, "err", err)
+			}
+		}()
+	}
+}
+
+func (p *TxPool) fetchBatch(pp *propagationPeer, hashes []types.Hash) error {
+	pp.Lock()
+	client, err := pp.dial(p.propagator)
+	pp.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), propagationTimeout)
+	defer cancel()
+
+	resp, err := client.GetTxns(ctx, toTxnHashes(hashes))
+	if err != nil {
+		pp.Lock()
+		pp.reset()
+		pp.Unlock()
+
+		return err
+	}
+
+	requested := make(map[types.Hash]struct{}, len(hashes))
+	for _, hash := range hashes {
+		requested[hash] = struct{}{}
+	}
+
+	for _, raw := range resp.Raw {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(raw.Value); err != nil {
+			p.penalizePeer(pp.id, invalidTxPenalty, "gossip_invalid_transactions", err)
+
+			return fmt.Errorf("failed to decode fetched tx, %w", err)
+		}
+
+		tx.ComputeHash()
+
+		// the peer only gets to send what was requested
+		if _, ok := requested[tx.Hash]; !ok {
+			continue
+		}
+
+		p.addPropagatedTx(tx, pp.id)
+	}
+
+	return nil
+}
+
+// acceptsPropagation returns false if the transactions propagated by the peer should be dropped.
+// Like the gossiped ones, they are only accepted by the sealing nodes
+func (p *TxPool) acceptsPropagation(from peer.ID) bool {
+	return p.getSealing() && p.allowPeer(from)
+}
+
+// addPropagatedTx adds the transaction received from the peer,
+// and propagates it further if it wasn't known yet
+func (p *TxPool) addPropagatedTx(tx *types.Transaction, from peer.ID) {
+	// the sealing may have stopped while the transaction was fetched
+	if !p.getSealing() {
+		return
+	}
+
+	if err := p.addTx(gossip, tx); err != nil {
+		if errors.Is(err, ErrAlreadyKnown) {
+			p.logger.Debug("rejecting known tx (propagation)", "hash", tx.Hash.String())
+
+			return
+		}
+
+		p.logger.Error("failed to add propagated tx", "err", err, "hash", tx.Hash.String())
+		p.scoreGossipError(from, err)
+
+		return
+	}
+
+	p.propagate(tx, from)
+}
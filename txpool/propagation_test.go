@@ -0,0 +1,533 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/network"
+	"github.com/SECRYPT-2022/SECRYPT/network/grpc"
+	"github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	rawGrpc "google.golang.org/grpc"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+type mockTxNetwork struct {
+	sync.Mutex
+
+	peers        []peer.ID
+	disconnected map[peer.ID]bool
+	banned       []peer.ID
+}
+
+func (m *mockTxNetwork) Peers() []*network.PeerConnInfo {
+	m.Lock()
+	defer m.Unlock()
+
+	infos := make([]*network.PeerConnInfo, len(m.peers))
+	for idx, peerID := range m.peers {
+		infos[idx] = &network.PeerConnInfo{Info: peer.AddrInfo{ID: peerID}}
+	}
+
+	return infos
+}
+
+func (m *mockTxNetwork) IsConnected(peerID peer.ID) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	return !m.disconnected[peerID]
+}
+
+func (m *mockTxNetwork) NewProtoConnection(string, peer.ID) (*rawGrpc.ClientConn, error) {
+	return nil, errors.New("not supported")
+}
+
+func (m *mockTxNetwork) RegisterProtocol(string, network.Protocol) {}
+
+func (m *mockTxNetwork) BanPeer(peerID peer.ID, _ time.Duration, _ string) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.banned = append(m.banned, peerID)
+}
+
+// mockPropagationClient records the requests sent to a peer
+type mockPropagationClient struct {
+	sync.Mutex
+
+	pushed    []types.Hash
+	announced []types.Hash
+	requested []types.Hash
+
+	// transactions returned by GetTxns
+	txs map[types.Hash]*types.Transaction
+
+	// error returned by Push, if set
+	pushErr error
+}
+
+func (m *mockPropagationClient) Announce(
+	_ context.Context,
+	in *proto.TxnHashes,
+	_ ...rawGrpc.CallOption,
+) (*empty.Empty, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.announced = append(m.announced, fromTxnHashes(in)...)
+
+	return &empty.Empty{}, nil
+}
+
+func (m *mockPropagationClient) Push(
+	_ context.Context,
+	in *proto.TxnBatch,
+	_ ...rawGrpc.CallOption,
+) (*empty.Empty, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.pushErr != nil {
+		return nil, m.pushErr
+	}
+
+	for _, raw := range in.Raw {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(raw.Value); err != nil {
+			return nil, err
+		}
+
+		tx.ComputeHash()
+
+		m.pushed = append(m.pushed, tx.Hash)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (m *mockPropagationClient) GetTxns(
+	_ context.Context,
+	in *proto.TxnHashes,
+	_ ...rawGrpc.CallOption,
+) (*proto.TxnBatch, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	txs := make([]*types.Transaction, 0)
+
+	for _, hash := range fromTxnHashes(in) {
+		m.requested = append(m.requested, hash)
+
+		if tx, ok := m.txs[hash]; ok {
+			txs = append(txs, tx)
+		}
+	}
+
+	return toTxnBatch(txs), nil
+}
+
+func (m *mockPropagationClient) counts() (int, int, int) {
+	m.Lock()
+	defer m.Unlock()
+
+	return len(m.pushed), len(m.announced), len(m.requested)
+}
+
+// newPropagationTestPool returns a pool connected to the given peers,
+// with a mock client for each of them
+func newPropagationTestPool(
+	t *testing.T,
+	peers ...peer.ID,
+) (*TxPool, *mockTxNetwork, map[peer.ID]*mockPropagationClient) {
+	t.Helper()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+
+	pool.SetSigner(&mockSigner{})
+	pool.SetSealing(true)
+
+	net := &mockTxNetwork{
+		peers:        peers,
+		disconnected: map[peer.ID]bool{},
+	}
+
+	clients := make(map[peer.ID]*mockPropagationClient, len(peers))
+	for _, peerID := range peers {
+		clients[peerID] = &mockPropagationClient{
+			txs: map[types.Hash]*types.Transaction{},
+		}
+	}
+
+	pool.propagator = newPropagator(hclog.NewNullLogger(), net)
+	pool.propagator.connect = func(peerID peer.ID) (proto.TxnPropagationClient, func() error, error) {
+		return clients[peerID], func() error { return nil }, nil
+	}
+
+	return pool, net, clients
+}
+
+func TestPropagator_Queue(t *testing.T) {
+	t.Parallel()
+
+	peers := []peer.ID{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J"}
+
+	pool, _, _ := newPropagationTestPool(t, peers...)
+
+	tx := newTx(addr1, 0, 1)
+	tx.ComputeHash()
+
+	// the sender is skipped
+	pool.propagate(tx, "A")
+
+	var pushed, announced int
+
+	for _, peerID := range peers {
+		pp := pool.propagator.getPeer(peerID)
+
+		pushed += len(pp.txs)
+		announced += len(pp.hashes)
+	}
+
+	assert.Equal(t, 3, pushed)
+	assert.Equal(t, 6, announced)
+	assert.Len(t, pool.propagator.getPeer("A").txs, 0)
+	assert.Len(t, pool.propagator.getPeer("A").hashes, 0)
+}
+
+func TestPropagator_QueuePrivateTx(t *testing.T) {
+	t.Parallel()
+
+	pool, _, _ := newPropagationTestPool(t, "A")
+
+	tx := newTx(addr1, 0, 1)
+	tx.ComputeHash()
+
+	pool.privateTxs.add(tx.Hash, 10)
+	pool.propagate(tx, "")
+
+	pp := pool.propagator.getPeer("A")
+	assert.Len(t, pp.txs, 0)
+	assert.Len(t, pp.hashes, 0)
+}
+
+func TestPropagator_Flush(t *testing.T) {
+	t.Parallel()
+
+	pool, net, clients := newPropagationTestPool(t, "A", "B")
+
+	txs := make([]*types.Transaction, propagationBatchSize+1)
+	for idx := range txs {
+		txs[idx] = newTx(addr1, uint64(idx), 1)
+		txs[idx].ComputeHash()
+	}
+
+	// A gets the txs in full, B only the hashes
+	ppA, ppB := pool.propagator.getPeer("A"), pool.propagator.getPeer("B")
+	ppA.txs = txs
+
+	for _, tx := range txs {
+		ppB.hashes = append(ppB.hashes, tx.Hash)
+	}
+
+	pool.propagator.flush()
+
+	assert.Eventually(t, func() bool {
+		pushed, _, _ := clients["A"].counts()
+		_, announced, _ := clients["B"].counts()
+
+		return pushed == propagationBatchSize && announced == propagationBatchSize
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the last tx is sent with the next batch, unless the peer is gone
+	assert.Eventually(t, func() bool {
+		ppA.Lock()
+		defer ppA.Unlock()
+
+		return !ppA.sending
+	}, 5*time.Second, 10*time.Millisecond)
+
+	net.Lock()
+	net.disconnected["B"] = true
+	net.Unlock()
+
+	pool.propagator.flush()
+
+	assert.Eventually(t, func() bool {
+		pushed, _, _ := clients["A"].counts()
+
+		return pushed == len(txs)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	pool.propagator.peersLock.Lock()
+	assert.Len(t, pool.propagator.peers, 1)
+	pool.propagator.peersLock.Unlock()
+
+	_, announced, _ := clients["B"].counts()
+	assert.Equal(t, propagationBatchSize, announced)
+}
+
+func TestPropagator_Fallback(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, peers ...peer.ID) (*TxPool, map[peer.ID]*mockPropagationClient, func() []types.Hash) {
+		t.Helper()
+
+		pool, _, clients := newPropagationTestPool(t, peers...)
+
+		var (
+			lock      sync.Mutex
+			published []types.Hash
+		)
+
+		pool.propagator.publish = func(txs []*types.Transaction, hashes []types.Hash) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			for _, tx := range txs {
+				published = append(published, tx.Hash)
+			}
+
+			published = append(published, hashes...)
+		}
+
+		return pool, clients, func() []types.Hash {
+			lock.Lock()
+			defer lock.Unlock()
+
+			return append([]types.Hash{}, published...)
+		}
+	}
+
+	t.Run("publishes the txs of the peers without the protocol", func(t *testing.T) {
+		t.Parallel()
+
+		pool, _, published := setup(t, "A")
+		pool.propagator.connect = func(peer.ID) (proto.TxnPropagationClient, func() error, error) {
+			return nil, nil, errors.New("protocol not supported")
+		}
+
+		first, second := newTx(addr1, 0, 1), newTx(addr1, 1, 1)
+		first.ComputeHash()
+		second.ComputeHash()
+
+		pool.propagate(first, "")
+		pool.propagator.flush()
+
+		assert.Eventually(t, func() bool {
+			return len(published()) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, first.Hash, published()[0])
+		assert.True(t, pool.propagator.isLegacy("A"))
+
+		// the peer isn't queued anymore, the tx is published right away
+		pool.propagate(second, "")
+
+		assert.Equal(t, []types.Hash{first.Hash, second.Hash}, published())
+
+		pp := pool.propagator.getPeer("A")
+		assert.Len(t, pp.txs, 0)
+		assert.Len(t, pp.hashes, 0)
+	})
+
+	t.Run("publishes the batch failed to be sent", func(t *testing.T) {
+		t.Parallel()
+
+		pool, clients, published := setup(t, "A")
+		clients["A"].pushErr = errors.New("stream reset")
+
+		tx := newTx(addr1, 0, 1)
+		tx.ComputeHash()
+
+		pool.propagate(tx, "")
+		pool.propagator.flush()
+
+		assert.Eventually(t, func() bool {
+			return len(published()) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, tx.Hash, published()[0])
+
+		// the stream is opened again by the next batch
+		assert.False(t, pool.propagator.isLegacy("A"))
+	})
+}
+
+func TestPropagationService_NonSealing(t *testing.T) {
+	t.Parallel()
+
+	pool, _, clients := newPropagationTestPool(t, "A", "B")
+	pool.SetSealing(false)
+
+	pool.Start()
+	t.Cleanup(pool.Close)
+
+	pushedTx := newTx(addr1, 0, 1)
+	announcedTx := newTx(addr2, 0, 1)
+
+	announcedTx.ComputeHash()
+	clients["A"].txs[announcedTx.Hash] = announcedTx
+
+	service := &propagationService{pool: pool}
+	ctx := &grpc.Context{Context: context.Background(), PeerID: "A"}
+
+	// the propagated txs are dropped, like the gossiped ones
+	_, err := service.Push(ctx, toTxnBatch([]*types.Transaction{pushedTx}))
+	assert.NoError(t, err)
+
+	_, err = service.Announce(ctx, toTxnHashes([]types.Hash{announcedTx.Hash}))
+	assert.NoError(t, err)
+
+	// and so are the ones fetched before the sealing stopped
+	pool.addPropagatedTx(announcedTx, "A")
+
+	pool.propagator.flush()
+
+	assert.Len(t, pool.index.list(), 0)
+
+	for _, peerID := range []peer.ID{"A", "B"} {
+		pushed, announced, requested := clients[peerID].counts()
+		assert.Equal(t, 0, pushed)
+		assert.Equal(t, 0, announced)
+		assert.Equal(t, 0, requested)
+	}
+}
+
+func TestPropagationService_Announce(t *testing.T) {
+	t.Parallel()
+
+	peerCtx := func(peerID peer.ID) context.Context {
+		return &grpc.Context{Context: context.Background(), PeerID: peerID}
+	}
+
+	t.Run("fetches the unknown txs and propagates them", func(t *testing.T) {
+		t.Parallel()
+
+		pool, _, clients := newPropagationTestPool(t, "A", "B")
+		pool.Start()
+		t.Cleanup(pool.Close)
+
+		known := newTx(addr1, 0, 1)
+		unknown := newTx(addr2, 0, 1)
+
+		assert.NoError(t, pool.addTx(local, known))
+
+		unknown.ComputeHash()
+		clients["A"].txs[unknown.Hash] = unknown
+
+		service := &propagationService{pool: pool}
+
+		_, err := service.Announce(peerCtx("A"), toTxnHashes([]types.Hash{known.Hash, unknown.Hash}))
+		assert.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			_, ok := pool.index.get(unknown.Hash)
+
+			return ok
+		}, 5*time.Second, 10*time.Millisecond)
+
+		clients["A"].Lock()
+		assert.Equal(t, []types.Hash{unknown.Hash}, clients["A"].requested)
+		clients["A"].Unlock()
+
+		// relayed to the other peers only
+		assert.Eventually(t, func() bool {
+			pushed, _, _ := clients["B"].counts()
+
+			return pushed == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		pushed, announced, _ := clients["A"].counts()
+		assert.Equal(t, 0, pushed)
+		assert.Equal(t, 0, announced)
+
+		ppA := pool.propagator.getPeer("A")
+		ppA.Lock()
+		assert.Equal(t, 0, ppA.inflight)
+		ppA.Unlock()
+	})
+
+	t.Run("respects the in-flight limit", func(t *testing.T) {
+		t.Parallel()
+
+		pool, _, clients := newPropagationTestPool(t, "A")
+
+		pp := pool.propagator.getPeer("A")
+		pp.inflight = maxInflightFetches
+
+		tx := newTx(addr2, 0, 1)
+		tx.ComputeHash()
+
+		service := &propagationService{pool: pool}
+
+		_, err := service.Announce(peerCtx("A"), toTxnHashes([]types.Hash{tx.Hash}))
+		assert.NoError(t, err)
+
+		_, _, requested := clients["A"].counts()
+		assert.Equal(t, 0, requested)
+
+		// not marked as fetching, so another peer can be asked
+		pool.propagator.fetchingLock.Lock()
+		assert.Len(t, pool.propagator.fetching, 0)
+		pool.propagator.fetchingLock.Unlock()
+	})
+
+	t.Run("skips the txs being fetched", func(t *testing.T) {
+		t.Parallel()
+
+		pool, _, clients := newPropagationTestPool(t, "A")
+
+		tx := newTx(addr2, 0, 1)
+		tx.ComputeHash()
+
+		pool.propagator.markFetching([]types.Hash{tx.Hash})
+
+		service := &propagationService{pool: pool}
+
+		_, err := service.Announce(peerCtx("A"), toTxnHashes([]types.Hash{tx.Hash}))
+		assert.NoError(t, err)
+
+		_, _, requested := clients["A"].counts()
+		assert.Equal(t, 0, requested)
+	})
+
+	t.Run("rejects too large batches", func(t *testing.T) {
+		t.Parallel()
+
+		pool, _, _ := newPropagationTestPool(t, "A")
+		service := &propagationService{pool: pool}
+
+		_, err := service.Announce(peerCtx("A"), toTxnHashes(make([]types.Hash, propagationBatchSize+1)))
+		assert.ErrorIs(t, err, ErrPropagationBatchTooLarge)
+	})
+}
+
+func TestPropagationService_GetTxns(t *testing.T) {
+	t.Parallel()
+
+	pool, _, _ := newPropagationTestPool(t)
+	pool.Start()
+	t.Cleanup(pool.Close)
+
+	public := newTx(addr1, 0, 1)
+	private := newTx(addr2, 0, 1)
+
+	assert.NoError(t, pool.addTx(local, public))
+	assert.NoError(t, pool.addPrivateTx(private, 10))
+
+	service := &propagationService{pool: pool}
+
+	resp, err := service.GetTxns(
+		context.Background(),
+		toTxnHashes([]types.Hash{public.Hash, private.Hash, types.StringToHash("0x1")}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, toTxnBatch([]*types.Transaction{public}).Raw[0].Value, resp.Raw[0].Value)
+	assert.Len(t, resp.Raw, 1)
+}
@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.3
+// source: operator.proto
 
 package proto
 
@@ -25,6 +29,19 @@ type TxnPoolOperatorClient interface {
 	AddTxn(ctx context.Context, in *AddTxnReq, opts ...grpc.CallOption) (*AddTxnResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (TxnPoolOperator_SubscribeClient, error)
+	// ListTxns returns the transactions in the pool, ordered by account and nonce
+	ListTxns(ctx context.Context, in *ListTxnsReq, opts ...grpc.CallOption) (*ListTxnsResp, error)
+	// GetTxn returns the transaction in the pool with the given hash
+	GetTxn(ctx context.Context, in *GetTxnReq, opts ...grpc.CallOption) (*GetTxnResp, error)
+	// RemoveTxn removes the transaction from the pool,
+	// the following transactions of the account are enqueued again
+	RemoveTxn(ctx context.Context, in *RemoveTxnReq, opts ...grpc.CallOption) (*RemoveTxnsResp, error)
+	// RemoveAccount removes all the transactions of the account from the pool
+	RemoveAccount(ctx context.Context, in *AccountReq, opts ...grpc.CallOption) (*RemoveTxnsResp, error)
+	// AccountStatus returns the status of the account in the pool
+	AccountStatus(ctx context.Context, in *AccountReq, opts ...grpc.CallOption) (*AccountStatusResp, error)
+	// TxnStatus returns the state of the transaction in the pool, along with its lifecycle history
+	TxnStatus(ctx context.Context, in *GetTxnReq, opts ...grpc.CallOption) (*TxnStatusResp, error)
 }
 
 type txnPoolOperatorClient struct {
@@ -85,6 +102,60 @@ func (x *txnPoolOperatorSubscribeClient) Recv() (*TxPoolEvent, error) {
 	return m, nil
 }
 
+func (c *txnPoolOperatorClient) ListTxns(ctx context.Context, in *ListTxnsReq, opts ...grpc.CallOption) (*ListTxnsResp, error) {
+	out := new(ListTxnsResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/ListTxns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) GetTxn(ctx context.Context, in *GetTxnReq, opts ...grpc.CallOption) (*GetTxnResp, error) {
+	out := new(GetTxnResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/GetTxn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) RemoveTxn(ctx context.Context, in *RemoveTxnReq, opts ...grpc.CallOption) (*RemoveTxnsResp, error) {
+	out := new(RemoveTxnsResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/RemoveTxn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) RemoveAccount(ctx context.Context, in *AccountReq, opts ...grpc.CallOption) (*RemoveTxnsResp, error) {
+	out := new(RemoveTxnsResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/RemoveAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) AccountStatus(ctx context.Context, in *AccountReq, opts ...grpc.CallOption) (*AccountStatusResp, error) {
+	out := new(AccountStatusResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/AccountStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) TxnStatus(ctx context.Context, in *GetTxnReq, opts ...grpc.CallOption) (*TxnStatusResp, error) {
+	out := new(TxnStatusResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/TxnStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TxnPoolOperatorServer is the server API for TxnPoolOperator service.
 // All implementations must embed UnimplementedTxnPoolOperatorServer
 // for forward compatibility
@@ -95,6 +166,19 @@ type TxnPoolOperatorServer interface {
 	AddTxn(context.Context, *AddTxnReq) (*AddTxnResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error
+	// ListTxns returns the transactions in the pool, ordered by account and nonce
+	ListTxns(context.Context, *ListTxnsReq) (*ListTxnsResp, error)
+	// GetTxn returns the transaction in the pool with the given hash
+	GetTxn(context.Context, *GetTxnReq) (*GetTxnResp, error)
+	// RemoveTxn removes the transaction from the pool,
+	// the following transactions of the account are enqueued again
+	RemoveTxn(context.Context, *RemoveTxnReq) (*RemoveTxnsResp, error)
+	// RemoveAccount removes all the transactions of the account from the pool
+	RemoveAccount(context.Context, *AccountReq) (*RemoveTxnsResp, error)
+	// AccountStatus returns the status of the account in the pool
+	AccountStatus(context.Context, *AccountReq) (*AccountStatusResp, error)
+	// TxnStatus returns the state of the transaction in the pool, along with its lifecycle history
+	TxnStatus(context.Context, *GetTxnReq) (*TxnStatusResp, error)
 	mustEmbedUnimplementedTxnPoolOperatorServer()
 }
 
@@ -111,6 +195,24 @@ func (UnimplementedTxnPoolOperatorServer) AddTxn(context.Context, *AddTxnReq) (*
 func (UnimplementedTxnPoolOperatorServer) Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedTxnPoolOperatorServer) ListTxns(context.Context, *ListTxnsReq) (*ListTxnsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTxns not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) GetTxn(context.Context, *GetTxnReq) (*GetTxnResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxn not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) RemoveTxn(context.Context, *RemoveTxnReq) (*RemoveTxnsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTxn not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) RemoveAccount(context.Context, *AccountReq) (*RemoveTxnsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAccount not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) AccountStatus(context.Context, *AccountReq) (*AccountStatusResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountStatus not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) TxnStatus(context.Context, *GetTxnReq) (*TxnStatusResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxnStatus not implemented")
+}
 func (UnimplementedTxnPoolOperatorServer) mustEmbedUnimplementedTxnPoolOperatorServer() {}
 
 // UnsafeTxnPoolOperatorServer may be embedded to opt out of forward compatibility for this service.
@@ -181,6 +283,114 @@ func (x *txnPoolOperatorSubscribeServer) Send(m *TxPoolEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TxnPoolOperator_ListTxns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTxnsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).ListTxns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/ListTxns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).ListTxns(ctx, req.(*ListTxnsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_GetTxn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).GetTxn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/GetTxn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).GetTxn(ctx, req.(*GetTxnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_RemoveTxn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTxnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).RemoveTxn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/RemoveTxn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).RemoveTxn(ctx, req.(*RemoveTxnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_RemoveAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).RemoveAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/RemoveAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).RemoveAccount(ctx, req.(*AccountReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_AccountStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).AccountStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/AccountStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).AccountStatus(ctx, req.(*AccountReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_TxnStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).TxnStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/TxnStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).TxnStatus(ctx, req.(*GetTxnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TxnPoolOperator_ServiceDesc is the grpc.ServiceDesc for TxnPoolOperator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -196,6 +406,30 @@ var TxnPoolOperator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddTxn",
 			Handler:    _TxnPoolOperator_AddTxn_Handler,
 		},
+		{
+			MethodName: "ListTxns",
+			Handler:    _TxnPoolOperator_ListTxns_Handler,
+		},
+		{
+			MethodName: "GetTxn",
+			Handler:    _TxnPoolOperator_GetTxn_Handler,
+		},
+		{
+			MethodName: "RemoveTxn",
+			Handler:    _TxnPoolOperator_RemoveTxn_Handler,
+		},
+		{
+			MethodName: "RemoveAccount",
+			Handler:    _TxnPoolOperator_RemoveAccount_Handler,
+		},
+		{
+			MethodName: "AccountStatus",
+			Handler:    _TxnPoolOperator_AccountStatus_Handler,
+		},
+		{
+			MethodName: "TxnStatus",
+			Handler:    _TxnPoolOperator_TxnStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
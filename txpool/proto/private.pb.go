@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.3
+// source: private.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PrivateTxnReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Raw *anypb.Any `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
+	// The transaction is dropped after this block
+	MaxBlockNumber uint64 `protobuf:"varint,2,opt,name=maxBlockNumber,proto3" json:"maxBlockNumber,omitempty"`
+}
+
+func (x *PrivateTxnReq) Reset() {
+	*x = PrivateTxnReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_private_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrivateTxnReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrivateTxnReq) ProtoMessage() {}
+
+func (x *PrivateTxnReq) ProtoReflect() protoreflect.Message {
+	mi := &file_private_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrivateTxnReq.ProtoReflect.Descriptor instead.
+func (*PrivateTxnReq) Descriptor() ([]byte, []int) {
+	return file_private_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PrivateTxnReq) GetRaw() *anypb.Any {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+func (x *PrivateTxnReq) GetMaxBlockNumber() uint64 {
+	if x != nil {
+		return x.MaxBlockNumber
+	}
+	return 0
+}
+
+var File_private_proto protoreflect.FileDescriptor
+
+var file_private_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x02, 0x76, 0x31, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x5f, 0x0a, 0x0d, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x03,
+	0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52,
+	0x03, 0x72, 0x61, 0x77, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6d, 0x61,
+	0x78, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x32, 0x42, 0x0a, 0x0a,
+	0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x54, 0x78, 0x6e, 0x12, 0x34, 0x0a, 0x07, 0x46, 0x6f,
+	0x72, 0x77, 0x61, 0x72, 0x64, 0x12, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_private_proto_rawDescOnce sync.Once
+	file_private_proto_rawDescData = file_private_proto_rawDesc
+)
+
+func file_private_proto_rawDescGZIP() []byte {
+	file_private_proto_rawDescOnce.Do(func() {
+		file_private_proto_rawDescData = protoimpl.X.CompressGZIP(file_private_proto_rawDescData)
+	})
+	return file_private_proto_rawDescData
+}
+
+var file_private_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_private_proto_goTypes = []interface{}{
+	(*PrivateTxnReq)(nil), // 0: v1.PrivateTxnReq
+	(*anypb.Any)(nil),     // 1: google.protobuf.Any
+	(*emptypb.Empty)(nil), // 2: google.protobuf.Empty
+}
+var file_private_proto_depIdxs = []int32{
+	1, // 0: v1.PrivateTxnReq.raw:type_name -> google.protobuf.Any
+	0, // 1: v1.PrivateTxn.Forward:input_type -> v1.PrivateTxnReq
+	2, // 2: v1.PrivateTxn.Forward:output_type -> google.protobuf.Empty
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_private_proto_init() }
+func file_private_proto_init() {
+	if File_private_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_private_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrivateTxnReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_private_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_private_proto_goTypes,
+		DependencyIndexes: file_private_proto_depIdxs,
+		MessageInfos:      file_private_proto_msgTypes,
+	}.Build()
+	File_private_proto = out.File
+	file_private_proto_rawDesc = nil
+	file_private_proto_goTypes = nil
+	file_private_proto_depIdxs = nil
+}
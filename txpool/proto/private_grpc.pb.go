@@ -0,0 +1,108 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.3
+// source: private.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PrivateTxnClient is the client API for PrivateTxn service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PrivateTxnClient interface {
+	// Forward submits a private transaction to a validator, without gossiping it
+	Forward(ctx context.Context, in *PrivateTxnReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type privateTxnClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPrivateTxnClient(cc grpc.ClientConnInterface) PrivateTxnClient {
+	return &privateTxnClient{cc}
+}
+
+func (c *privateTxnClient) Forward(ctx context.Context, in *PrivateTxnReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.PrivateTxn/Forward", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrivateTxnServer is the server API for PrivateTxn service.
+// All implementations must embed UnimplementedPrivateTxnServer
+// for forward compatibility
+type PrivateTxnServer interface {
+	// Forward submits a private transaction to a validator, without gossiping it
+	Forward(context.Context, *PrivateTxnReq) (*emptypb.Empty, error)
+	mustEmbedUnimplementedPrivateTxnServer()
+}
+
+// UnimplementedPrivateTxnServer must be embedded to have forward compatible implementations.
+type UnimplementedPrivateTxnServer struct {
+}
+
+func (UnimplementedPrivateTxnServer) Forward(context.Context, *PrivateTxnReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Forward not implemented")
+}
+func (UnimplementedPrivateTxnServer) mustEmbedUnimplementedPrivateTxnServer() {}
+
+// UnsafePrivateTxnServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PrivateTxnServer will
+// result in compilation errors.
+type UnsafePrivateTxnServer interface {
+	mustEmbedUnimplementedPrivateTxnServer()
+}
+
+func RegisterPrivateTxnServer(s grpc.ServiceRegistrar, srv PrivateTxnServer) {
+	s.RegisterService(&PrivateTxn_ServiceDesc, srv)
+}
+
+func _PrivateTxn_Forward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrivateTxnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivateTxnServer).Forward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.PrivateTxn/Forward",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivateTxnServer).Forward(ctx, req.(*PrivateTxnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PrivateTxn_ServiceDesc is the grpc.ServiceDesc for PrivateTxn service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PrivateTxn_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.PrivateTxn",
+	HandlerType: (*PrivateTxnServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Forward",
+			Handler:    _PrivateTxn_Forward_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "private.proto",
+}
@@ -0,0 +1,229 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.3
+// source: propagation.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TxnHashes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hashes [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (x *TxnHashes) Reset() {
+	*x = TxnHashes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_propagation_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxnHashes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnHashes) ProtoMessage() {}
+
+func (x *TxnHashes) ProtoReflect() protoreflect.Message {
+	mi := &file_propagation_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnHashes.ProtoReflect.Descriptor instead.
+func (*TxnHashes) Descriptor() ([]byte, []int) {
+	return file_propagation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TxnHashes) GetHashes() [][]byte {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+type TxnBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Raw []*anypb.Any `protobuf:"bytes,1,rep,name=raw,proto3" json:"raw,omitempty"`
+}
+
+func (x *TxnBatch) Reset() {
+	*x = TxnBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_propagation_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxnBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnBatch) ProtoMessage() {}
+
+func (x *TxnBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_propagation_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnBatch.ProtoReflect.Descriptor instead.
+func (*TxnBatch) Descriptor() ([]byte, []int) {
+	return file_propagation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TxnBatch) GetRaw() []*anypb.Any {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+var File_propagation_proto protoreflect.FileDescriptor
+
+var file_propagation_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x23, 0x0a, 0x09, 0x54, 0x78, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x68, 0x61,
+	0x73, 0x68, 0x65, 0x73, 0x22, 0x32, 0x0a, 0x08, 0x54, 0x78, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x26, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x41, 0x6e, 0x79, 0x52, 0x03, 0x72, 0x61, 0x77, 0x32, 0x99, 0x01, 0x0a, 0x0e, 0x54, 0x78, 0x6e,
+	0x50, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x08, 0x41,
+	0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x12, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x78, 0x6e,
+	0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2c,
+	0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x78, 0x6e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x26, 0x0a, 0x07,
+	0x47, 0x65, 0x74, 0x54, 0x78, 0x6e, 0x73, 0x12, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x78, 0x6e,
+	0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x1a, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x78, 0x6e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_propagation_proto_rawDescOnce sync.Once
+	file_propagation_proto_rawDescData = file_propagation_proto_rawDesc
+)
+
+func file_propagation_proto_rawDescGZIP() []byte {
+	file_propagation_proto_rawDescOnce.Do(func() {
+		file_propagation_proto_rawDescData = protoimpl.X.CompressGZIP(file_propagation_proto_rawDescData)
+	})
+	return file_propagation_proto_rawDescData
+}
+
+var file_propagation_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_propagation_proto_goTypes = []interface{}{
+	(*TxnHashes)(nil),     // 0: v1.TxnHashes
+	(*TxnBatch)(nil),      // 1: v1.TxnBatch
+	(*anypb.Any)(nil),     // 2: google.protobuf.Any
+	(*emptypb.Empty)(nil), // 3: google.protobuf.Empty
+}
+var file_propagation_proto_depIdxs = []int32{
+	2, // 0: v1.TxnBatch.raw:type_name -> google.protobuf.Any
+	0, // 1: v1.TxnPropagation.Announce:input_type -> v1.TxnHashes
+	1, // 2: v1.TxnPropagation.Push:input_type -> v1.TxnBatch
+	0, // 3: v1.TxnPropagation.GetTxns:input_type -> v1.TxnHashes
+	3, // 4: v1.TxnPropagation.Announce:output_type -> google.protobuf.Empty
+	3, // 5: v1.TxnPropagation.Push:output_type -> google.protobuf.Empty
+	1, // 6: v1.TxnPropagation.GetTxns:output_type -> v1.TxnBatch
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_propagation_proto_init() }
+func file_propagation_proto_init() {
+	if File_propagation_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_propagation_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxnHashes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_propagation_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxnBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_propagation_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_propagation_proto_goTypes,
+		DependencyIndexes: file_propagation_proto_depIdxs,
+		MessageInfos:      file_propagation_proto_msgTypes,
+	}.Build()
+	File_propagation_proto = out.File
+	file_propagation_proto_rawDesc = nil
+	file_propagation_proto_goTypes = nil
+	file_propagation_proto_depIdxs = nil
+}
@@ -0,0 +1,184 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.3
+// source: propagation.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// TxnPropagationClient is the client API for TxnPropagation service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TxnPropagationClient interface {
+	// Announce notifies the peer of new transactions, by their hashes
+	Announce(ctx context.Context, in *TxnHashes, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Push sends full transactions to the peer
+	Push(ctx context.Context, in *TxnBatch, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetTxns requests the announced transactions from the peer
+	GetTxns(ctx context.Context, in *TxnHashes, opts ...grpc.CallOption) (*TxnBatch, error)
+}
+
+type txnPropagationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTxnPropagationClient(cc grpc.ClientConnInterface) TxnPropagationClient {
+	return &txnPropagationClient{cc}
+}
+
+func (c *txnPropagationClient) Announce(ctx context.Context, in *TxnHashes, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.TxnPropagation/Announce", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPropagationClient) Push(ctx context.Context, in *TxnBatch, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.TxnPropagation/Push", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPropagationClient) GetTxns(ctx context.Context, in *TxnHashes, opts ...grpc.CallOption) (*TxnBatch, error) {
+	out := new(TxnBatch)
+	err := c.cc.Invoke(ctx, "/v1.TxnPropagation/GetTxns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TxnPropagationServer is the server API for TxnPropagation service.
+// All implementations must embed UnimplementedTxnPropagationServer
+// for forward compatibility
+type TxnPropagationServer interface {
+	// Announce notifies the peer of new transactions, by their hashes
+	Announce(context.Context, *TxnHashes) (*emptypb.Empty, error)
+	// Push sends full transactions to the peer
+	Push(context.Context, *TxnBatch) (*emptypb.Empty, error)
+	// GetTxns requests the announced transactions from the peer
+	GetTxns(context.Context, *TxnHashes) (*TxnBatch, error)
+	mustEmbedUnimplementedTxnPropagationServer()
+}
+
+// UnimplementedTxnPropagationServer must be embedded to have forward compatible implementations.
+type UnimplementedTxnPropagationServer struct {
+}
+
+func (UnimplementedTxnPropagationServer) Announce(context.Context, *TxnHashes) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Announce not implemented")
+}
+func (UnimplementedTxnPropagationServer) Push(context.Context, *TxnBatch) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
+}
+func (UnimplementedTxnPropagationServer) GetTxns(context.Context, *TxnHashes) (*TxnBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxns not implemented")
+}
+func (UnimplementedTxnPropagationServer) mustEmbedUnimplementedTxnPropagationServer() {}
+
+// UnsafeTxnPropagationServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TxnPropagationServer will
+// result in compilation errors.
+type UnsafeTxnPropagationServer interface {
+	mustEmbedUnimplementedTxnPropagationServer()
+}
+
+func RegisterTxnPropagationServer(s grpc.ServiceRegistrar, srv TxnPropagationServer) {
+	s.RegisterService(&TxnPropagation_ServiceDesc, srv)
+}
+
+func _TxnPropagation_Announce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnHashes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPropagationServer).Announce(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPropagation/Announce",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPropagationServer).Announce(ctx, req.(*TxnHashes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPropagation_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPropagationServer).Push(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPropagation/Push",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPropagationServer).Push(ctx, req.(*TxnBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPropagation_GetTxns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnHashes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPropagationServer).GetTxns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPropagation/GetTxns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPropagationServer).GetTxns(ctx, req.(*TxnHashes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TxnPropagation_ServiceDesc is the grpc.ServiceDesc for TxnPropagation service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TxnPropagation_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.TxnPropagation",
+	HandlerType: (*TxnPropagationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Announce",
+			Handler:    _TxnPropagation_Announce_Handler,
+		},
+		{
+			MethodName: "Push",
+			Handler:    _TxnPropagation_Push_Handler,
+		},
+		{
+			MethodName: "GetTxns",
+			Handler:    _TxnPropagation_GetTxns_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "propagation.proto",
+}
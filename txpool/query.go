@@ -1,6 +1,11 @@
 package txpool
 
-import "github.com/SECRYPT-2022/SECRYPT/types"
+import (
+	"math/big"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/types"
+)
 
 /* QUERY methods */
 // Used to query the pool for specific state info.
@@ -38,6 +43,27 @@ func (p *TxPool) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
 	return tx, true
 }
 
+// GetTxTTL returns the remaining lifetime of the transaction in the TxPool,
+// or false if the transaction is unknown or the transactions don't expire [Thread-safe]
+func (p *TxPool) GetTxTTL(txHash types.Hash) (time.Duration, bool) {
+	if p.lifetime == 0 {
+		return 0, false
+	}
+
+	arrival, ok := p.index.arrival(txHash)
+	if !ok {
+		return 0, false
+	}
+
+	ttl := p.lifetime - time.Since(arrival)
+	if ttl < 0 {
+		// expired, waiting for the next sweep
+		ttl = 0
+	}
+
+	return ttl, true
+}
+
 // GetTxs gets pending and queued transactions
 func (p *TxPool) GetTxs(inclQueued bool) (
 	allPromoted, allEnqueued map[types.Address][]*types.Transaction,
@@ -46,3 +72,114 @@ func (p *TxPool) GetTxs(inclQueued bool) (
 
 	return
 }
+
+// maximum number of missing nonces reported for an enqueued transaction
+const maxMissingNonces = 64
+
+// states of the transactions reported by GetTxStatus
+const (
+	TxStatusUnknown  = "unknown"
+	TxStatusEnqueued = "enqueued"
+	TxStatusPromoted = "promoted"
+	TxStatusRejected = "rejected"
+	TxStatusRemoved  = "removed"
+)
+
+// TxStatus is the diagnostic status of a transaction in the pool
+type TxStatus struct {
+	// current state of the transaction in the pool
+	State string
+
+	// sender and nonce, only known while the transaction is in the pool
+	From  types.Address
+	Nonce uint64
+
+	// next nonce of the sender, and the nonces missing before an enqueued transaction
+	NextNonce     uint64
+	MissingNonces []uint64
+
+	// gas price of the transaction, and the minimum one accepted by the pool
+	GasPrice         *big.Int
+	RequiredGasPrice *big.Int
+
+	// recorded lifecycle events, from the oldest to the latest
+	History []*TxEvent
+}
+
+// LastEvent returns the latest recorded event of the transaction, if any
+func (s *TxStatus) LastEvent() *TxEvent {
+	if len(s.History) == 0 {
+		return nil
+	}
+
+	return s.History[len(s.History)-1]
+}
+
+// GetTxStatus returns the state of the transaction in the TxPool,
+// along with its recorded history explaining why it's stuck or gone [Thread-safe]
+func (p *TxPool) GetTxStatus(txHash types.Hash) *TxStatus {
+	status := &TxStatus{
+		State:            TxStatusUnknown,
+		RequiredGasPrice: new(big.Int).SetUint64(p.priceLimit),
+		History:          p.eventManager.getHistory(txHash),
+	}
+
+	if last := status.LastEvent(); last != nil {
+		status.State = TxStatusRemoved
+
+		if last.Type == rejectedEventType {
+			status.State = TxStatusRejected
+		}
+	}
+
+	tx, ok := p.index.get(txHash)
+	if !ok {
+		return status
+	}
+
+	status.From = tx.From
+	status.Nonce = tx.Nonce
+	status.GasPrice = new(big.Int).Set(tx.GasPrice)
+
+	account := p.accounts.get(tx.From)
+	if account == nil {
+		return status
+	}
+
+	promoted, enqueued := account.txs()
+	status.NextNonce = account.getNonce()
+
+	for _, promotedTx := range promoted {
+		if promotedTx.Hash == txHash {
+			status.State = TxStatusPromoted
+
+			return status
+		}
+	}
+
+	status.State = TxStatusEnqueued
+	status.MissingNonces = missingNonces(status.NextNonce, tx.Nonce, enqueued)
+
+	return status
+}
+
+// missingNonces returns the nonces from the next nonce up to the given one
+// which aren't in the enqueued transactions, sorted by nonce
+func missingNonces(nextNonce, nonce uint64, enqueued []*types.Transaction) []uint64 {
+	missing := make([]uint64, 0)
+	idx := 0
+
+	for n := nextNonce; n < nonce && len(missing) < maxMissingNonces; n++ {
+		for idx < len(enqueued) && enqueued[idx].Nonce < n {
+			idx++
+		}
+
+		if idx < len(enqueued) && enqueued[idx].Nonce == n {
+			continue
+		}
+
+		missing = append(missing, n)
+	}
+
+	return missing
+}
@@ -61,6 +61,28 @@ func (q *accountQueue) prune(nonce uint64) (
 	return
 }
 
+// removeIf removes all transactions matching the given condition from the queue.
+func (q *accountQueue) removeIf(cond func(*types.Transaction) bool) (
+	removed []*types.Transaction,
+) {
+	kept := make(minNonceQueue, 0, len(q.queue))
+
+	for _, tx := range q.queue {
+		if cond(tx) {
+			removed = append(removed, tx)
+		} else {
+			kept = append(kept, tx)
+		}
+	}
+
+	if len(removed) > 0 {
+		q.queue = kept
+		heap.Init(&q.queue)
+	}
+
+	return
+}
+
 // clear removes all transactions from the queue.
 func (q *accountQueue) clear() (removed []*types.Transaction) {
 	// store txs
@@ -77,6 +99,71 @@ func (q *accountQueue) push(tx *types.Transaction) {
 	heap.Push(&q.queue, tx)
 }
 
+// get returns the transaction with the given nonce, or nil if there's none.
+func (q *accountQueue) get(nonce uint64) *types.Transaction {
+	for _, tx := range q.queue {
+		if tx.Nonce == nonce {
+			return tx
+		}
+	}
+
+	return nil
+}
+
+// replace swaps the transaction having the same nonce for the given one,
+// and returns the replaced transaction, or nil if there's none.
+func (q *accountQueue) replace(tx *types.Transaction) *types.Transaction {
+	for idx, old := range q.queue {
+		if old.Nonce != tx.Nonce {
+			continue
+		}
+
+		q.queue[idx] = tx
+		heap.Fix(&q.queue, idx)
+
+		return old
+	}
+
+	return nil
+}
+
+// last returns the transaction with the highest nonce without removing it.
+func (q *accountQueue) last() *types.Transaction {
+	if idx := q.lastIndex(); idx >= 0 {
+		return q.queue[idx]
+	}
+
+	return nil
+}
+
+// removeLast removes the transaction with the highest nonce from the queue and returns it.
+func (q *accountQueue) removeLast() *types.Transaction {
+	idx := q.lastIndex()
+	if idx < 0 {
+		return nil
+	}
+
+	transaction, ok := heap.Remove(&q.queue, idx).(*types.Transaction)
+	if !ok {
+		return nil
+	}
+
+	return transaction
+}
+
+// lastIndex returns the position of the highest nonce transaction, or -1 if the queue is empty.
+func (q *accountQueue) lastIndex() int {
+	idx := -1
+
+	for i, tx := range q.queue {
+		if idx < 0 || tx.Nonce > q.queue[idx].Nonce {
+			idx = i
+		}
+	}
+
+	return idx
+}
+
 // peek returns the first transaction from the queue without removing it.
 func (q *accountQueue) peek() *types.Transaction {
 	if q.length() == 0 {
@@ -0,0 +1,100 @@
+package txpool
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+)
+
+const (
+	// number of transactions whose lifecycle history is kept
+	maxTxHistoryTxs = 8192
+
+	// number of events kept per transaction
+	maxTxHistoryEvents = 16
+
+	// event type of the transactions rejected by the pool, only recorded in the history
+	rejectedEventType = "rejected"
+)
+
+// reasons of the events, when not given by the caller
+var defaultEventReasons = map[proto.EventType]string{
+	proto.EventType_DROPPED:         "failed to execute in the block",
+	proto.EventType_DEMOTED:         "recoverable execution error, skipped for the block",
+	proto.EventType_PRUNED_PROMOTED: "nonce lower than the account nonce, included in a block or replaced",
+	proto.EventType_PRUNED_ENQUEUED: "nonce lower than the account nonce, included in a block or replaced",
+	proto.EventType_REPLACED:        "replaced by a higher priced transaction with the same nonce",
+	proto.EventType_EVICTED:         "evicted by a higher priced transaction, the pool being full",
+}
+
+// TxEvent is an entry of the lifecycle history of a transaction
+type TxEvent struct {
+	Type   string
+	Reason string
+	Time   time.Time
+}
+
+// txHistory keeps the latest lifecycle events of the latest transactions
+type txHistory struct {
+	sync.Mutex
+
+	events map[types.Hash][]*TxEvent
+
+	// transactions in the order they were first recorded, the oldest ones are forgotten first
+	order []types.Hash
+}
+
+func newTxHistory() *txHistory {
+	return &txHistory{
+		events: make(map[types.Hash][]*TxEvent),
+		order:  make([]types.Hash, 0),
+	}
+}
+
+// eventTypeName returns the name of the event type used in the history
+func eventTypeName(eventType proto.EventType) string {
+	return strings.ToLower(eventType.String())
+}
+
+// record appends the event to the history of the given transactions
+func (h *txHistory) record(eventType, reason string, txHashes ...types.Hash) {
+	h.Lock()
+	defer h.Unlock()
+
+	now := time.Now()
+
+	for _, hash := range txHashes {
+		events, ok := h.events[hash]
+		if !ok {
+			h.order = append(h.order, hash)
+		}
+
+		if len(events) >= maxTxHistoryEvents {
+			events = events[1:]
+		}
+
+		h.events[hash] = append(events, &TxEvent{
+			Type:   eventType,
+			Reason: reason,
+			Time:   now,
+		})
+	}
+
+	for len(h.order) > maxTxHistoryTxs {
+		delete(h.events, h.order[0])
+		h.order = h.order[1:]
+	}
+}
+
+// get returns the recorded events of the transaction, from the oldest to the latest
+func (h *txHistory) get(hash types.Hash) []*TxEvent {
+	h.Lock()
+	defer h.Unlock()
+
+	events := h.events[hash]
+
+	return append(make([]*TxEvent, 0, len(events)), events...)
+}
@@ -0,0 +1,116 @@
+package txpool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SECRYPT-2022/SECRYPT/txpool/proto"
+	"github.com/SECRYPT-2022/SECRYPT/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxHistory_Bounded(t *testing.T) {
+	t.Parallel()
+
+	history := newTxHistory()
+
+	first := types.StringToHash("0x1")
+
+	for i := 0; i < maxTxHistoryEvents+1; i++ {
+		history.record(eventTypeName(proto.EventType_DEMOTED), "", first)
+	}
+
+	history.record(eventTypeName(proto.EventType_DROPPED), "reason", first)
+
+	events := history.get(first)
+	assert.Len(t, events, maxTxHistoryEvents)
+	assert.Equal(t, "dropped", events[len(events)-1].Type)
+	assert.Equal(t, "reason", events[len(events)-1].Reason)
+
+	// the oldest transactions are forgotten first
+	for i := 0; i < maxTxHistoryTxs; i++ {
+		history.record(rejectedEventType, "", types.BytesToHash([]byte{byte(i), byte(i >> 8), 0xff}))
+	}
+
+	assert.Len(t, history.get(first), 0)
+	assert.Len(t, history.events, maxTxHistoryTxs)
+	assert.Len(t, history.order, maxTxHistoryTxs)
+}
+
+func TestGetTxStatus(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+
+	pool.SetSigner(&mockSigner{})
+
+	pool.Start()
+	t.Cleanup(pool.Close)
+
+	promoted := newTx(addr1, 0, 1)
+	enqueued := newTx(addr1, 4, 1)
+	next := newTx(addr1, 2, 1)
+
+	for _, tx := range []*types.Transaction{promoted, enqueued, next} {
+		assert.NoError(t, pool.addTx(local, tx))
+	}
+
+	assert.Eventually(t, func() bool {
+		return pool.accounts.promoted() == 1 && pool.accounts.get(addr1).enqueued.length() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	t.Run("promoted", func(t *testing.T) {
+		status := pool.GetTxStatus(promoted.Hash)
+		assert.Equal(t, TxStatusPromoted, status.State)
+		assert.Equal(t, addr1, status.From)
+		assert.Equal(t, uint64(1), status.NextNonce)
+		assert.Equal(t, "promoted", status.LastEvent().Type)
+	})
+
+	t.Run("enqueued behind nonce gaps", func(t *testing.T) {
+		status := pool.GetTxStatus(enqueued.Hash)
+		assert.Equal(t, TxStatusEnqueued, status.State)
+		assert.Equal(t, []uint64{1, 3}, status.MissingNonces)
+		assert.Equal(t, "enqueued", status.LastEvent().Type)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		underpriced := newTx(addr2, 0, 1)
+		underpriced.GasPrice.SetUint64(0)
+
+		assert.ErrorIs(t, pool.addTx(gossip, underpriced), ErrUnderpriced)
+
+		status := pool.GetTxStatus(underpriced.Hash)
+		assert.Equal(t, TxStatusRejected, status.State)
+		assert.Equal(t, ErrUnderpriced.Error(), status.LastEvent().Reason)
+		assert.Equal(t, uint64(defaultPriceLimit), status.RequiredGasPrice.Uint64())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		status := pool.GetTxStatus(types.StringToHash("0x1"))
+		assert.Equal(t, TxStatusUnknown, status.State)
+		assert.Nil(t, status.LastEvent())
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		_, err := pool.RemoveTxn(context.Background(), &proto.RemoveTxnReq{Hash: next.Hash.String()})
+		assert.NoError(t, err)
+
+		status := pool.GetTxStatus(next.Hash)
+		assert.Equal(t, TxStatusRemoved, status.State)
+		assert.Equal(t, "pruned_enqueued", status.LastEvent().Type)
+		assert.Equal(t, "removed by the operator", status.LastEvent().Reason)
+	})
+}
+
+func TestMissingNonces(t *testing.T) {
+	t.Parallel()
+
+	enqueued := []*types.Transaction{newTx(addr1, 3, 1), newTx(addr1, 5, 1)}
+
+	assert.Equal(t, []uint64{1, 2, 4}, missingNonces(1, 6, enqueued))
+	assert.Equal(t, []uint64{}, missingNonces(3, 3, enqueued))
+	assert.Len(t, missingNonces(0, 1000, nil), maxMissingNonces)
+}
@@ -1,9 +1,12 @@
 package txpool
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -11,10 +14,10 @@ import (
 	"github.com/SECRYPT-2022/SECRYPT/chain"
 	"github.com/SECRYPT-2022/SECRYPT/network"
 	"github.com/SECRYPT-2022/SECRYPT/state"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/allowlist"
 	"github.com/SECRYPT-2022/SECRYPT/txpool/proto"
 	"github.com/SECRYPT-2022/SECRYPT/types"
 	"github.com/armon/go-metrics"
-	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"google.golang.org/grpc"
@@ -34,6 +37,9 @@ const (
 
 	pruningCooldown = 5000 * time.Millisecond
 
+	// maximum time between the sweeps of the expired transactions
+	expirySweepInterval = time.Minute
+
 	// txPoolMetrics is a prefix used for txpool-related metrics
 	txPoolMetrics = "txpool"
 )
@@ -47,6 +53,7 @@ var (
 	ErrInvalidSender           = errors.New("invalid sender")
 	ErrTxPoolOverflow          = errors.New("txpool is full")
 	ErrUnderpriced             = errors.New("transaction underpriced")
+	ErrReplaceUnderpriced      = errors.New("replacement transaction underpriced")
 	ErrNonceTooLow             = errors.New("nonce too low")
 	ErrInsufficientFunds       = errors.New("insufficient funds for gas * price + value")
 	ErrInvalidAccountState     = errors.New("invalid account state")
@@ -55,15 +62,20 @@ var (
 	ErrMaxEnqueuedLimitReached = errors.New("maximum number of enqueued transactions reached")
 	ErrRejectFutureTx          = errors.New("rejected future tx due to low slots")
 	ErrSmartContractRestricted = errors.New("smart contract deployment restricted")
+	ErrTransactionRestricted   = errors.New("sender is not allowed to send transactions")
+	ErrInvalidFeePayer         = errors.New("invalid fee payer")
+	ErrTxTypeNotSupported      = errors.New("transaction type not supported")
+	ErrInsufficientFeePayer    = errors.New("insufficient fee payer funds for gas * price")
 )
 
 // indicates origin of a transaction
 type txOrigin int
 
 const (
-	local  txOrigin = iota // json-RPC/gRPC endpoints
-	gossip                 // gossip protocol
-	reorg                  // legacy code
+	local   txOrigin = iota // json-RPC/gRPC endpoints
+	gossip                  // gossip protocol
+	reorg                   // legacy code
+	private                 // private submission, never gossiped
 )
 
 func (o txOrigin) String() (s string) {
@@ -74,6 +86,8 @@ func (o txOrigin) String() (s string) {
 		s = "gossip"
 	case reorg:
 		s = "reorg"
+	case private:
+		s = "private"
 	}
 
 	return
@@ -85,17 +99,38 @@ type store interface {
 	GetNonce(root types.Hash, addr types.Address) uint64
 	GetBalance(root types.Hash, addr types.Address) (*big.Int, error)
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
+	GetStorage(root types.Hash, addr types.Address, slot types.Hash) types.Hash
 }
 
 type signer interface {
 	Sender(tx *types.Transaction) (types.Address, error)
+	FeePayer(tx *types.Transaction) (types.Address, error)
 }
 
 type Config struct {
 	PriceLimit          uint64
+	PriceBump           uint64
 	MaxSlots            uint64
 	MaxAccountEnqueued  uint64
 	DeploymentWhitelist []types.Address
+	AllowLists          *chain.AllowLists
+
+	// FeeDelegation is the block from which the fee delegated
+	// transactions are accepted, they're rejected if nil
+	FeeDelegation *chain.Fork
+
+	// JournalPath is the file keeping the pool transactions
+	// across restarts, the journal is disabled if empty
+	JournalPath string
+	// JournalRemotes indicates if the gossiped transactions are journaled
+	JournalRemotes bool
+	// RejournalInterval is the time between the journal compactions
+	RejournalInterval time.Duration
+	// Lifetime is the maximum time a transaction stays in the pool,
+	// transactions don't expire if zero
+	Lifetime time.Duration
+	// PrivateTxPeers are the validator peers the private transactions are forwarded to
+	PrivateTxPeers []peer.ID
 }
 
 /* All requests are passed to the main loop
@@ -152,7 +187,21 @@ type TxPool struct {
 	index lookupMap
 
 	// networking stack
-	topic *network.Topic
+	topic   *network.Topic
+	network txNetwork
+
+	// propagates the transactions to the peers, nil without networking
+	propagator *propagator
+
+	// private transactions, forwarded only to the privatePeers
+	privateTxs   *privateTxs
+	privatePeers []peer.ID
+
+	// bundles waiting for their target block
+	bundles *bundleStore
+
+	// penalty scores of the peers sending invalid or underpriced transactions
+	peerScores *peerScorer
 
 	// gauge for measuring pool capacity
 	gauge slotGauge
@@ -160,6 +209,10 @@ type TxPool struct {
 	// priceLimit is a lower threshold for gas price
 	priceLimit uint64
 
+	// priceBump is the minimum gas price increase (in percent)
+	// needed to replace a transaction with the same nonce
+	priceBump uint64
+
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
 	enqueueReqCh chan enqueueRequest
@@ -179,6 +232,23 @@ type TxPool struct {
 	// deploymentWhitelist map
 	deploymentWhitelist deploymentWhitelist
 
+	// contract-managed allow lists, replacing the
+	// deployment whitelist once they are activated
+	allowLists           *chain.AllowLists
+	deploymentAllowList  *allowlist.AllowList
+	transactionAllowList *allowlist.AllowList
+
+	// block from which the fee delegated transactions are accepted (never if nil)
+	feeDelegation *chain.Fork
+
+	// maximum time a transaction stays in the pool (disabled if zero)
+	lifetime time.Duration
+
+	// journal of the pool transactions, nil if disabled
+	journal           *txJournal
+	journalRemotes    bool
+	rejournalInterval time.Duration
+
 	// indicates which txpool operator commands should be implemented
 	proto.UnimplementedTxnPoolOperatorServer
 
@@ -237,9 +307,14 @@ func NewTxPool(
 		store:       store,
 		executables: newPricedQueue(),
 		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
+		index:       newLookupMap(),
 		gauge:       slotGauge{height: 0, max: config.MaxSlots},
 		priceLimit:  config.PriceLimit,
+		priceBump:   config.PriceBump,
+		lifetime:    config.Lifetime,
+		privateTxs:  newPrivateTxs(),
+		bundles:     newBundleStore(),
+		peerScores:  newPeerScorer(),
 
 		//	main loop channels
 		enqueueReqCh: make(chan enqueueRequest),
@@ -252,22 +327,47 @@ func NewTxPool(
 	pool.eventManager = newEventManager(pool.logger)
 
 	if network != nil {
-		// subscribe to the gossip protocol
+		// subscribe to the gossip protocol, still used by the nodes which don't
+		// support the propagation protocol. The transactions are published to it
+		// for the peers which can't be reached through the propagation protocol
 		topic, err := network.NewTopic(topicNameV1, &proto.Txn{})
 		if err != nil {
 			return nil, err
 		}
 
-		if subscribeErr := topic.Subscribe(pool.addGossipTx); subscribeErr != nil {
+		// the relaying peer is scored, not the publisher of the transaction
+		if subscribeErr := topic.SubscribeForwarded(pool.addGossipTx); subscribeErr != nil {
 			return nil, fmt.Errorf("unable to subscribe to gossip topic, %w", subscribeErr)
 		}
 
 		pool.topic = topic
+
+		pool.setupPrivateTxs(network, config.PrivateTxPeers)
+		pool.setupPropagation(network)
 	}
 
 	// initialize deployment whitelist
 	pool.deploymentWhitelist = newDeploymentWhitelist(config.DeploymentWhitelist)
 
+	pool.feeDelegation = config.FeeDelegation
+
+	// initialize allow lists, bootstrapped by the deployment whitelist
+	pool.allowLists = config.AllowLists
+	pool.deploymentAllowList = allowlist.NewAllowList(
+		allowlist.DeploymentAllowListAddr,
+		config.DeploymentWhitelist,
+	)
+	pool.transactionAllowList = allowlist.NewAllowList(
+		allowlist.TransactionAllowListAddr,
+		config.DeploymentWhitelist,
+	)
+
+	if config.JournalPath != "" {
+		pool.journal = newTxJournal(config.JournalPath)
+		pool.journalRemotes = config.JournalRemotes
+		pool.rejournalInterval = config.RejournalInterval
+	}
+
 	if grpcServer != nil {
 		proto.RegisterTxnPoolOperatorServer(grpcServer, pool)
 	}
@@ -287,6 +387,11 @@ func (p *TxPool) Start() {
 	// set default value of txpool pending transactions gauge
 	p.updatePending(0)
 
+	// run the propagation of the transactions to the peers
+	if p.propagator != nil {
+		go p.propagator.run()
+	}
+
 	//	run the handler for high gauge level pruning
 	go func() {
 		for {
@@ -305,6 +410,31 @@ func (p *TxPool) Start() {
 
 	//	run the handler for the tx pipeline
 	go func() {
+		// the journal is compacted periodically, once it has been loaded
+		var rejournalCh <-chan time.Time
+
+		if p.journal != nil && p.rejournalInterval > 0 {
+			ticker := time.NewTicker(p.rejournalInterval)
+			defer ticker.Stop()
+
+			rejournalCh = ticker.C
+		}
+
+		// the expired transactions are swept periodically
+		var expiryCh <-chan time.Time
+
+		if p.lifetime > 0 {
+			interval := expirySweepInterval
+			if p.lifetime < interval {
+				interval = p.lifetime
+			}
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			expiryCh = ticker.C
+		}
+
 		for {
 			select {
 			case <-p.shutdownCh:
@@ -313,6 +443,10 @@ func (p *TxPool) Start() {
 				go p.handleEnqueueRequest(req)
 			case req := <-p.promoteReqCh:
 				go p.handlePromoteRequest(req)
+			case <-expiryCh:
+				go p.pruneExpiredTxs()
+			case <-rejournalCh:
+				go p.handleRejournal()
 			}
 		}
 	}()
@@ -322,6 +456,93 @@ func (p *TxPool) Start() {
 func (p *TxPool) Close() {
 	p.eventManager.Close()
 	p.shutdownCh <- struct{}{}
+
+	if p.propagator != nil {
+		p.propagator.close()
+	}
+
+	if p.journal != nil {
+		if err := p.journal.close(); err != nil {
+			p.logger.Error("failed to close the journal", "err", err)
+		}
+	}
+}
+
+// LoadJournal adds the journaled transactions back to the pool, and compacts
+// the journal. It should be called once the pool is started and reset with
+// the latest header, so the already mined transactions are dropped.
+func (p *TxPool) LoadJournal() error {
+	if p.journal == nil {
+		return nil
+	}
+
+	txs, loadErr := p.journal.load()
+	if loadErr != nil {
+		// keep the transactions read before the corrupted entry
+		p.logger.Error("failed to read the whole journal", "err", loadErr)
+	}
+
+	dropped := 0
+
+	for _, tx := range txs {
+		if err := p.AddTx(tx); err != nil {
+			dropped++
+		}
+	}
+
+	p.logger.Info("loaded journaled transactions", "loaded", len(txs)-dropped, "dropped", dropped)
+
+	return p.rejournal()
+}
+
+// handleRejournal compacts the journal once it has been loaded
+func (p *TxPool) handleRejournal() {
+	if !p.journal.isActive() {
+		return
+	}
+
+	if err := p.rejournal(); err != nil {
+		p.logger.Error("failed to rotate the journal", "err", err)
+	}
+}
+
+// rejournal rewrites the journal with the transactions currently in the pool,
+// the transactions added during the rewrite being kept as well.
+// Only one rewrite runs at a time, the others are skipped
+func (p *TxPool) rejournal() error {
+	if !p.journal.beginRotation() {
+		return nil
+	}
+
+	txs := make([]*types.Transaction, 0)
+
+	for _, tx := range p.index.list() {
+		if p.journalRemotes && !p.privateTxs.isPrivate(tx.Hash) {
+			txs = append(txs, tx)
+
+			continue
+		}
+
+		if p.privateTxs.isPrivate(tx.Hash) {
+			// private txs must not outlive their max block number
+			continue
+		}
+
+		if account := p.accounts.get(tx.From); account != nil && account.isLocal() {
+			txs = append(txs, tx)
+		}
+	}
+
+	// keep the nonce order of each account when loading back
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].From != txs[j].From {
+			return bytes.Compare(txs[i].From.Bytes(), txs[j].From.Bytes()) < 0
+		}
+
+		return txs[i].Nonce < txs[j].Nonce
+	})
+
+	return p.journal.rotate(txs)
 }
 
 // SetSigner sets the signer the pool will use
@@ -358,21 +579,48 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 		return err
 	}
 
-	// broadcast the transaction only if a topic
-	// subscription is present
-	if p.topic != nil {
-		tx := &proto.Txn{
-			Raw: &any.Any{
-				Value: tx.MarshalRLP(),
-			},
-		}
+	// propagate the transaction only if the networking is enabled
+	p.propagate(tx, "")
+
+	return nil
+}
+
+// SubscribePendingTxs subscribes to the hashes of the transactions promoted in the pool,
+// the private ones left out. The returned channel is closed by the returned cancel function
+func (p *TxPool) SubscribePendingTxs() (<-chan types.Hash, func()) {
+	subscription := p.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
+
+	var (
+		hashCh    = make(chan types.Hash)
+		doneCh    = make(chan struct{})
+		closeOnce sync.Once
+	)
+
+	go func() {
+		defer close(hashCh)
 
-		if err := p.topic.Publish(tx); err != nil {
-			p.logger.Error("failed to topic tx", "err", err)
+		for event := range subscription.subscriptionChannel {
+			hash := types.StringToHash(event.TxHash)
+			if p.privateTxs.isPrivate(hash) {
+				continue
+			}
+
+			select {
+			case hashCh <- hash:
+			case <-doneCh:
+				return
+			}
 		}
+	}()
+
+	cancel := func() {
+		closeOnce.Do(func() {
+			close(doneCh)
+			p.eventManager.cancelSubscription(subscription.subscriptionID)
+		})
 	}
 
-	return nil
+	return hashCh, cancel
 }
 
 // Prepare generates all the transactions
@@ -416,13 +664,22 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 	defer account.promoted.unlock()
 
 	// pop the top most promoted tx
-	account.promoted.pop()
+	popped := account.promoted.pop()
+	if popped == nil {
+		return
+	}
+
+	// the executed tx was replaced in the meantime,
+	// so its replacement is stale and won't be mined
+	if popped.Hash != tx.Hash {
+		p.index.remove(popped)
+	}
 
 	// successfully popping an account resets its demotions count to 0
 	account.resetDemotions()
 
 	// update state
-	p.gauge.decrease(slotsRequired(tx))
+	p.gauge.decrease(slotsRequired(popped))
 
 	// update metrics
 	p.updatePending(-1)
@@ -464,17 +721,32 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 	account.setNonce(nextNonce)
 
 	// drop promoted
-	dropped := account.promoted.clear()
-	clearAccountQueue(dropped)
+	droppedPromoted := account.promoted.clear()
+	clearAccountQueue(droppedPromoted)
 
 	// update metrics
-	p.updatePending(-1 * int64(len(dropped)))
+	p.updatePending(-1 * int64(len(droppedPromoted)))
 
 	// drop enqueued
-	dropped = account.enqueued.clear()
-	clearAccountQueue(dropped)
+	droppedEnqueued := account.enqueued.clear()
+	clearAccountQueue(droppedEnqueued)
 
 	p.eventManager.signalEvent(proto.EventType_DROPPED, tx.Hash)
+
+	// record why the rest of the account's txs are gone
+	others := make([]types.Hash, 0, droppedCount)
+
+	for _, dropped := range append(droppedPromoted, droppedEnqueued...) {
+		if dropped.Hash != tx.Hash {
+			others = append(others, dropped.Hash)
+		}
+	}
+
+	p.eventManager.signalEventWithReason(
+		proto.EventType_DROPPED,
+		fmt.Sprintf("dropped along with the failed transaction %s of the account", tx.Hash),
+		others...,
+	)
 	p.logger.Debug("dropped account txs",
 		"num", droppedCount,
 		"next_nonce", nextNonce,
@@ -595,6 +867,10 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 	// reset accounts with the new state
 	p.resetAccounts(stateNonces)
 
+	// drop the private txs and the bundles which can't be included anymore
+	p.pruneExpiredPrivateTxs(p.store.Header().Number)
+	p.bundles.prune(p.store.Header().Number)
+
 	if !p.getSealing() {
 		// only non-validator cleanup inactive accounts
 		p.updateAccountSkipsCounts(stateNonces)
@@ -634,9 +910,20 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		tx.From = from
 	}
 
-	// Check if transaction can deploy smart contract
-	if tx.IsContractCreation() && !p.deploymentWhitelist.allowed(tx.From) {
-		return ErrSmartContractRestricted
+	// Grab the latest block header
+	header := p.store.Header()
+	stateRoot := header.StateRoot
+
+	// Check if the fee payer signed the transaction,
+	// once the fee delegated transactions are enabled
+	if tx.IsFeeDelegated() {
+		if p.feeDelegation == nil || !p.feeDelegation.Active(header.Number+1) {
+			return ErrTxTypeNotSupported
+		}
+
+		if _, err := p.signer.FeePayer(tx); err != nil {
+			return ErrInvalidFeePayer
+		}
 	}
 
 	// Reject underpriced transactions
@@ -644,8 +931,10 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrUnderpriced
 	}
 
-	// Grab the state root for the latest block
-	stateRoot := p.store.Header().StateRoot
+	// Check if the sender is allowed to send the transaction
+	if err := p.validateAllowLists(tx, header); err != nil {
+		return err
+	}
 
 	// Check nonce ordering
 	if p.store.GetNonce(stateRoot, tx.From) > tx.Nonce {
@@ -658,8 +947,24 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	}
 
 	// Check if the sender has enough funds to execute the transaction
-	if accountBalance.Cmp(tx.Cost()) < 0 {
-		return ErrInsufficientFunds
+	if !tx.IsFeeDelegated() {
+		if accountBalance.Cmp(tx.Cost()) < 0 {
+			return ErrInsufficientFunds
+		}
+	} else {
+		// The sender only transfers the value, while the gas is paid by the fee payer
+		if accountBalance.Cmp(tx.Value) < 0 {
+			return ErrInsufficientFunds
+		}
+
+		feePayerBalance, balanceErr := p.store.GetBalance(stateRoot, *tx.FeePayer)
+		if balanceErr != nil {
+			return ErrInvalidAccountState
+		}
+
+		if feePayerBalance.Cmp(tx.GasCost()) < 0 {
+			return ErrInsufficientFeePayer
+		}
 	}
 
 	// Make sure the transaction has more gas than the basic transaction fee
@@ -682,6 +987,43 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	return nil
 }
 
+// validateAllowLists checks the transaction against the allow lists
+// which are going to be enforced in the block following the given header
+func (p *TxPool) validateAllowLists(tx *types.Transaction, header *types.Header) error {
+	nextBlock := header.Number + 1
+	reader := &storeStateReader{store: p.store, root: header.StateRoot}
+
+	if p.allowLists.IsTransactionActive(nextBlock) &&
+		!p.transactionAllowList.GetRole(reader, tx.From).Enabled() {
+		return ErrTransactionRestricted
+	}
+
+	if !tx.IsContractCreation() {
+		return nil
+	}
+
+	// Check if transaction can deploy smart contract
+	if p.allowLists.IsDeploymentActive(nextBlock) {
+		if !p.deploymentAllowList.GetRole(reader, tx.From).Enabled() {
+			return ErrSmartContractRestricted
+		}
+	} else if !p.deploymentWhitelist.allowed(tx.From) {
+		return ErrSmartContractRestricted
+	}
+
+	return nil
+}
+
+// storeStateReader reads the storage of accounts at the given state root
+type storeStateReader struct {
+	store store
+	root  types.Hash
+}
+
+func (r *storeStateReader) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return r.store.GetStorage(r.root, addr, key)
+}
+
 func (p *TxPool) signalPruning() {
 	select {
 	case p.pruneCh <- struct{}{}:
@@ -712,6 +1054,12 @@ func (p *TxPool) pruneAccountsWithNonceHoles() {
 			p.index.remove(removed...)
 			p.gauge.decrease(slotsRequired(removed...))
 
+			p.eventManager.signalEventWithReason(
+				proto.EventType_PRUNED_ENQUEUED,
+				"blocked by a nonce gap while the pool is under pressure",
+				toHash(removed...)...,
+			)
+
 			return true
 		},
 	)
@@ -721,12 +1069,19 @@ func (p *TxPool) pruneAccountsWithNonceHoles() {
 // for all new transactions. If the call is
 // successful, an account is created for this address
 // (only once) and an enqueueRequest is signaled.
-func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
+func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) (err error) {
 	p.logger.Debug("add tx",
 		"origin", origin.String(),
 		"hash", tx.Hash.String(),
 	)
 
+	defer func() {
+		if err != nil && !errors.Is(err, ErrAlreadyKnown) {
+			tx.ComputeHash()
+			p.eventManager.recordRejection(tx.Hash, err)
+		}
+	}()
+
 	// validate incoming tx
 	if err := p.validateTx(tx); err != nil {
 		return err
@@ -742,12 +1097,25 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 		}
 	}
 
-	// check for overflow
-	if p.gauge.read()+slotsRequired(tx) > p.gauge.max {
-		return ErrTxPoolOverflow
+	tx.ComputeHash()
+
+	// reject underpriced replacements of known nonces
+	if account := p.accounts.get(tx.From); account != nil {
+		if err := account.validateReplacement(tx, p.priceBump); err != nil {
+			return err
+		}
 	}
 
-	tx.ComputeHash()
+	// check for overflow, making room by evicting cheaper remote txs
+	if p.gauge.read()+slotsRequired(tx) > p.gauge.max {
+		if _, known := p.index.get(tx.Hash); known {
+			return ErrAlreadyKnown
+		}
+
+		if !p.evictUnderpriced(tx) {
+			return ErrTxPoolOverflow
+		}
+	}
 
 	// add to index
 	if ok := p.index.add(tx); !ok {
@@ -757,9 +1125,22 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	// initialize account for this address once
 	p.createAccountOnce(tx.From)
 
+	if origin == local || origin == private {
+		// protect the account's txs from eviction
+		p.accounts.get(tx.From).markLocal()
+	}
+
+	// signaled first, as the tx may be enqueued and promoted right away
+	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
+
 	// send request [BLOCKING]
 	p.enqueueReqCh <- enqueueRequest{tx: tx}
-	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
+
+	if p.journal != nil && (origin == local || (origin == gossip && p.journalRemotes)) {
+		if err := p.journal.insert(tx); err != nil {
+			p.logger.Error("failed to journal tx", "err", err)
+		}
+	}
 
 	return nil
 }
@@ -776,7 +1157,8 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 	account := p.accounts.get(addr)
 
 	// enqueue tx
-	if err := account.enqueue(tx); err != nil {
+	replaced, wasPromoted, err := account.enqueue(tx, p.priceBump)
+	if err != nil {
 		p.logger.Error("enqueue request", "err", err)
 
 		p.index.remove(tx)
@@ -784,10 +1166,26 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 		return
 	}
 
-	p.logger.Debug("enqueue request", "hash", tx.Hash.String())
-
 	p.gauge.increase(slotsRequired(tx))
 
+	if replaced != nil {
+		p.logger.Debug("replaced tx", "old", replaced.Hash.String(), "new", tx.Hash.String())
+
+		p.index.remove(replaced)
+		p.gauge.decrease(slotsRequired(replaced))
+
+		p.eventManager.signalEvent(proto.EventType_REPLACED, replaced.Hash)
+	}
+
+	if wasPromoted {
+		// the tx took the place of a promoted one
+		p.eventManager.signalEvent(proto.EventType_PROMOTED, tx.Hash)
+
+		return
+	}
+
+	p.logger.Debug("enqueue request", "hash", tx.Hash.String())
+
 	p.eventManager.signalEvent(proto.EventType_ENQUEUED, tx.Hash)
 
 	if tx.Nonce > account.getNonce() {
@@ -821,11 +1219,15 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 
 // addGossipTx handles receiving transactions
 // gossiped by the network.
-func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
+func (p *TxPool) addGossipTx(obj interface{}, from peer.ID) {
 	if !p.getSealing() {
 		return
 	}
 
+	if !p.allowPeer(from) {
+		return
+	}
+
 	raw, ok := obj.(*proto.Txn)
 	if !ok {
 		p.logger.Error("failed to cast gossiped message to txn")
@@ -845,6 +1247,7 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 	// decode tx
 	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
 		p.logger.Error("failed to decode broadcast tx", "err", err)
+		p.penalizePeer(from, invalidTxPenalty, "gossip_invalid_transactions", err)
 
 		return
 	}
@@ -857,6 +1260,8 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 			return
 		}
 
+		p.scoreGossipError(from, err)
+
 		p.logger.Error("failed to add broadcast tx", "err", err, "hash", tx.Hash.String())
 	}
 }
@@ -919,6 +1324,124 @@ func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 	}
 }
 
+// pruneExpiredTxs removes the transactions which stayed in the pool longer than their lifetime.
+func (p *TxPool) pruneExpiredTxs() {
+	deadline := time.Now().Add(-p.lifetime)
+
+	p.pruneTxs(func(tx *types.Transaction) bool {
+		arrival, ok := p.index.arrival(tx.Hash)
+
+		return ok && arrival.Before(deadline)
+	}, "lifetime in the pool expired")
+}
+
+// pruneTxs removes the expired transactions from the pool,
+// demoting the promoted transactions following them.
+func (p *TxPool) pruneTxs(isExpired func(tx *types.Transaction) bool, reason string) {
+	pruned, demoted := p.removeTxs(isExpired, reason)
+	if len(pruned) == 0 {
+		return
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "expired_transactions"}, float32(len(pruned)))
+
+	p.logger.Debug("pruned expired txs", "num", len(pruned), "demoted", demoted)
+}
+
+// removeTxs removes the matching transactions from the pool for the given reason,
+// demoting the promoted transactions following them.
+// Returns the removed transactions and the number of demoted ones.
+func (p *TxPool) removeTxs(isExpired func(tx *types.Transaction) bool, reason string) ([]*types.Transaction, int) {
+	var (
+		allPrunedPromoted []*types.Transaction
+		allPrunedEnqueued []*types.Transaction
+		allDemoted        []*types.Transaction
+		allDropped        []*types.Transaction
+		demotedCount      int
+	)
+
+	p.accounts.Range(
+		func(_, value interface{}) bool {
+			account, _ := value.(*account)
+
+			prunedPromoted, prunedEnqueued, demoted, dropped := account.pruneExpired(isExpired)
+
+			allPrunedPromoted = append(allPrunedPromoted, prunedPromoted...)
+			allPrunedEnqueued = append(allPrunedEnqueued, prunedEnqueued...)
+			allDropped = append(allDropped, dropped...)
+			demotedCount += len(demoted)
+
+			// the demoted transactions dropped over the enqueued limit aren't enqueued
+			droppedHashes := make(map[types.Hash]struct{}, len(dropped))
+			for _, tx := range dropped {
+				droppedHashes[tx.Hash] = struct{}{}
+			}
+
+			for _, tx := range demoted {
+				if _, ok := droppedHashes[tx.Hash]; !ok {
+					allDemoted = append(allDemoted, tx)
+				}
+			}
+
+			return true
+		},
+	)
+
+	// pool cleanup callback
+	cleanup := func(stale []*types.Transaction) {
+		p.index.remove(stale...)
+		p.gauge.decrease(slotsRequired(stale...))
+	}
+
+	if len(allPrunedPromoted) > 0 {
+		cleanup(allPrunedPromoted)
+
+		p.eventManager.signalEventWithReason(
+			proto.EventType_PRUNED_PROMOTED,
+			reason,
+			toHash(allPrunedPromoted...)...,
+		)
+	}
+
+	if len(allPrunedEnqueued) > 0 {
+		cleanup(allPrunedEnqueued)
+
+		p.eventManager.signalEventWithReason(
+			proto.EventType_PRUNED_ENQUEUED,
+			reason,
+			toHash(allPrunedEnqueued...)...,
+		)
+	}
+
+	if len(allDemoted) > 0 {
+		// moved back to the enqueued queues
+		p.eventManager.signalEventWithReason(
+			proto.EventType_ENQUEUED,
+			"lower nonce transaction removed from the pool",
+			toHash(allDemoted...)...,
+		)
+	}
+
+	if len(allDropped) > 0 {
+		cleanup(allDropped)
+
+		p.eventManager.signalEventWithReason(
+			proto.EventType_PRUNED_ENQUEUED,
+			ErrMaxEnqueuedLimitReached.Error(),
+			toHash(allDropped...)...,
+		)
+
+		p.logger.Debug("dropped demoted txs over the enqueued limit", "num", len(allDropped))
+	}
+
+	// the demoted transactions left promoted, whether they are enqueued or dropped
+	if removed := len(allPrunedPromoted) + demotedCount; removed > 0 {
+		p.updatePending(-int64(removed))
+	}
+
+	return append(allPrunedPromoted, allPrunedEnqueued...), len(allDemoted)
+}
+
 // updateAccountSkipsCounts update the accounts' skips,
 // the number of the consecutive blocks that doesn't have the account's transactions
 func (p *TxPool) updateAccountSkipsCounts(latestActiveAccounts map[types.Address]uint64) {
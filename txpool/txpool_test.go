@@ -6,12 +6,14 @@ import (
 	"crypto/rand"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/SECRYPT-2022/SECRYPT/chain"
 	"github.com/SECRYPT-2022/SECRYPT/crypto"
 	"github.com/SECRYPT-2022/SECRYPT/helper/tests"
+	"github.com/SECRYPT-2022/SECRYPT/state/runtime/allowlist"
 	"github.com/SECRYPT-2022/SECRYPT/txpool/proto"
 	"github.com/SECRYPT-2022/SECRYPT/types"
 	"github.com/golang/protobuf/ptypes/any"
@@ -860,7 +862,7 @@ func TestPromoteHandler(t *testing.T) {
 	})
 
 	t.Run(
-		"promote handler discards cheaper tx",
+		"enqueue handler replaces cheaper tx",
 		func(t *testing.T) {
 			t.Parallel()
 
@@ -926,16 +928,19 @@ func TestPromoteHandler(t *testing.T) {
 			promReq1 := handleEnqueueRequest(enqTx1)
 			promReq2 := handleEnqueueRequest(enqTx2)
 
+			// the second Tx replaced the first one
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
-			assert.Equal(t, uint64(2), pool.accounts.get(addr1).enqueued.length())
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+			assertTxExists(t, tx1, false)
+			assertTxExists(t, tx2, true)
 			assert.Equal(
 				t,
-				slotsRequired(tx1)+slotsRequired(tx2),
+				slotsRequired(tx2),
 				pool.gauge.read(),
 			)
 
-			// promote the second Tx and remove the first Tx
+			// promote the second Tx
 			pool.handlePromoteRequest(promReq1)
 
 			assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
@@ -966,6 +971,514 @@ func TestPromoteHandler(t *testing.T) {
 	)
 }
 
+func TestReplaceByFee(t *testing.T) {
+	t.Parallel()
+
+	newPricedTx := func(nonce, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr1, nonce, 1)
+		tx.GasPrice.SetUint64(gasPrice)
+		tx.ComputeHash()
+
+		return tx
+	}
+
+	setupPool := func(t *testing.T) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+
+		pool.SetSigner(&mockSigner{})
+		pool.priceBump = 10
+
+		pool.Start()
+		t.Cleanup(pool.Close)
+
+		return pool
+	}
+
+	addAndWait := func(t *testing.T, pool *TxPool, tx *types.Transaction, eventType proto.EventType) {
+		t.Helper()
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{eventType})
+		defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+		assert.NoError(t, pool.addTx(local, tx))
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancelFn()
+
+		assert.Len(t, waitForEvents(ctx, subscription, 1), 1)
+	}
+
+	assertReplaced := func(t *testing.T, pool *TxPool, oldTx, newTx *types.Transaction) {
+		t.Helper()
+
+		_, exists := pool.index.get(oldTx.Hash)
+		assert.False(t, exists)
+
+		_, exists = pool.index.get(newTx.Hash)
+		assert.True(t, exists)
+
+		assert.Equal(t, slotsRequired(newTx), pool.gauge.read())
+	}
+
+	t.Run("replaces promoted tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		oldTx := newPricedTx(0, 100)
+		newTx := newPricedTx(0, 110)
+
+		addAndWait(t, pool, oldTx, proto.EventType_PROMOTED)
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_REPLACED})
+		addAndWait(t, pool, newTx, proto.EventType_PROMOTED)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancelFn()
+
+		events := waitForEvents(ctx, subscription, 1)
+		assert.Len(t, events, 1)
+		assert.Equal(t, oldTx.Hash.String(), events[0].TxHash)
+
+		account := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(1), account.getNonce())
+		assert.Equal(t, uint64(1), account.promoted.length())
+		assert.Equal(t, newTx.Hash, account.promoted.peek().Hash)
+		assertReplaced(t, pool, oldTx, newTx)
+	})
+
+	t.Run("replaces enqueued tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		oldTx := newPricedTx(1, 100)
+		newTx := newPricedTx(1, 200)
+
+		addAndWait(t, pool, oldTx, proto.EventType_ENQUEUED)
+		addAndWait(t, pool, newTx, proto.EventType_REPLACED)
+
+		account := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(0), account.getNonce())
+		assert.Equal(t, uint64(1), account.enqueued.length())
+		assert.Equal(t, newTx.Hash, account.enqueued.peek().Hash)
+		assertReplaced(t, pool, oldTx, newTx)
+	})
+
+	t.Run("rejects underpriced replacement", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		oldTx := newPricedTx(0, 100)
+
+		addAndWait(t, pool, oldTx, proto.EventType_PROMOTED)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, newPricedTx(0, 109)),
+			ErrReplaceUnderpriced,
+		)
+
+		assert.Equal(t, oldTx.Hash, pool.accounts.get(addr1).promoted.peek().Hash)
+		assert.Equal(t, slotsRequired(oldTx), pool.gauge.read())
+	})
+
+	t.Run("pops replacement of executed tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		oldTx := newPricedTx(0, 100)
+		newTx := newPricedTx(0, 200)
+
+		addAndWait(t, pool, oldTx, proto.EventType_PROMOTED)
+
+		// the tx is replaced while being executed
+		pool.Prepare()
+		executed := pool.Peek()
+
+		addAndWait(t, pool, newTx, proto.EventType_REPLACED)
+
+		pool.Pop(executed)
+
+		_, exists := pool.index.get(newTx.Hash)
+		assert.False(t, exists)
+		assert.Equal(t, uint64(0), pool.gauge.read())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+	})
+}
+
+func Test_isReplacementPriced(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		oldPrice  uint64
+		newPrice  uint64
+		priceBump uint64
+		expected  bool
+	}{
+		{"same price", 100, 100, 0, false},
+		{"lower price", 100, 99, 0, false},
+		{"higher price without bump", 100, 101, 0, true},
+		{"below bump", 100, 109, 10, false},
+		{"exact bump", 100, 110, 10, true},
+		{"above bump", 100, 150, 10, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			oldTx := newTx(addr1, 0, 1)
+			oldTx.GasPrice.SetUint64(tt.oldPrice)
+
+			replacement := newTx(addr1, 0, 1)
+			replacement.GasPrice.SetUint64(tt.newPrice)
+
+			assert.Equal(t, tt.expected, isReplacementPriced(oldTx, replacement, tt.priceBump))
+		})
+	}
+}
+
+func TestEvictUnderpriced(t *testing.T) {
+	t.Parallel()
+
+	newPricedTx := func(addr types.Address, nonce, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr, nonce, 1)
+		tx.GasPrice.SetUint64(gasPrice)
+		tx.ComputeHash()
+
+		return tx
+	}
+
+	// fills the pool of 4 slots, addr1 having the cheapest txs
+	setupFullPool := func(t *testing.T, addr1Origin txOrigin) (*TxPool, []*types.Transaction) {
+		t.Helper()
+
+		pool, err := newTestPoolWithSlots(4)
+		assert.NoError(t, err)
+
+		pool.SetSigner(&mockSigner{})
+
+		pool.Start()
+		t.Cleanup(pool.Close)
+
+		txs := []*types.Transaction{
+			newPricedTx(addr1, 0, 2),
+			newPricedTx(addr1, 1, 2),
+			newPricedTx(addr2, 0, 5),
+			newPricedTx(addr3, 0, 3),
+		}
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_PROMOTED})
+		defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+		for _, tx := range txs {
+			origin := gossip
+			if tx.From == addr1 {
+				origin = addr1Origin
+			}
+
+			assert.NoError(t, pool.addTx(origin, tx))
+		}
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancelFn()
+
+		assert.Len(t, waitForEvents(ctx, subscription, len(txs)), len(txs))
+		assert.Equal(t, uint64(4), pool.gauge.read())
+
+		return pool, txs
+	}
+
+	addAndWaitEviction := func(t *testing.T, pool *TxPool, tx *types.Transaction) []*proto.TxPoolEvent {
+		t.Helper()
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{
+			proto.EventType_EVICTED,
+			proto.EventType_PROMOTED,
+		})
+		defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+		assert.NoError(t, pool.addTx(gossip, tx))
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancelFn()
+
+		return waitForEvents(ctx, subscription, 2)
+	}
+
+	t.Run("evicts lowest priced remote tx with highest nonce", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupFullPool(t, gossip)
+
+		tx := newPricedTx(addr4, 0, 10)
+		events := addAndWaitEviction(t, pool, tx)
+
+		assert.Len(t, events, 2)
+		assert.Equal(t, proto.EventType_EVICTED, events[0].Type)
+		assert.Equal(t, txs[1].Hash.String(), events[0].TxHash)
+
+		_, exists := pool.index.get(txs[1].Hash)
+		assert.False(t, exists)
+
+		// the nonce of the account is rolled back
+		account := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(1), account.getNonce())
+		assert.Equal(t, uint64(1), account.promoted.length())
+
+		assert.Equal(t, uint64(4), pool.gauge.read())
+		assert.Equal(t, int64(4), atomic.LoadInt64(&pool.pending))
+	})
+
+	t.Run("protects local txs", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupFullPool(t, local)
+
+		tx := newPricedTx(addr4, 0, 10)
+		events := addAndWaitEviction(t, pool, tx)
+
+		assert.Len(t, events, 2)
+		assert.Equal(t, proto.EventType_EVICTED, events[0].Type)
+		assert.Equal(t, txs[3].Hash.String(), events[0].TxHash)
+
+		assert.Equal(t, uint64(2), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr3).promoted.length())
+		assert.Equal(t, uint64(4), pool.gauge.read())
+	})
+
+	t.Run("rejects tx not paying more than remote txs", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupFullPool(t, gossip)
+
+		assert.ErrorIs(t,
+			pool.addTx(gossip, newPricedTx(addr4, 0, 2)),
+			ErrTxPoolOverflow,
+		)
+
+		for _, tx := range txs {
+			_, exists := pool.index.get(tx.Hash)
+			assert.True(t, exists)
+		}
+
+		assert.Equal(t, uint64(4), pool.gauge.read())
+	})
+}
+
+func TestPruneExpiredTxs(t *testing.T) {
+	t.Parallel()
+
+	setupPool := func(t *testing.T, txs ...*types.Transaction) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+
+		pool.SetSigner(&mockSigner{})
+		pool.lifetime = time.Hour
+
+		pool.Start()
+		t.Cleanup(pool.Close)
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_ENQUEUED})
+		defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+		for _, tx := range txs {
+			assert.NoError(t, pool.addTx(local, tx))
+		}
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancelFn()
+
+		assert.Len(t, waitForEvents(ctx, subscription, len(txs)), len(txs))
+
+		return pool
+	}
+
+	expire := func(pool *TxPool, txs ...*types.Transaction) {
+		pool.index.Lock()
+		defer pool.index.Unlock()
+
+		for _, tx := range txs {
+			pool.index.arrivals[tx.Hash] = time.Now().Add(-2 * time.Hour)
+		}
+	}
+
+	t.Run("prunes expired enqueued tx", func(t *testing.T) {
+		t.Parallel()
+
+		fresh := newTx(addr1, 5, 1)
+		expired := newTx(addr1, 6, 1)
+
+		pool := setupPool(t, fresh, expired)
+		expire(pool, expired)
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_PRUNED_ENQUEUED})
+		defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+		pool.pruneExpiredTxs()
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancelFn()
+
+		events := waitForEvents(ctx, subscription, 1)
+		assert.Len(t, events, 1)
+		assert.Equal(t, expired.Hash.String(), events[0].TxHash)
+
+		_, exists := pool.index.get(expired.Hash)
+		assert.False(t, exists)
+
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+		assert.Equal(t, slotsRequired(fresh), pool.gauge.read())
+	})
+
+	t.Run("prunes expired promoted tx and demotes the following ones", func(t *testing.T) {
+		t.Parallel()
+
+		txs := []*types.Transaction{
+			newTx(addr1, 0, 1),
+			newTx(addr1, 1, 1),
+			newTx(addr1, 2, 1),
+		}
+
+		pool := setupPool(t, txs...)
+
+		// wait for the promotions
+		assert.Eventually(t, func() bool {
+			return pool.accounts.get(addr1).getNonce() == 3
+		}, 10*time.Second, 10*time.Millisecond)
+
+		expire(pool, txs[1])
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{
+			proto.EventType_PRUNED_PROMOTED,
+			proto.EventType_ENQUEUED,
+		})
+		defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+		pool.pruneExpiredTxs()
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancelFn()
+
+		events := waitForEvents(ctx, subscription, 2)
+		assert.Len(t, events, 2)
+		assert.Equal(t, proto.EventType_PRUNED_PROMOTED, events[0].Type)
+		assert.Equal(t, txs[1].Hash.String(), events[0].TxHash)
+		assert.Equal(t, proto.EventType_ENQUEUED, events[1].Type)
+		assert.Equal(t, txs[2].Hash.String(), events[1].TxHash)
+
+		account := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(1), account.getNonce())
+		assert.Equal(t, uint64(1), account.promoted.length())
+		assert.Equal(t, txs[0].Hash, account.promoted.peek().Hash)
+		assert.Equal(t, uint64(1), account.enqueued.length())
+		assert.Equal(t, txs[2].Hash, account.enqueued.peek().Hash)
+
+		assert.Equal(t, slotsRequired(txs[0], txs[2]), pool.gauge.read())
+		assert.Equal(t, int64(1), atomic.LoadInt64(&pool.pending))
+	})
+
+	t.Run("drops the demoted txs over the enqueued limit", func(t *testing.T) {
+		t.Parallel()
+
+		txs := []*types.Transaction{
+			newTx(addr1, 0, 1),
+			newTx(addr1, 1, 1),
+			newTx(addr1, 2, 1),
+			newTx(addr1, 3, 1),
+			newTx(addr1, 4, 1),
+		}
+
+		pool := setupPool(t, txs...)
+
+		// wait for the promotions
+		assert.Eventually(t, func() bool {
+			return pool.accounts.get(addr1).getNonce() == 5
+		}, 10*time.Second, 10*time.Millisecond)
+
+		pool.accounts.get(addr1).maxEnqueued = 2
+
+		expire(pool, txs[1])
+
+		subscription := pool.eventManager.subscribe([]proto.EventType{
+			proto.EventType_PRUNED_PROMOTED,
+			proto.EventType_PRUNED_ENQUEUED,
+			proto.EventType_ENQUEUED,
+		})
+		defer pool.eventManager.cancelSubscription(subscription.subscriptionID)
+
+		pool.pruneExpiredTxs()
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancelFn()
+
+		events := waitForEvents(ctx, subscription, 4)
+		assert.Len(t, events, 4)
+
+		enqueued := make([]string, 0, 2)
+
+		for _, event := range events {
+			switch event.Type {
+			case proto.EventType_PRUNED_PROMOTED:
+				assert.Equal(t, txs[1].Hash.String(), event.TxHash)
+			case proto.EventType_PRUNED_ENQUEUED:
+				assert.Equal(t, txs[4].Hash.String(), event.TxHash)
+			case proto.EventType_ENQUEUED:
+				enqueued = append(enqueued, event.TxHash)
+			}
+		}
+
+		assert.ElementsMatch(t, []string{txs[2].Hash.String(), txs[3].Hash.String()}, enqueued)
+
+		_, exists := pool.index.get(txs[4].Hash)
+		assert.False(t, exists)
+
+		account := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(1), account.getNonce())
+		assert.Equal(t, uint64(1), account.promoted.length())
+		assert.Equal(t, uint64(2), account.enqueued.length())
+
+		assert.Equal(t, slotsRequired(txs[0], txs[2], txs[3]), pool.gauge.read())
+		assert.Equal(t, int64(1), atomic.LoadInt64(&pool.pending))
+	})
+
+	t.Run("returns the remaining lifetime", func(t *testing.T) {
+		t.Parallel()
+
+		tx := newTx(addr1, 0, 1)
+		pool := setupPool(t, tx)
+
+		ttl, ok := pool.GetTxTTL(tx.Hash)
+		assert.True(t, ok)
+		assert.True(t, ttl > 59*time.Minute && ttl <= time.Hour)
+
+		_, ok = pool.GetTxTTL(types.StringToHash("0x1"))
+		assert.False(t, ok)
+
+		expire(pool, tx)
+
+		ttl, ok = pool.GetTxTTL(tx.Hash)
+		assert.True(t, ok)
+		assert.Equal(t, time.Duration(0), ttl)
+
+		// transactions don't expire without lifetime
+		pool.lifetime = 0
+
+		_, ok = pool.GetTxTTL(tx.Hash)
+		assert.False(t, ok)
+	})
+}
+
 func TestResetAccount(t *testing.T) {
 	t.Parallel()
 
@@ -1713,6 +2226,210 @@ func TestPermissionSmartContractDeployment(t *testing.T) {
 	})
 }
 
+func TestAllowLists(t *testing.T) {
+	t.Parallel()
+
+	poolSigner := crypto.NewEIP155Signer(100)
+
+	// Generate a private key and address
+	defaultKey, defaultAddr := tests.GenerateKeyAndAddr(t)
+
+	signTx := func(transaction *types.Transaction) *types.Transaction {
+		signedTx, signErr := poolSigner.SignTx(transaction, defaultKey)
+		if signErr != nil {
+			t.Fatalf("Unable to sign transaction, %v", signErr)
+		}
+
+		return signedTx
+	}
+
+	// the account storage of the allow lists, with the default address explicitly enabled
+	enabledStore := storageMockStore{
+		defaultMockStore: NewDefaultMockStore(mockHeader),
+		storage: map[types.Address]map[types.Hash]types.Hash{
+			allowlist.DeploymentAllowListAddr: {
+				types.BytesToHash(defaultAddr.Bytes()): types.BytesToHash([]byte{byte(allowlist.EnabledRole) + 1}),
+			},
+			allowlist.TransactionAllowListAddr: {
+				types.BytesToHash(defaultAddr.Bytes()): types.BytesToHash([]byte{byte(allowlist.EnabledRole) + 1}),
+			},
+		},
+	}
+
+	setupPool := func(allowLists *chain.AllowLists, mockStore store, bootstrap ...types.Address) *TxPool {
+		pool, err := newTestPool(mockStore)
+		if err != nil {
+			t.Fatalf("cannot create txpool - err: %v\n", err)
+		}
+
+		pool.SetSigner(poolSigner)
+		pool.allowLists = allowLists
+		pool.deploymentAllowList = allowlist.NewAllowList(allowlist.DeploymentAllowListAddr, bootstrap)
+		pool.transactionAllowList = allowlist.NewAllowList(allowlist.TransactionAllowListAddr, bootstrap)
+
+		return pool
+	}
+
+	t.Run("bootstrap admins can deploy", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(
+			&chain.AllowLists{Deployment: chain.NewFork(0)},
+			NewDefaultMockStore(mockHeader),
+			defaultAddr,
+		)
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.To = nil
+
+		assert.NoError(t, pool.validateTx(signTx(tx)))
+	})
+
+	t.Run("accounts without a role can not deploy", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(
+			&chain.AllowLists{Deployment: chain.NewFork(0)},
+			NewDefaultMockStore(mockHeader),
+			addr1,
+		)
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.To = nil
+
+		assert.ErrorIs(t, pool.validateTx(signTx(tx)), ErrSmartContractRestricted)
+	})
+
+	t.Run("enabled accounts can deploy and transact", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(
+			&chain.AllowLists{Deployment: chain.NewFork(0), Transaction: chain.NewFork(0)},
+			enabledStore,
+			addr1,
+		)
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.To = nil
+
+		assert.NoError(t, pool.validateTx(signTx(tx)))
+	})
+
+	t.Run("accounts without a role can not transact", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(
+			&chain.AllowLists{Transaction: chain.NewFork(0)},
+			NewDefaultMockStore(mockHeader),
+			addr1,
+		)
+
+		assert.ErrorIs(t, pool.validateTx(signTx(newTx(defaultAddr, 0, 1))), ErrTransactionRestricted)
+	})
+
+	t.Run("allow lists are enforced from the activation block", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool(
+			&chain.AllowLists{Deployment: chain.NewFork(10), Transaction: chain.NewFork(10)},
+			NewDefaultMockStore(mockHeader),
+			addr1,
+		)
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.To = nil
+
+		assert.NoError(t, pool.validateTx(signTx(tx)))
+	})
+}
+
+func TestFeeDelegatedTransactions(t *testing.T) {
+	t.Parallel()
+
+	poolSigner := crypto.NewEIP155Signer(100)
+
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	feePayerKey, feePayerAddr := tests.GenerateKeyAndAddr(t)
+
+	setupPool := func() *TxPool {
+		pool, err := newTestPool()
+		if err != nil {
+			t.Fatalf("cannot create txpool - err: %v\n", err)
+		}
+
+		pool.SetSigner(poolSigner)
+		pool.feeDelegation = chain.NewFork(0)
+
+		return pool
+	}
+
+	signTx := func(transaction *types.Transaction, feePayerKey *ecdsa.PrivateKey) *types.Transaction {
+		signedTx, signErr := poolSigner.SignTx(transaction, senderKey)
+		if signErr != nil {
+			t.Fatalf("Unable to sign transaction, %v", signErr)
+		}
+
+		signedTx, signErr = poolSigner.SignFeePayerTx(signedTx, feePayerKey)
+		if signErr != nil {
+			t.Fatalf("Unable to sign transaction as fee payer, %v", signErr)
+		}
+
+		return signedTx
+	}
+
+	newFeeDelegatedTx := func(gasPrice *big.Int) *types.Transaction {
+		tx := newTx(senderAddr, 0, 1)
+		tx.GasPrice = gasPrice
+		tx.Type = types.FeeDelegatedTx
+		tx.FeePayer = &feePayerAddr
+
+		return tx
+	}
+
+	t.Run("fee delegated transaction is accepted", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+
+		tx := signTx(newFeeDelegatedTx(big.NewInt(1)), feePayerKey)
+
+		assert.NoError(t, pool.validateTx(tx))
+		assert.Equal(t, senderAddr, tx.From)
+		assert.Equal(t, feePayerAddr, tx.Payer())
+	})
+
+	t.Run("fee delegated transaction is accepted from the fork", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+
+		tx := signTx(newFeeDelegatedTx(big.NewInt(1)), feePayerKey)
+
+		// the next block is the first one of the pool
+		pool.feeDelegation = nil
+		assert.ErrorIs(t, pool.validateTx(tx.Copy()), ErrTxTypeNotSupported)
+
+		pool.feeDelegation = chain.NewFork(mockHeader.Number + 2)
+		assert.ErrorIs(t, pool.validateTx(tx.Copy()), ErrTxTypeNotSupported)
+
+		pool.feeDelegation = chain.NewFork(mockHeader.Number + 1)
+		assert.NoError(t, pool.validateTx(tx.Copy()))
+	})
+
+	t.Run("fee payer signature is tampered", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+
+		tx := signTx(newFeeDelegatedTx(big.NewInt(1)), feePayerKey)
+		tx.FeePayerR = new(big.Int).Add(tx.FeePayerR, big.NewInt(1))
+
+		assert.ErrorIs(t, pool.validateTx(tx), ErrInvalidFeePayer)
+	})
+
+	t.Run("fee payer can not cover the gas", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+
+		// the mock store balance is below gas * gasPrice
+		tx := signTx(newFeeDelegatedTx(big.NewInt(1000000000000)), feePayerKey)
+
+		assert.ErrorIs(t, pool.validateTx(tx), ErrInsufficientFeePayer)
+	})
+}
+
 /* "Integrated" tests */
 
 // The following tests ensure that the pool's inner event loop
@@ -2729,3 +3446,37 @@ func TestSetSealing(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscribePendingTxs(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+
+	pool.SetSigner(&mockSigner{})
+
+	pool.Start()
+	t.Cleanup(pool.Close)
+
+	hashCh, cancel := pool.SubscribePendingTxs()
+
+	privateTx := newTx(addr1, 0, 1)
+	publicTx := newTx(addr2, 0, 1)
+
+	// the private tx is promoted first, but left out
+	assert.NoError(t, pool.AddPrivateTx(privateTx, 0))
+	assert.NoError(t, pool.addTx(local, publicTx))
+
+	select {
+	case hash := <-hashCh:
+		assert.Equal(t, publicTx.Hash, hash)
+	case <-time.After(10 * time.Second):
+		t.Fatal("pending tx not notified")
+	}
+
+	cancel()
+
+	// the channel is closed once cancelled
+	for range hashCh {
+	}
+}
@@ -65,6 +65,44 @@ func TestRLPMarshall_And_Unmarshall_Transaction(t *testing.T) {
 	}
 }
 
+func TestRLPMarshall_And_Unmarshall_FeeDelegatedTransaction(t *testing.T) {
+	addrTo := StringToAddress("11")
+	feePayer := StringToAddress("12")
+	txn := &Transaction{
+		Nonce:     0,
+		GasPrice:  big.NewInt(11),
+		Gas:       11,
+		To:        &addrTo,
+		Value:     big.NewInt(1),
+		Input:     []byte{1, 2},
+		V:         big.NewInt(25),
+		S:         big.NewInt(26),
+		R:         big.NewInt(27),
+		Type:      FeeDelegatedTx,
+		FeePayer:  &feePayer,
+		FeePayerV: big.NewInt(28),
+		FeePayerR: big.NewInt(29),
+		FeePayerS: big.NewInt(30),
+	}
+	unmarshalledTxn := new(Transaction)
+	marshaledRlp := txn.MarshalRLP()
+
+	if err := unmarshalledTxn.UnmarshalRLP(marshaledRlp); err != nil {
+		t.Fatal(err)
+	}
+
+	unmarshalledTxn.ComputeHash()
+
+	txn.Hash = unmarshalledTxn.Hash
+	if !reflect.DeepEqual(txn, unmarshalledTxn) {
+		t.Fatal("[ERROR] Unmarshalled transaction not equal to base transaction")
+	}
+
+	if unmarshalledTxn.Payer() != feePayer {
+		t.Fatal("[ERROR] Unmarshalled transaction not paid by the fee payer")
+	}
+}
+
 func TestRLPStorage_Marshall_And_Unmarshall_Receipt(t *testing.T) {
 	addr := StringToAddress("11")
 	hash := StringToHash("10")
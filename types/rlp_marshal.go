@@ -186,5 +186,19 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv.Set(arena.NewBigInt(t.R))
 	vv.Set(arena.NewBigInt(t.S))
 
+	// fee delegated transactions extend the legacy fields
+	// with the fee payer and its signature
+	if t.IsFeeDelegated() {
+		if t.FeePayer != nil {
+			vv.Set(arena.NewBytes((*t.FeePayer).Bytes()))
+		} else {
+			vv.Set(arena.NewNull())
+		}
+
+		vv.Set(arena.NewBigInt(t.FeePayerV))
+		vv.Set(arena.NewBigInt(t.FeePayerR))
+		vv.Set(arena.NewBigInt(t.FeePayerS))
+	}
+
 	return vv
 }
@@ -299,8 +299,16 @@ func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
-	if len(elems) < 9 {
-		return fmt.Errorf("incorrect number of elements to decode transaction, expected 9 but found %d", len(elems))
+	switch len(elems) {
+	case 9:
+		t.Type = LegacyTx
+	case 13:
+		t.Type = FeeDelegatedTx
+	default:
+		return fmt.Errorf(
+			"incorrect number of elements to decode transaction, expected 9 or 13 but found %d",
+			len(elems),
+		)
 	}
 
 	p.Hash(t.Hash[:0], v)
@@ -354,5 +362,35 @@ func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
+	if !t.IsFeeDelegated() {
+		return nil
+	}
+
+	// fee payer
+	if vv, _ := elems[9].Bytes(); len(vv) == 20 {
+		feePayer := BytesToAddress(vv)
+		t.FeePayer = &feePayer
+	} else {
+		t.FeePayer = nil
+	}
+
+	// fee payer V
+	t.FeePayerV = new(big.Int)
+	if err = elems[10].GetBigInt(t.FeePayerV); err != nil {
+		return err
+	}
+
+	// fee payer R
+	t.FeePayerR = new(big.Int)
+	if err = elems[11].GetBigInt(t.FeePayerR); err != nil {
+		return err
+	}
+
+	// fee payer S
+	t.FeePayerS = new(big.Int)
+	if err = elems[12].GetBigInt(t.FeePayerS); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -7,6 +7,17 @@ import (
 	"github.com/SECRYPT-2022/SECRYPT/helper/keccak"
 )
 
+// TxType is the type of the transaction
+type TxType byte
+
+const (
+	// LegacyTx is paid by its sender
+	LegacyTx TxType = 0x0
+	// FeeDelegatedTx is signed by both its sender and a fee payer,
+	// which is charged for the gas instead of the sender
+	FeeDelegatedTx TxType = 0x16
+)
+
 type Transaction struct {
 	Nonce    uint64
 	GasPrice *big.Int
@@ -20,6 +31,14 @@ type Transaction struct {
 	Hash     Hash
 	From     Address
 
+	Type TxType
+
+	// Fee payer of a fee delegated transaction, and its signature
+	FeePayer  *Address
+	FeePayerV *big.Int
+	FeePayerR *big.Int
+	FeePayerS *big.Int
+
 	// Cache
 	size atomic.Value
 }
@@ -29,6 +48,20 @@ func (t *Transaction) IsContractCreation() bool {
 	return t.To == nil
 }
 
+// IsFeeDelegated checks if the gas of the tx is paid by a fee payer
+func (t *Transaction) IsFeeDelegated() bool {
+	return t.Type == FeeDelegatedTx
+}
+
+// Payer returns the address charged for the gas of the tx
+func (t *Transaction) Payer() Address {
+	if t.IsFeeDelegated() && t.FeePayer != nil {
+		return *t.FeePayer
+	}
+
+	return t.From
+}
+
 // ComputeHash computes the hash of the transaction
 func (t *Transaction) ComputeHash() *Transaction {
 	ar := marshalArenaPool.Get()
@@ -70,17 +103,39 @@ func (t *Transaction) Copy() *Transaction {
 	tt.Input = make([]byte, len(t.Input))
 	copy(tt.Input[:], t.Input[:])
 
+	if t.FeePayer != nil {
+		feePayer := *t.FeePayer
+		tt.FeePayer = &feePayer
+	}
+
+	if t.FeePayerV != nil {
+		tt.FeePayerV = new(big.Int).Set(t.FeePayerV)
+	}
+
+	if t.FeePayerR != nil {
+		tt.FeePayerR = new(big.Int).Set(t.FeePayerR)
+	}
+
+	if t.FeePayerS != nil {
+		tt.FeePayerS = new(big.Int).Set(t.FeePayerS)
+	}
+
 	return tt
 }
 
 // Cost returns gas * gasPrice + value
 func (t *Transaction) Cost() *big.Int {
-	total := new(big.Int).Mul(t.GasPrice, new(big.Int).SetUint64(t.Gas))
+	total := t.GasCost()
 	total.Add(total, t.Value)
 
 	return total
 }
 
+// GasCost returns gas * gasPrice
+func (t *Transaction) GasCost() *big.Int {
+	return new(big.Int).Mul(t.GasPrice, new(big.Int).SetUint64(t.Gas))
+}
+
 func (t *Transaction) Size() uint64 {
 	if size := t.size.Load(); size != nil {
 		sizeVal, ok := size.(uint64)